@@ -0,0 +1,54 @@
+package compat
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/freeconf/yang/compat"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+// Run "fc-yang compat old.yang new.yang" command. Exits with status 1 if there
+// are any breaking changes.
+func Run() {
+	jsonPtr := flag.Bool("json", false, "write report as JSON.")
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		log.Fatal("Usage: compat [-json] old.yang new.yang")
+	}
+	from := load(flag.Arg(0))
+	to := load(flag.Arg(1))
+	r := compat.Compare(from, to)
+	var err error
+	if *jsonPtr {
+		err = r.WriteJSON(os.Stdout)
+	} else {
+		err = r.WriteText(os.Stdout)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if r.Breaking() {
+		os.Exit(1)
+	}
+}
+
+// load module from file, imports are found next to file or in YANGPATH
+func load(fname string) *meta.Module {
+	data, err := ioutil.ReadFile(fname)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ypath := source.Any(source.Dir(filepath.Dir(fname)), source.Path(os.Getenv("YANGPATH")))
+	m, err := parser.LoadModuleFromString(ypath, string(data))
+	if err != nil {
+		log.Fatalf("could not load %s.\n%s", fname, err)
+	}
+	return m
+}
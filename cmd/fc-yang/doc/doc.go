@@ -2,8 +2,10 @@ package doc
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/val"
 )
 
 type doc struct {
@@ -156,6 +158,22 @@ func (self *doc) appendDef(parent *def, m meta.Definition, level int) (*def, err
 		if len(dt.Enum()) > 0 {
 			d.appendDetail(fmt.Sprintf("Allowed Values: %s", dt.Enum().String()))
 		}
+		if len(dt.Bits()) > 0 {
+			bits := make([]string, len(dt.Bits()))
+			for i, b := range dt.Bits() {
+				bits[i] = b.Ident()
+			}
+			d.appendDetail(fmt.Sprintf("Allowed Bits: %s", strings.Join(bits, ",")))
+		}
+		if dt.Format() == val.FmtBinary || dt.Format() == val.FmtBinaryList {
+			for _, r := range dt.Length() {
+				if r.Min == "" {
+					d.appendDetail(fmt.Sprintf("Length: %s bytes", r.Max))
+				} else {
+					d.appendDetail(fmt.Sprintf("Length: %s bytes", r.String()))
+				}
+			}
+		}
 		if dets, valid := m.(meta.HasDetails); valid {
 			if !dets.Config() {
 				d.appendDetail("r/o")
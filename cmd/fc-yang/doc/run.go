@@ -9,11 +9,14 @@ import (
 	"strings"
 
 	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/jsonschema"
 	"github.com/freeconf/yang/meta"
 	"github.com/freeconf/yang/node"
 	"github.com/freeconf/yang/nodeutil"
+	"github.com/freeconf/yang/openapi"
 	"github.com/freeconf/yang/parser"
 	"github.com/freeconf/yang/source"
+	"github.com/freeconf/yang/tree"
 )
 
 // Run "freeconf get ..." command
@@ -24,10 +27,14 @@ func Run() {
 
 	moduleName := flag.String("module", "", "Module to be documented.")
 	tmplPtr := flag.String("f", "none", "output format. available formats include "+
-		"html, md, json or dot.")
+		"html, md, json, dot, openapi, json-schema or tree.")
 	exportTemplatePtr := flag.Bool("x", false, "export the builting template to stdout. You can then edit "+
 		"template and pass it back in using -t option.  Be sure to pick correct format.")
 	useTemplatePtr := flag.String("t", "", "Use the template instead of the builtin template.")
+	configOnlyPtr := flag.Bool("config", false, "only include config definitions. Only for json-schema format.")
+	treeDepthPtr := flag.Int("tree-depth", 0, "only write this many levels of nodes. Only for tree format.")
+	treePathPtr := flag.String("tree-path", "", "only write nodes along schema path like /a/b and below it. Only for tree format.")
+	treeLineLengthPtr := flag.Int("tree-line-length", 0, "fold lines longer than this. Only for tree format.")
 	titlePtr := flag.String("title", "RESTful API", "Title.")
 	imageLinkPtr := flag.String("img-link", "", "Link to image for HTML templates. Default is (module-name).svg.")
 	flag.Var(&on, "on", "enable this feature.  You can specify -on multiple times to enable multiple features. You cannot specify both on and off however.")
@@ -56,6 +63,9 @@ func Run() {
 		builder = &dot{}
 	}
 	if *exportTemplatePtr {
+		if builder == nil {
+			log.Fatalf("%s format has no template", *tmplPtr)
+		}
 		if _, err := fmt.Print(builder.builtinTemplate()); err != nil {
 			log.Fatal(err)
 		}
@@ -79,10 +89,30 @@ func Run() {
 	ypath := source.Path(os.Getenv("YANGPATH"))
 	m, err = parser.LoadModuleWithOptions(ypath, *moduleName, options)
 	if err != nil {
-		log.Fatalf("could not load %s. %s", *moduleName, err)
+		log.Fatalf("could not load %s.\n%s", *moduleName, err)
 	}
 
-	if *tmplPtr == "none" {
+	if *tmplPtr == "openapi" {
+		w := &openapi.Wtr{Out: os.Stdout, Title: *titlePtr, Pretty: true}
+		if err = w.Write(m); err != nil {
+			log.Fatal(err)
+		}
+	} else if *tmplPtr == "json-schema" {
+		w := &jsonschema.Wtr{Out: os.Stdout, ConfigOnly: *configOnlyPtr, Pretty: true}
+		if err = w.Write(m); err != nil {
+			log.Fatal(err)
+		}
+	} else if *tmplPtr == "tree" {
+		w := &tree.Wtr{
+			Out:        os.Stdout,
+			Depth:      *treeDepthPtr,
+			Path:       *treePathPtr,
+			LineLength: *treeLineLengthPtr,
+		}
+		if err = w.Write(m); err != nil {
+			log.Fatal(err)
+		}
+	} else if *tmplPtr == "none" {
 		ymod := parser.RequireModule(ypath, "fc-yang")
 		n := &nodeutil.JSONWtr{Out: os.Stdout, Pretty: true}
 		if err = nodeutil.Schema(ymod, m).Root().InsertInto(n.Node()).LastErr; err != nil {
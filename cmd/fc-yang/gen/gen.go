@@ -0,0 +1,43 @@
+package gen
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/freeconf/yang/gogen"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+// Run "fc-yang gen go ..." command
+func Run() {
+	if len(os.Args) <= 1 {
+		log.Fatal("Usage: gen [go] ...")
+	}
+	lang := os.Args[1]
+	os.Args = append([]string{os.Args[0]}, os.Args[2:]...)
+
+	moduleName := flag.String("module", "", "Module to generate source for.")
+	pkg := flag.String("package", "", "Package name of generated source. Default is module name.")
+	flag.Parse()
+
+	if *moduleName == "" {
+		log.Fatal("missing module name")
+	}
+	ypath := source.Path(os.Getenv("YANGPATH"))
+	m, err := parser.LoadModule(ypath, *moduleName)
+	if err != nil {
+		log.Fatalf("could not load %s.\n%s", *moduleName, err)
+	}
+	switch lang {
+	case "go":
+		w := &gogen.Wtr{Out: os.Stdout, Package: *pkg}
+		err = w.Write(m)
+	default:
+		log.Fatalf("'%s' is not a supported language", lang)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,71 @@
+package lint
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/freeconf/yang/lint"
+	"github.com/freeconf/yang/source"
+)
+
+// Run "fc-yang lint module.yang" command. Exits with status 1 if there are
+// any problems at error level.
+func Run() {
+	jsonPtr := flag.Bool("json", false, "write report as JSON.")
+	levels := make(levelsFlag)
+	flag.Var(levels, "rule", "set level of a rule as name=level where level is off, warning or error. May be repeated.")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatal("Usage: lint [-json] [-rule name=level]... module.yang")
+	}
+	fname := flag.Arg(0)
+	ypath := source.Any(source.Dir(filepath.Dir(fname)), source.Path(os.Getenv("YANGPATH")))
+	name := strings.TrimSuffix(filepath.Base(fname), filepath.Ext(fname))
+	r, err := lint.Lint(ypath, name, lint.Options{Levels: levels})
+	if err != nil {
+		log.Fatalf("could not load %s.\n%s", fname, err)
+	}
+	if *jsonPtr {
+		err = r.WriteJSON(os.Stdout)
+	} else {
+		err = r.WriteText(os.Stdout)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if r.HasErrors() {
+		os.Exit(1)
+	}
+}
+
+type levelsFlag map[lint.Rule]lint.Level
+
+func (f levelsFlag) String() string {
+	var s []string
+	for rule, level := range f {
+		s = append(s, fmt.Sprintf("%s=%s", rule, level))
+	}
+	return strings.Join(s, ",")
+}
+
+func (f levelsFlag) Set(s string) error {
+	eq := strings.IndexRune(s, '=')
+	if eq < 0 {
+		return fmt.Errorf("expected name=level but got %q", s)
+	}
+	rule := lint.Rule(s[:eq])
+	if _, known := lint.DefaultLevels[rule]; !known {
+		return fmt.Errorf("unknown rule %q", rule)
+	}
+	level, err := lint.ParseLevel(s[eq+1:])
+	if err != nil {
+		return err
+	}
+	f[rule] = level
+	return nil
+}
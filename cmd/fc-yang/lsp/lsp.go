@@ -0,0 +1,23 @@
+package lsp
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/freeconf/yang/lsp"
+)
+
+// Run "fc-yang lsp" command. Editors start this and talk Language Server
+// Protocol over stdin and stdout. Imported modules are found next to the file
+// being edited and then in YANGPATH.
+func Run() {
+	yangPath := flag.String("ypath", os.Getenv("YANGPATH"), "directories to find imported modules in separated by ':'.")
+	flag.Parse()
+
+	// stdout is for protocol so anything else has to go to stderr
+	log.SetOutput(os.Stderr)
+	if err := lsp.NewServer(*yangPath).Serve(os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"log"
 	"os"
 
+	"github.com/freeconf/yang/cmd/fc-yang/compat"
 	"github.com/freeconf/yang/cmd/fc-yang/doc"
+	"github.com/freeconf/yang/cmd/fc-yang/gen"
 	"github.com/freeconf/yang/cmd/fc-yang/get"
+	"github.com/freeconf/yang/cmd/fc-yang/lint"
+	"github.com/freeconf/yang/cmd/fc-yang/lsp"
+	"github.com/freeconf/yang/cmd/fc-yang/print"
 )
 
 // fc-yang is your one stop command utility for all things yang.  It's a bit
@@ -14,7 +19,7 @@ import (
 // follows in the evolution of go's "go" command that went thru same path.
 func main() {
 	if len(os.Args) <= 1 {
-		log.Fatal("Usage: [doc, get] ...")
+		log.Fatal("Usage: [compat, doc, gen, get, lint, lsp, print] ...")
 	}
 	cmd := os.Args[1]
 
@@ -28,10 +33,20 @@ func main() {
 
 	// dispatch to appropriate command
 	switch cmd {
+	case "compat":
+		compat.Run()
 	case "doc":
 		doc.Run()
+	case "gen":
+		gen.Run()
 	case "get":
 		get.Run()
+	case "lint":
+		lint.Run()
+	case "lsp":
+		lsp.Run()
+	case "print":
+		print.Run()
 	default:
 		log.Fatalf("'%s' is not a recognized command", os.Args[1])
 	}
@@ -0,0 +1,71 @@
+package print
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+// Run "fc-yang print ..." command
+func Run() {
+	var on featureParams
+	var off featureParams
+
+	moduleName := flag.String("module", "", "Module to be printed.")
+	resolvedPtr := flag.Bool("resolved", false, "print effective schema with groupings expanded, "+
+		"augments and deviations applied and disabled features removed.")
+	yinPtr := flag.Bool("yin", false, "print in YIN, the XML form of YANG.")
+	flag.Var(&on, "on", "enable this feature.  You can specify -on multiple times to enable multiple features. You cannot specify both on and off however.")
+	flag.Var(&off, "off", "disable this feature.  You can specify -off multiple times to disable multiple features. You cannot specify both on and off however.")
+
+	flag.Parse()
+
+	if *moduleName == "" {
+		log.Fatal("missing module name")
+	}
+
+	var fs meta.FeatureSet
+	if len(off) > 0 {
+		if len(on) > 0 {
+			log.Fatal("You cannot specify both on and off")
+		}
+		fs = meta.FeaturesOff(off)
+	} else if len(on) > 0 {
+		fs = meta.FeaturesOn(on)
+	}
+	options := parser.Options{
+		Features:   fs,
+		Uncompiled: !*resolvedPtr,
+	}
+	ypath := source.Path(os.Getenv("YANGPATH"))
+	m, err := parser.LoadModuleWithOptions(ypath, *moduleName, options)
+	if err != nil {
+		log.Fatalf("could not load %s.\n%s", *moduleName, err)
+	}
+	if *yinPtr {
+		w := &meta.YinWtr{Out: os.Stdout, Resolved: *resolvedPtr}
+		err = w.Write(m)
+	} else {
+		w := &meta.YangWtr{Out: os.Stdout, Resolved: *resolvedPtr}
+		err = w.Write(m)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+type featureParams []string
+
+func (f *featureParams) String() string {
+	return strings.Join([]string(*f), ", ")
+}
+
+func (f *featureParams) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
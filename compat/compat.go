@@ -0,0 +1,456 @@
+package compat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/freeconf/yang/meta"
+)
+
+// Rule identifies the kind of change between revisions of a module
+type Rule string
+
+// Changes that are checked for. Most follow directly from the update rules of
+// RFC 7950 Sec. 11
+const (
+	NodeRemoved          Rule = "node-removed"
+	NodeAdded            Rule = "node-added"
+	KindChanged          Rule = "kind-changed"
+	TypeChanged          Rule = "type-changed"
+	RangeNarrowed        Rule = "range-narrowed"
+	LengthNarrowed       Rule = "length-narrowed"
+	PatternChanged       Rule = "pattern-changed"
+	EnumRemoved          Rule = "enum-removed"
+	EnumValueChanged     Rule = "enum-value-changed"
+	BitRemoved           Rule = "bit-removed"
+	BitPositionChanged   Rule = "bit-position-changed"
+	IdentityRemoved      Rule = "identity-removed"
+	KeyChanged           Rule = "key-changed"
+	MandatoryAdded       Rule = "mandatory-added"
+	MinElementsIncreased Rule = "min-elements-increased"
+	MaxElementsDecreased Rule = "max-elements-decreased"
+	ConfigChanged        Rule = "config-changed"
+	DefaultChanged       Rule = "default-changed"
+	UnitsChanged         Rule = "units-changed"
+	ConstraintAdded      Rule = "constraint-added"
+	StatusReverted       Rule = "status-reverted"
+	NamespaceChanged     Rule = "namespace-changed"
+	DefinitionRemoved    Rule = "definition-removed"
+	RevisionNotAdded     Rule = "revision-not-added"
+)
+
+// Change is a single difference between two revisions of a module
+type Change struct {
+	// Path is schema path of node or for top-level statements like
+	// identities or typedefs, the keyword and name
+	Path     string `json:"path"`
+	Rule     Rule   `json:"rule"`
+	Breaking bool   `json:"breaking"`
+	Message  string `json:"message"`
+}
+
+func (c *Change) String() string {
+	kind := "ok"
+	if c.Breaking {
+		kind = "breaking"
+	}
+	return fmt.Sprintf("%s %s %s: %s", kind, c.Rule, c.Path, c.Message)
+}
+
+// Report is everything that changed between two revisions of a module
+type Report struct {
+	Module  string    `json:"module"`
+	From    string    `json:"from,omitempty"`
+	To      string    `json:"to,omitempty"`
+	Changes []*Change `json:"changes"`
+}
+
+// Breaking is true if clients of the old revision may not work with the new
+// revision
+func (r *Report) Breaking() bool {
+	for _, c := range r.Changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteText writes a line for each change
+func (r *Report) WriteText(out io.Writer) error {
+	for _, c := range r.Changes {
+		if _, err := fmt.Fprintln(out, c.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes report for other tools to read
+func (r *Report) WriteJSON(out io.Writer) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Compare finds changes from one revision of a module to another. Both
+// modules must be compiled.
+//
+// Schema nodes are matched by name so a node that was renamed or moved is
+// reported as removed and added.  Expressions in "must" and "when" statements
+// cannot be compared for whether they were relaxed so any new or changed
+// expressions are reported as breaking.
+func Compare(from *meta.Module, to *meta.Module) *Report {
+	c := &comparer{
+		report: &Report{Module: to.Ident()},
+		seen:   make(map[meta.Definition]bool),
+	}
+	if rev := from.Revision(); rev != nil {
+		c.report.From = rev.Ident()
+	}
+	if rev := to.Revision(); rev != nil {
+		c.report.To = rev.Ident()
+	}
+	c.module(from, to)
+	return c.report
+}
+
+type comparer struct {
+	report *Report
+
+	// recursive schemas would otherwise never end
+	seen map[meta.Definition]bool
+}
+
+func (c *comparer) add(path string, rule Rule, breaking bool, msg string, args ...interface{}) {
+	c.report.Changes = append(c.report.Changes, &Change{
+		Path:     path,
+		Rule:     rule,
+		Breaking: breaking,
+		Message:  fmt.Sprintf(msg, args...),
+	})
+}
+
+func (c *comparer) module(a *meta.Module, b *meta.Module) {
+	if a.Namespace() != b.Namespace() {
+		c.add(b.Ident(), NamespaceChanged, true, "namespace changed from %q to %q", a.Namespace(), b.Namespace())
+	}
+	if c.report.To == "" || c.report.To <= c.report.From {
+		c.add(b.Ident(), RevisionNotAdded, false, "no new revision statement")
+	}
+	c.removed("feature", keys(a.Features()), keys(b.Features()))
+	c.removed("typedef", keys(a.Typedefs()), keys(b.Typedefs()))
+	c.removed("grouping", keys(a.Groupings()), keys(b.Groupings()))
+	for _, ident := range keys(a.Identities()) {
+		if _, found := b.Identities()[ident]; !found {
+			c.add("identity "+ident, IdentityRemoved, true, "identity removed")
+		}
+	}
+	c.definitions(a, b)
+}
+
+// removed reports definitions clients or other modules may refer to by name
+func (c *comparer) removed(keyword string, a []string, b []string) {
+	for _, ident := range a {
+		if !contains(b, ident) {
+			c.add(keyword+" "+ident, DefinitionRemoved, true, "%s removed", keyword)
+		}
+	}
+}
+
+// definitions compares data definitions, actions and notifications
+func (c *comparer) definitions(a meta.HasDataDefinitions, b meta.HasDataDefinitions) {
+	c.members(a.DataDefinitions(), b.DataDefinitions())
+	if x, valid := a.(meta.HasActions); valid {
+		y := b.(meta.HasActions)
+		c.members(actions(x.Actions()), actions(y.Actions()))
+	}
+	if x, valid := a.(meta.HasNotifications); valid {
+		y := b.(meta.HasNotifications)
+		c.members(notifications(x.Notifications()), notifications(y.Notifications()))
+	}
+}
+
+func (c *comparer) members(a []meta.Definition, b []meta.Definition) {
+	index := make(map[string]meta.Definition)
+	for _, y := range b {
+		index[y.Ident()] = y
+	}
+	for _, x := range a {
+		y, found := index[x.Ident()]
+		if !found {
+			c.add(meta.SchemaPath(x), NodeRemoved, true, "%s removed", keyword(x))
+			continue
+		}
+		c.definition(x, y)
+		delete(index, x.Ident())
+	}
+	for _, y := range b {
+		if _, added := index[y.Ident()]; !added {
+			continue
+		}
+		if mandatory(y) {
+			c.add(meta.SchemaPath(y), MandatoryAdded, true, "mandatory %s added", keyword(y))
+		} else {
+			c.add(meta.SchemaPath(y), NodeAdded, false, "%s added", keyword(y))
+		}
+	}
+}
+
+func (c *comparer) definition(a meta.Definition, b meta.Definition) {
+	path := meta.SchemaPath(b)
+	if keyword(a) != keyword(b) {
+		c.add(path, KindChanged, true, "changed from %s to %s", keyword(a), keyword(b))
+		return
+	}
+	c.details(path, a, b)
+	if x, valid := a.(meta.HasType); valid {
+		c.typ(path, x.Type(), b.(meta.HasType).Type())
+	}
+	switch x := a.(type) {
+	case *meta.Choice:
+		y := b.(*meta.Choice)
+		var acases, bcases []meta.Definition
+		for _, ident := range x.CaseIdents() {
+			acases = append(acases, x.Cases()[ident])
+		}
+		for _, ident := range y.CaseIdents() {
+			bcases = append(bcases, y.Cases()[ident])
+		}
+		c.members(acases, bcases)
+	case *meta.Rpc:
+		y := b.(*meta.Rpc)
+		c.members(rpcIO(x), rpcIO(y))
+	case *meta.List:
+		y := b.(*meta.List)
+		akeys, bkeys := keyIdents(x), keyIdents(y)
+		if akeys != bkeys {
+			c.add(path, KeyChanged, true, "key changed from %q to %q", akeys, bkeys)
+		}
+	}
+	if x, valid := a.(meta.HasDataDefinitions); valid {
+		if _, isRpc := a.(*meta.Rpc); isRpc || c.seen[a] {
+			return
+		}
+		c.seen[a] = true
+		c.definitions(x, b.(meta.HasDataDefinitions))
+	}
+}
+
+// details compares statements common to many definitions
+func (c *comparer) details(path string, a meta.Definition, b meta.Definition) {
+	if x, valid := a.(meta.HasConfig); valid {
+		y := b.(meta.HasConfig)
+		if x.Config() && !y.Config() {
+			c.add(path, ConfigChanged, true, "changed from config to read-only")
+		} else if !x.Config() && y.Config() {
+			c.add(path, ConfigChanged, false, "changed from read-only to config")
+		}
+	}
+	if x, valid := a.(meta.HasMandatory); valid {
+		if !x.Mandatory() && b.(meta.HasMandatory).Mandatory() {
+			c.add(path, MandatoryAdded, true, "changed to mandatory")
+		}
+	}
+	if x, valid := a.(meta.HasMinMax); valid {
+		y := b.(meta.HasMinMax)
+		if y.MinElements() > x.MinElements() {
+			c.add(path, MinElementsIncreased, true, "min-elements increased from %d to %d", x.MinElements(), y.MinElements())
+		}
+		xmax, ymax := maxElements(a), maxElements(b)
+		if ymax >= 0 && (xmax < 0 || ymax < xmax) {
+			c.add(path, MaxElementsDecreased, true, "max-elements decreased to %d", ymax)
+		}
+	}
+	if x, valid := a.(meta.HasUnits); valid {
+		if y := b.(meta.HasUnits); x.Units() != "" && x.Units() != y.Units() {
+			c.add(path, UnitsChanged, true, "units changed from %q to %q", x.Units(), y.Units())
+		}
+	}
+	if x, valid := a.(meta.HasDefault); valid {
+		y := b.(meta.HasDefault)
+		if x.HasDefault() {
+			if !y.HasDefault() {
+				c.add(path, DefaultChanged, true, "default removed")
+			} else if fmt.Sprint(x.Default()) != fmt.Sprint(y.Default()) {
+				c.add(path, DefaultChanged, true, "default changed from %v to %v", x.Default(), y.Default())
+			}
+		}
+	}
+	if x, valid := a.(meta.HasWhen); valid {
+		if y := b.(meta.HasWhen); y.When() != nil {
+			if x.When() == nil {
+				c.add(path, ConstraintAdded, true, "when %q added", y.When().Expression())
+			} else if x.When().Expression() != y.When().Expression() {
+				c.add(path, ConstraintAdded, true, "when changed from %q to %q", x.When().Expression(), y.When().Expression())
+			}
+		}
+	}
+	if x, valid := a.(meta.HasMusts); valid {
+		var existing []string
+		for _, must := range x.Musts() {
+			existing = append(existing, must.Expression())
+		}
+		for _, must := range b.(meta.HasMusts).Musts() {
+			if !contains(existing, must.Expression()) {
+				c.add(path, ConstraintAdded, true, "must %q added", must.Expression())
+			}
+		}
+	}
+	if x, valid := a.(meta.HasStatus); valid {
+		if y := b.(meta.HasStatus); y.Status() < x.Status() {
+			c.add(path, StatusReverted, true, "status changed from %s to %s", status(x.Status()), status(y.Status()))
+		}
+	}
+}
+
+// mandatory is true for nodes that clients must supply
+func mandatory(def meta.Definition) bool {
+	if x, valid := def.(meta.HasMandatory); valid && x.Mandatory() {
+		return true
+	}
+	if x, valid := def.(meta.HasMinMax); valid && x.MinElements() > 0 {
+		return true
+	}
+	// non-presence containers with mandatory nodes are mandatory too
+	if x, valid := def.(*meta.Container); valid && x.Presence() == "" {
+		for _, child := range x.DataDefinitions() {
+			if mandatory(child) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maxElements is -1 when unbounded
+func maxElements(def meta.Definition) int {
+	if x, valid := def.(meta.HasUnbounded); valid && x.Unbounded() {
+		return -1
+	}
+	x := def.(meta.HasMinMax)
+	if !x.IsMaxElementsSet() {
+		return -1
+	}
+	return x.MaxElements()
+}
+
+func keyword(def meta.Definition) string {
+	switch def.(type) {
+	case *meta.Container:
+		return "container"
+	case *meta.List:
+		return "list"
+	case *meta.Leaf:
+		return "leaf"
+	case *meta.LeafList:
+		return "leaf-list"
+	case *meta.Any:
+		return "anydata"
+	case *meta.Choice:
+		return "choice"
+	case *meta.ChoiceCase:
+		return "case"
+	case *meta.Rpc:
+		if _, isModule := def.Parent().(*meta.Module); isModule {
+			return "rpc"
+		}
+		return "action"
+	case *meta.RpcInput:
+		return "input"
+	case *meta.RpcOutput:
+		return "output"
+	case *meta.Notification:
+		return "notification"
+	}
+	return fmt.Sprintf("%T", def)
+}
+
+func status(s meta.Status) string {
+	switch s {
+	case meta.Deprecated:
+		return "deprecated"
+	case meta.Obsolete:
+		return "obsolete"
+	}
+	return "current"
+}
+
+func keyIdents(l *meta.List) string {
+	var idents []string
+	for _, k := range l.KeyMeta() {
+		idents = append(idents, k.Ident())
+	}
+	return strings.Join(idents, " ")
+}
+
+func rpcIO(rpc *meta.Rpc) []meta.Definition {
+	var defs []meta.Definition
+	if rpc.Input() != nil {
+		defs = append(defs, rpc.Input())
+	}
+	if rpc.Output() != nil {
+		defs = append(defs, rpc.Output())
+	}
+	return defs
+}
+
+func actions(m map[string]*meta.Rpc) []meta.Definition {
+	var defs []meta.Definition
+	for _, ident := range keys(m) {
+		defs = append(defs, m[ident])
+	}
+	return defs
+}
+
+func notifications(m map[string]*meta.Notification) []meta.Definition {
+	var defs []meta.Definition
+	for _, ident := range keys(m) {
+		defs = append(defs, m[ident])
+	}
+	return defs
+}
+
+func keys(m interface{}) []string {
+	var idents []string
+	switch x := m.(type) {
+	case map[string]*meta.Rpc:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	case map[string]*meta.Notification:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	case map[string]*meta.Identity:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	case map[string]*meta.Feature:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	case map[string]*meta.Typedef:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	case map[string]*meta.Grouping:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	}
+	sort.Strings(idents)
+	return idents
+}
+
+func contains(l []string, s string) bool {
+	for _, candidate := range l {
+		if candidate == s {
+			return true
+		}
+	}
+	return false
+}
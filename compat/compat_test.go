@@ -0,0 +1,57 @@
+package compat
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+var updateFlag = flag.Bool("update", false, "update golden files instead of verifying against them")
+
+func TestCompare(t *testing.T) {
+	old := parser.RequireModule(source.Dir("./testdata/old"), "car")
+	new := parser.RequireModule(source.Dir("./testdata/new"), "car")
+	r := Compare(old, new)
+	fc.AssertEqual(t, true, r.Breaking())
+	var actual bytes.Buffer
+	if err := r.WriteText(&actual); err != nil {
+		t.Fatal(err)
+	}
+	fc.Gold(t, *updateFlag, actual.Bytes(), "testdata/gold/car.txt")
+
+	same := Compare(old, old)
+	fc.AssertEqual(t, false, same.Breaking())
+	fc.AssertEqual(t, 1, len(same.Changes))
+	fc.AssertEqual(t, RevisionNotAdded, same.Changes[0].Rule)
+}
+
+func TestReportJSON(t *testing.T) {
+	r := &Report{
+		Module: "car",
+		From:   "2023-01-01",
+		To:     "2024-01-01",
+		Changes: []*Change{
+			{Path: "car/engine", Rule: NodeRemoved, Breaking: true, Message: "container removed"},
+		},
+	}
+	var actual bytes.Buffer
+	if err := r.WriteJSON(&actual); err != nil {
+		t.Fatal(err)
+	}
+	fc.Gold(t, *updateFlag, actual.Bytes(), "testdata/gold/report.json")
+}
+
+func TestCompareQualified(t *testing.T) {
+	old := parser.RequireModule(source.Dir("./testdata/qualified/old"), "tire")
+	new := parser.RequireModule(source.Dir("./testdata/qualified/new"), "tire")
+	r := Compare(old, new)
+	var actual bytes.Buffer
+	if err := r.WriteText(&actual); err != nil {
+		t.Fatal(err)
+	}
+	fc.Gold(t, *updateFlag, actual.Bytes(), "testdata/gold/qualified.txt")
+}
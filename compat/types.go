@@ -0,0 +1,163 @@
+package compat
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/val"
+)
+
+// typ checks new type accepts all the values the old type did
+func (c *comparer) typ(path string, a *meta.Type, b *meta.Type) {
+	if a.Format() != b.Format() {
+		c.add(path, TypeChanged, true, "type changed from %s to %s", a.Ident(), b.Ident())
+		return
+	}
+	switch a.Format().Single() {
+	case val.FmtInt8, val.FmtInt16, val.FmtInt32, val.FmtInt64,
+		val.FmtUInt8, val.FmtUInt16, val.FmtUInt32, val.FmtUInt64:
+		if narrowed(a.Range(), b.Range()) {
+			c.add(path, RangeNarrowed, true, "range narrowed from %s to %s", rangeString(a.Range()), rangeString(b.Range()))
+		}
+	case val.FmtDecimal64:
+		if a.FractionDigits() != b.FractionDigits() {
+			c.add(path, TypeChanged, true, "fraction-digits changed from %d to %d", a.FractionDigits(), b.FractionDigits())
+		}
+		if narrowed(a.Range(), b.Range()) {
+			c.add(path, RangeNarrowed, true, "range narrowed from %s to %s", rangeString(a.Range()), rangeString(b.Range()))
+		}
+	case val.FmtString, val.FmtBinary:
+		if narrowed(a.Length(), b.Length()) {
+			c.add(path, LengthNarrowed, true, "length narrowed from %s to %s", rangeString(a.Length()), rangeString(b.Length()))
+		}
+		var existing []string
+		for _, p := range a.Patterns() {
+			existing = append(existing, p.Pattern)
+		}
+		for _, p := range b.Patterns() {
+			if !contains(existing, p.Pattern) {
+				c.add(path, PatternChanged, true, "pattern %q added", p.Pattern)
+			}
+		}
+	case val.FmtEnum:
+		for _, x := range a.Enum() {
+			y, found := b.Enum().ByLabel(x.Label)
+			if !found {
+				c.add(path, EnumRemoved, true, "enum %s removed", x.Label)
+			} else if x.Id != y.Id {
+				c.add(path, EnumValueChanged, true, "value of enum %s changed from %d to %d", x.Label, x.Id, y.Id)
+			}
+		}
+	case val.FmtBits:
+		positions := make(map[string]int)
+		for _, y := range b.Bits() {
+			positions[y.Ident()] = y.Position
+		}
+		for _, x := range a.Bits() {
+			pos, found := positions[x.Ident()]
+			if !found {
+				c.add(path, BitRemoved, true, "bit %s removed", x.Ident())
+			} else if pos != x.Position {
+				c.add(path, BitPositionChanged, true, "position of bit %s changed from %d to %d", x.Ident(), x.Position, pos)
+			}
+		}
+	case val.FmtIdentityRef:
+		if a.Base() == nil || b.Base() == nil {
+			return
+		}
+		// identities with same ident may be in different modules
+		allowed := make(map[string]bool)
+		for _, y := range b.Base().AllDerived() {
+			allowed[qualifiedIdentity(y)] = true
+		}
+		var idents []string
+		for _, x := range a.Base().AllDerived() {
+			idents = append(idents, qualifiedIdentity(x))
+		}
+		sort.Strings(idents)
+		for _, ident := range idents {
+			if !allowed[ident] {
+				c.add(path, IdentityRemoved, true, "identity %s no longer allowed", ident)
+			}
+		}
+	case val.FmtLeafRef:
+		if a.Path() != b.Path() {
+			c.add(path, TypeChanged, true, "leafref path changed from %q to %q", a.Path(), b.Path())
+		}
+	case val.FmtUnion:
+		if len(a.Union()) != len(b.Union()) {
+			c.add(path, TypeChanged, true, "union changed from %d to %d types", len(a.Union()), len(b.Union()))
+			return
+		}
+		for i, u := range a.Union() {
+			c.typ(path, u, b.Union()[i])
+		}
+	}
+}
+
+func qualifiedIdentity(y *meta.Identity) string {
+	return y.Parent().(*meta.Module).Ident() + ":" + y.Ident()
+}
+
+// interval of all the ranges together.  Ranges on a type are each from a
+// typedef or the type itself so a value must be in all of them.  Nil bound
+// means there is no limit other than the limits of the type.
+type interval struct {
+	min *big.Rat
+	max *big.Rat
+}
+
+func effective(ranges []*meta.Range) interval {
+	var i interval
+	for _, r := range ranges {
+		max := bound(r.Max)
+		min := max
+		if r.Min != "" {
+			min = bound(r.Min)
+		}
+		if min != nil && (i.min == nil || min.Cmp(i.min) > 0) {
+			i.min = min
+		}
+		if max != nil && (i.max == nil || max.Cmp(i.max) < 0) {
+			i.max = max
+		}
+	}
+	return i
+}
+
+func bound(s string) *big.Rat {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		// "min" or "max"
+		return nil
+	}
+	return r
+}
+
+// narrowed if new ranges do not allow a value the old ranges did
+func narrowed(a []*meta.Range, b []*meta.Range) bool {
+	x, y := effective(a), effective(b)
+	if y.min != nil && (x.min == nil || y.min.Cmp(x.min) > 0) {
+		return true
+	}
+	return y.max != nil && (x.max == nil || y.max.Cmp(x.max) < 0)
+}
+
+func rangeString(ranges []*meta.Range) string {
+	if len(ranges) == 0 {
+		return "none"
+	}
+	s := ""
+	for i, r := range ranges {
+		if i > 0 {
+			s += " and "
+		}
+		if r.Min == "" {
+			s += r.Max
+		} else {
+			s += r.String()
+		}
+	}
+	return s
+}
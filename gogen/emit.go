@@ -0,0 +1,513 @@
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/val"
+)
+
+// scalar is how a YANG type maps to Go and back
+type scalar struct {
+	goType   string
+	valType  string
+	listType string
+
+	// element type of list when Go slice cannot be converted to val list
+	// directly
+	listElem string
+}
+
+var scalars = map[val.Format]scalar{
+	val.FmtString:      {goType: "string", valType: "val.String", listType: "val.StringList"},
+	val.FmtBool:        {goType: "bool", valType: "val.Bool", listType: "val.BoolList"},
+	val.FmtInt8:        {goType: "int8", valType: "val.Int8", listType: "val.Int8List"},
+	val.FmtUInt8:       {goType: "uint8", valType: "val.UInt8", listType: "val.UInt8List"},
+	val.FmtInt16:       {goType: "int16", valType: "val.Int16", listType: "val.Int16List"},
+	val.FmtUInt16:      {goType: "uint16", valType: "val.UInt16", listType: "val.UInt16List"},
+	val.FmtInt32:       {goType: "int32", valType: "val.Int32", listType: "val.Int32List", listElem: "int"},
+	val.FmtUInt32:      {goType: "uint32", valType: "val.UInt32", listType: "val.UInt32List", listElem: "uint"},
+	val.FmtInt64:       {goType: "int64", valType: "val.Int64", listType: "val.Int64List"},
+	val.FmtUInt64:      {goType: "uint64", valType: "val.UInt64", listType: "val.UInt64List"},
+	val.FmtDecimal64:   {goType: "val.Decimal64", valType: "val.Decimal64", listType: "val.Decimal64List"},
+	val.FmtEnum:        {valType: "val.Enum", listType: "val.EnumList"},
+	val.FmtIdentityRef: {valType: "val.IdentRef", listType: "val.IdentRefList"},
+}
+
+type printer struct {
+	bytes.Buffer
+}
+
+func (p *printer) f(format string, args ...interface{}) {
+	fmt.Fprintf(&p.Buffer, format, args...)
+	p.WriteByte('\n')
+}
+
+func (p *printer) comment(text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		p.f("// %s", strings.TrimSpace(line))
+	}
+}
+
+func (b *builder) generate() string {
+	var p printer
+	b.hooksStruct(&p)
+	if b.hasIdentRef || len(b.module.Identities()) > 0 {
+		b.identities(&p)
+	}
+	for _, e := range b.enumOrder {
+		b.enumType(&p, e)
+	}
+	for _, s := range b.order {
+		b.structType(&p, s)
+		b.nodeFunc(&p, s)
+		if _, isList := s.def.(*meta.List); isList {
+			b.listFunc(&p, s)
+		}
+	}
+	return p.String()
+}
+
+func (b *builder) hooksStruct(p *printer) {
+	p.f("// Hooks implement the rpcs, actions and notifications of %s. Any", b.module.Ident())
+	p.f("// hook left nil is reported as not implemented when called.")
+	p.f("type Hooks struct {")
+	for i, h := range b.hooks {
+		if i > 0 {
+			p.f("")
+		}
+		if h.action {
+			p.f("// %s implements %s", h.name, h.path)
+		} else {
+			p.f("// %s sends events of %s to subscriber until closed", h.name, h.path)
+		}
+		if h.desc != "" {
+			p.f("//")
+			p.comment(h.desc)
+		}
+		p.f("%s %s", h.name, h.signature())
+	}
+	p.f("}")
+	p.f("")
+}
+
+func (h *hook) signature() string {
+	params := "x *" + h.parent.name
+	if !h.action {
+		return fmt.Sprintf("func(%s, send func(*%s)) (node.NotifyCloser, error)", params, h.msg.name)
+	}
+	if h.input != nil {
+		params += ", in *" + h.input.name
+	}
+	if h.output == nil {
+		return fmt.Sprintf("func(%s) error", params)
+	}
+	return fmt.Sprintf("func(%s) (*%s, error)", params, h.output.name)
+}
+
+func (b *builder) identities(p *printer) {
+	p.f("// Identity is the value of an identityref")
+	p.f("type Identity string")
+	p.f("")
+	idents := sortedKeys(b.module.Identities())
+	if len(idents) == 0 {
+		return
+	}
+	p.f("const (")
+	for _, ident := range idents {
+		p.f("Identity%s Identity = %q", camel(ident), ident)
+	}
+	p.f(")")
+	p.f("")
+}
+
+func (b *builder) enumType(p *printer, e *goEnum) {
+	if e.desc != "" {
+		p.comment(e.desc)
+	} else {
+		p.f("// %s is an enumeration", e.name)
+	}
+	p.f("type %s int", e.name)
+	p.f("")
+	p.f("const (")
+	for i, c := range e.consts {
+		p.f("%s %s = %d", c, e.name, e.ids[i])
+	}
+	p.f(")")
+	p.f("")
+	p.f("func (e %s) String() string {", e.name)
+	p.f("switch e {")
+	for i, c := range e.consts {
+		p.f("case %s:", c)
+		p.f("return %q", e.labels[i])
+	}
+	p.f("}")
+	p.f("return \"\"")
+	p.f("}")
+	p.f("")
+}
+
+func (b *builder) structType(p *printer, s *goStruct) {
+	if d, valid := s.def.(meta.Describable); valid && d.Description() != "" {
+		p.comment(d.Description())
+	} else {
+		p.f("// %s is %s", s.name, describe(s.def))
+	}
+	p.f("type %s struct {", s.name)
+	for _, f := range s.fields {
+		if f.desc != "" {
+			p.comment(f.desc)
+		}
+		p.f("%s %s", f.name, f.goType())
+	}
+	p.f("}")
+	p.f("")
+}
+
+func describe(def meta.HasDataDefinitions) string {
+	switch def.(type) {
+	case *meta.Module:
+		return "module " + def.Ident()
+	case *meta.RpcInput:
+		return "input of " + meta.SchemaPath(def.Parent())
+	case *meta.RpcOutput:
+		return "output of " + meta.SchemaPath(def.Parent())
+	}
+	return meta.SchemaPath(def)
+}
+
+func (f *field) goType() string {
+	switch f.kind {
+	case containerField:
+		return "*" + f.child.name
+	case listField:
+		return "[]*" + f.child.name
+	}
+	if f.multi {
+		return "[]" + f.typ.name
+	}
+	if f.ptr {
+		return "*" + f.typ.name
+	}
+	return f.typ.name
+}
+
+func (b *builder) nodeFunc(p *printer, s *goStruct) {
+	p.f("// Node to read and write %s", s.name)
+	p.f("func (x *%s) Node(h *Hooks) node.Node {", s.name)
+	p.f("return &nodeutil.Basic{")
+	p.f("Peekable: x,")
+	var children, leafs []*field
+	for _, f := range s.fields {
+		if f.kind == leafField {
+			leafs = append(leafs, f)
+		} else {
+			children = append(children, f)
+		}
+	}
+	if len(children) > 0 {
+		b.onChild(p, children)
+	}
+	if len(leafs) > 0 {
+		b.onField(p, leafs)
+	}
+	if len(s.choices) > 0 {
+		b.onChoose(p, s)
+	}
+	var actions, notifs []*hook
+	for _, h := range s.hooks {
+		if h.action {
+			actions = append(actions, h)
+		} else {
+			notifs = append(notifs, h)
+		}
+	}
+	if len(actions) > 0 {
+		b.onAction(p, actions)
+	}
+	if len(notifs) > 0 {
+		b.onNotify(p, notifs)
+	}
+	p.f("}")
+	p.f("}")
+	p.f("")
+}
+
+func (b *builder) onChild(p *printer, children []*field) {
+	p.f("OnChild: func(r node.ChildRequest) (node.Node, error) {")
+	p.f("switch r.Meta.Ident() {")
+	for _, f := range children {
+		p.f("case %q:", f.ident)
+		p.f("if r.New {")
+		if f.kind == containerField {
+			p.f("x.%s = &%s{}", f.name, f.child.name)
+		} else {
+			p.f("x.%s = []*%s{}", f.name, f.child.name)
+		}
+		p.f("} else if r.Delete {")
+		p.f("x.%s = nil", f.name)
+		p.f("}")
+		p.f("if x.%s != nil {", f.name)
+		if f.kind == containerField {
+			p.f("return x.%s.Node(h), nil", f.name)
+		} else {
+			p.f("return %s(&x.%s, h), nil", listFuncName(f.child), f.name)
+		}
+		p.f("}")
+	}
+	p.f("}")
+	p.f("return nil, nil")
+	p.f("},")
+}
+
+func (b *builder) onField(p *printer, leafs []*field) {
+	p.f("OnField: func(r node.FieldRequest, hnd *node.ValueHandle) error {")
+	p.f("switch r.Meta.Ident() {")
+	for _, f := range leafs {
+		p.f("case %q:", f.ident)
+		p.f("if r.Clear || (r.Write && hnd.Val == nil) {")
+		p.f("x.%s = %s", f.name, f.zero())
+		p.f("} else if r.Write {")
+		b.writeLeaf(p, f)
+		b.readLeaf(p, f)
+		p.f("}")
+	}
+	p.f("}")
+	p.f("return nil")
+	p.f("},")
+}
+
+func (f *field) zero() string {
+	if f.ptr || f.multi || f.typ.generic() {
+		return "nil"
+	}
+	switch f.typ.format {
+	case val.FmtString, val.FmtIdentityRef:
+		return `""`
+	case val.FmtBool:
+		return "false"
+	case val.FmtDecimal64:
+		return "val.Decimal64{}"
+	}
+	return "0"
+}
+
+// writeLeaf copies hnd.Val into struct
+func (b *builder) writeLeaf(p *printer, f *field) {
+	if f.typ.generic() {
+		p.f("x.%s = hnd.Val", f.name)
+		return
+	}
+	s := scalars[f.typ.format]
+	if f.multi {
+		if f.typ.enum == nil && f.typ.format != val.FmtIdentityRef && s.listElem == "" {
+			p.f("x.%s = []%s(hnd.Val.(%s))", f.name, f.typ.name, s.listType)
+			return
+		}
+		p.f("var l []%s", f.typ.name)
+		p.f("for _, v := range hnd.Val.(%s) {", s.listType)
+		p.f("l = append(l, %s)", f.typ.fromVal("v"))
+		p.f("}")
+		p.f("x.%s = l", f.name)
+		return
+	}
+	if f.ptr {
+		p.f("v := %s", f.typ.fromVal("hnd.Val.("+s.valType+")"))
+		p.f("x.%s = &v", f.name)
+		return
+	}
+	p.f("x.%s = %s", f.name, f.typ.fromVal("hnd.Val.("+s.valType+")"))
+}
+
+// readLeaf copies struct into hnd.Val
+func (b *builder) readLeaf(p *printer, f *field) {
+	if f.typ.generic() {
+		p.f("} else {")
+		p.f("hnd.Val = x.%s", f.name)
+		return
+	}
+	s := scalars[f.typ.format]
+	if f.multi {
+		p.f("} else if x.%s != nil {", f.name)
+		if f.typ.enum == nil && f.typ.format != val.FmtIdentityRef && s.listElem == "" {
+			p.f("hnd.Val = %s(x.%s)", s.listType, f.name)
+			return
+		}
+		p.f("l := make(%s, len(x.%s))", s.listType, f.name)
+		p.f("for i, v := range x.%s {", f.name)
+		if s.listElem != "" {
+			p.f("l[i] = %s(v)", s.listElem)
+		} else {
+			p.f("l[i] = %s", f.typ.toVal("v"))
+		}
+		p.f("}")
+		p.f("hnd.Val = l")
+		return
+	}
+	if f.ptr {
+		p.f("} else if x.%s != nil {", f.name)
+		p.f("hnd.Val = %s", f.typ.toVal("*x."+f.name))
+		return
+	}
+	p.f("} else {")
+	p.f("hnd.Val = %s", f.typ.toVal("x."+f.name))
+}
+
+// toVal is expression converting Go value to val.Value
+func (t *goType) toVal(expr string) string {
+	switch {
+	case t.generic():
+		return expr
+	case t.enum != nil:
+		recv := expr
+		if strings.HasPrefix(expr, "*") {
+			recv = "(" + expr + ")"
+		}
+		return fmt.Sprintf("val.Enum{Id: int(%s), Label: %s.String()}", expr, recv)
+	case t.format == val.FmtIdentityRef:
+		return fmt.Sprintf("val.IdentRef{Base: %q, Label: string(%s)}", t.base, expr)
+	}
+	return fmt.Sprintf("%s(%s)", scalars[t.format].valType, expr)
+}
+
+// fromVal is expression converting single val.Value of known type to Go
+func (t *goType) fromVal(expr string) string {
+	switch {
+	case t.enum != nil:
+		return fmt.Sprintf("%s(%s.Id)", t.name, expr)
+	case t.format == val.FmtIdentityRef:
+		return fmt.Sprintf("Identity(%s.Label)", expr)
+	}
+	return fmt.Sprintf("%s(%s)", t.name, expr)
+}
+
+func (b *builder) onChoose(p *printer, s *goStruct) {
+	p.f("OnChoose: func(sel node.Selection, choice *meta.Choice) (*meta.ChoiceCase, error) {")
+	p.f("switch choice.Ident() {")
+	for _, c := range s.choices {
+		p.f("case %q:", c.choice.Ident())
+		for _, kase := range c.cases {
+			if len(kase.fields) == 0 {
+				continue
+			}
+			var conds []string
+			for _, f := range kase.fields {
+				if f.kind == listField || f.multi {
+					conds = append(conds, fmt.Sprintf("len(x.%s) > 0", f.name))
+				} else {
+					conds = append(conds, fmt.Sprintf("x.%s != nil", f.name))
+				}
+			}
+			p.f("if %s {", strings.Join(conds, " || "))
+			p.f("return choice.Cases()[%q], nil", kase.ident)
+			p.f("}")
+		}
+	}
+	p.f("}")
+	p.f("return nil, nil")
+	p.f("},")
+}
+
+func (b *builder) onAction(p *printer, actions []*hook) {
+	p.f("OnAction: func(r node.ActionRequest) (node.Node, error) {")
+	p.f("switch r.Meta.Ident() {")
+	for _, h := range actions {
+		p.f("case %q:", h.ident)
+		p.f("if h == nil || h.%s == nil {", h.name)
+		p.f("break")
+		p.f("}")
+		args := "x"
+		if h.input != nil {
+			p.f("in := &%s{}", h.input.name)
+			p.f("if err := r.Input.InsertInto(in.Node(h)).LastErr; err != nil {")
+			p.f("return nil, err")
+			p.f("}")
+			args += ", in"
+		}
+		if h.output == nil {
+			p.f("return nil, h.%s(%s)", h.name, args)
+			continue
+		}
+		p.f("out, err := h.%s(%s)", h.name, args)
+		p.f("if err != nil || out == nil {")
+		p.f("return nil, err")
+		p.f("}")
+		p.f("return out.Node(h), nil")
+	}
+	p.f("}")
+	p.f(`return nil, fmt.Errorf("%%w. %%s", fc.NotImplementedError, r.Meta.Ident())`)
+	p.f("},")
+}
+
+func (b *builder) onNotify(p *printer, notifs []*hook) {
+	p.f("OnNotify: func(r node.NotifyRequest) (node.NotifyCloser, error) {")
+	p.f("switch r.Meta.Ident() {")
+	for _, h := range notifs {
+		p.f("case %q:", h.ident)
+		p.f("if h == nil || h.%s == nil {", h.name)
+		p.f("break")
+		p.f("}")
+		p.f("return h.%s(x, func(msg *%s) {", h.name, h.msg.name)
+		p.f("r.Send(msg.Node(h))")
+		p.f("})")
+	}
+	p.f("}")
+	p.f(`return nil, fmt.Errorf("%%w. %%s", fc.NotImplementedError, r.Meta.Ident())`)
+	p.f("},")
+}
+
+func listFuncName(s *goStruct) string {
+	return lowerFirst(s.name) + "List"
+}
+
+func (b *builder) listFunc(p *printer, s *goStruct) {
+	name := listFuncName(s)
+	p.f("func %s(l *[]*%s, h *Hooks) node.Node {", name, s.name)
+	p.f("return &nodeutil.Basic{")
+	p.f("OnNext: func(r node.ListRequest) (node.Node, []val.Value, error) {")
+	p.f("key := r.Key")
+	p.f("var item *%s", s.name)
+	p.f("if r.New {")
+	p.f("item = &%s{}", s.name)
+	p.f("*l = append(*l, item)")
+	if len(s.keys) > 0 {
+		p.f("} else if key != nil {")
+		p.f("for i, candidate := range *l {")
+		p.f("if val.EqualVals(candidate.key(), key) {")
+		p.f("if r.Delete {")
+		p.f("*l = append((*l)[:i], (*l)[i+1:]...)")
+		p.f("} else {")
+		p.f("item = candidate")
+		p.f("}")
+		p.f("break")
+		p.f("}")
+		p.f("}")
+	}
+	p.f("} else if r.Row < len(*l) {")
+	p.f("item = (*l)[r.Row]")
+	if len(s.keys) > 0 {
+		p.f("key = item.key()")
+	}
+	p.f("}")
+	p.f("if item == nil {")
+	p.f("return nil, nil, nil")
+	p.f("}")
+	p.f("return item.Node(h), key, nil")
+	p.f("},")
+	p.f("}")
+	p.f("}")
+	p.f("")
+	if len(s.keys) == 0 {
+		return
+	}
+	p.f("func (x *%s) key() []val.Value {", s.name)
+	var vals []string
+	for _, k := range s.keys {
+		vals = append(vals, k.typ.toVal("x."+k.name))
+	}
+	p.f("return []val.Value{%s}", strings.Join(vals, ", "))
+	p.f("}")
+	p.f("")
+}
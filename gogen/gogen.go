@@ -0,0 +1,460 @@
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/val"
+)
+
+// Wtr writes Go source with a struct for every container, list, rpc or action
+// input and output and notification of a module along with an implementation
+// of node.Node for each struct that reads and writes the struct's fields
+// directly, no reflection is involved.
+//
+// Leafs are pointers unless they are list keys or mandatory so it is clear
+// when they are not set. Containers are pointers and lists are slices of
+// pointers. Enumerations get their own Go type with a constant for each enum
+// and identities are constants of type Identity.  Types that have no direct
+// Go equivalent like unions, leafrefs, bits and binary are left as val.Value.
+//
+// Actions, rpcs and notifications are implemented by assigning functions to
+// the generated Hooks struct which is passed to Node() of the top-level struct.
+//
+// Module must be compiled.
+type Wtr struct {
+	Out io.Writer
+
+	// Package name of generated source, default is module name with
+	// characters not allowed in a package name removed
+	Package string
+}
+
+// WriteGo writes Go source for module to a string
+func WriteGo(m *meta.Module, pkg string) (string, error) {
+	var buf bytes.Buffer
+	w := &Wtr{Out: &buf, Package: pkg}
+	err := w.Write(m)
+	return buf.String(), err
+}
+
+// Write Go source for module to stream
+func (w *Wtr) Write(m *meta.Module) error {
+	pkg := w.Package
+	if pkg == "" {
+		pkg = packageName(m.Ident())
+	}
+	b := &builder{
+		module:  m,
+		names:   newNames(reservedNames...),
+		structs: make(map[meta.HasDataDefinitions]*goStruct),
+		enums:   make(map[interface{}]*goEnum),
+	}
+	b.root = b.object(m, camel(m.Ident()))
+	body := b.generate()
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by fc-yang gen go from module %s. DO NOT EDIT.\n\n", m.Ident())
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+	src.WriteString("import (\n")
+	for _, imp := range imports {
+		if strings.Contains(body, imp.use) {
+			if imp.path == "github.com/freeconf/yang/fc" && strings.Contains(body, "fmt.") {
+				src.WriteString("\n")
+			}
+			fmt.Fprintf(&src, "\t%q\n", imp.path)
+		}
+	}
+	src.WriteString(")\n\n")
+	src.WriteString(body)
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return fmt.Errorf("generated invalid source for %s. %s", m.Ident(), err)
+	}
+	_, err = w.Out.Write(formatted)
+	return err
+}
+
+var imports = []struct {
+	path string
+	use  string
+}{
+	{path: "fmt", use: "fmt."},
+	{path: "github.com/freeconf/yang/fc", use: "fc."},
+	{path: "github.com/freeconf/yang/meta", use: "meta."},
+	{path: "github.com/freeconf/yang/node", use: "node."},
+	{path: "github.com/freeconf/yang/nodeutil", use: "nodeutil."},
+	{path: "github.com/freeconf/yang/val", use: "val."},
+}
+
+// names of types that are always generated
+var reservedNames = []string{"Hooks", "Identity"}
+
+type builder struct {
+	module  *meta.Module
+	root    *goStruct
+	names   names
+	structs map[meta.HasDataDefinitions]*goStruct
+
+	// order structs were found so source is generated in same order as
+	// definitions in module
+	order []*goStruct
+
+	// by typedef name or type when enumeration is not a typedef
+	enums     map[interface{}]*goEnum
+	enumOrder []*goEnum
+
+	hooks       []*hook
+	hasIdentRef bool
+}
+
+type goStruct struct {
+	name   string
+	def    meta.HasDataDefinitions
+	fields []*field
+	// prefix of names of structs of children, empty for module
+	prefix  string
+	choices []*goChoice
+	hooks   []*hook
+	keys    []*field
+}
+
+type fieldKind int
+
+const (
+	leafField fieldKind = iota
+	containerField
+	listField
+)
+
+type field struct {
+	name  string
+	ident string
+	kind  fieldKind
+	desc  string
+
+	// only for leafs
+	typ   *goType
+	ptr   bool
+	multi bool
+
+	// only for containers and lists
+	child *goStruct
+}
+
+type goChoice struct {
+	choice *meta.Choice
+	cases  []*goCase
+}
+
+type goCase struct {
+	ident  string
+	fields []*field
+}
+
+type goType struct {
+	name   string
+	format val.Format
+	enum   *goEnum
+	// identity base for identityrefs
+	base string
+}
+
+func (t *goType) generic() bool {
+	return t.name == "val.Value"
+}
+
+type goEnum struct {
+	name   string
+	desc   string
+	labels []string
+	consts []string
+	ids    []int
+}
+
+type hook struct {
+	name   string
+	path   string
+	parent *goStruct
+	ident  string
+	desc   string
+
+	// only for rpcs and actions
+	action bool
+	input  *goStruct
+	output *goStruct
+
+	// only for notifications
+	msg *goStruct
+}
+
+func (b *builder) object(x meta.HasDataDefinitions, name string) *goStruct {
+	if s, found := b.structs[x]; found {
+		// recursive schema
+		return s
+	}
+	s := &goStruct{def: x, name: b.names.unique(name, b.root.childPrefix()+name)}
+	if x != meta.HasDataDefinitions(b.module) {
+		s.prefix = s.name
+	}
+	b.structs[x] = s
+	b.order = append(b.order, s)
+	fieldNames := newNames("Node")
+	b.members(s, x.DataDefinitions(), false, fieldNames)
+	if l, isList := x.(*meta.List); isList {
+		for _, k := range l.KeyMeta() {
+			for _, f := range s.fields {
+				if f.ident == k.Ident() {
+					s.keys = append(s.keys, f)
+				}
+			}
+		}
+	}
+	if hasActions, valid := x.(meta.HasActions); valid {
+		for _, ident := range sortedKeys(hasActions.Actions()) {
+			b.action(s, hasActions.Actions()[ident])
+		}
+	}
+	if hasNotifs, valid := x.(meta.HasNotifications); valid {
+		for _, ident := range sortedKeys(hasNotifs.Notifications()) {
+			b.notification(s, hasNotifs.Notifications()[ident])
+		}
+	}
+	return s
+}
+
+func (s *goStruct) childPrefix() string {
+	if s == nil {
+		return ""
+	}
+	return s.name
+}
+
+// members adds fields for definitions returning the fields that were added
+func (b *builder) members(s *goStruct, defs []meta.Definition, inChoice bool, fieldNames names) []*field {
+	var added []*field
+	for _, def := range defs {
+		if choice, isChoice := def.(*meta.Choice); isChoice {
+			c := &goChoice{choice: choice}
+			s.choices = append(s.choices, c)
+			for _, kaseIdent := range choice.CaseIdents() {
+				kase := choice.Cases()[kaseIdent]
+				fields := b.members(s, kase.DataDefinitions(), true, fieldNames)
+				c.cases = append(c.cases, &goCase{ident: kaseIdent, fields: fields})
+				added = append(added, fields...)
+			}
+			continue
+		}
+		f := &field{
+			ident: def.Ident(),
+			name:  fieldNames.unique(camel(def.Ident()), camel(def.Ident())+"_"),
+		}
+		if d, valid := def.(meta.Describable); valid {
+			f.desc = d.Description()
+		}
+		childName := s.prefix + camel(def.Ident())
+		switch x := def.(type) {
+		case *meta.Container:
+			f.kind = containerField
+			f.child = b.object(x, childName)
+		case *meta.List:
+			f.kind = listField
+			f.child = b.object(x, childName)
+		case meta.Leafable:
+			f.kind = leafField
+			f.typ = b.leafType(x.Type(), childName)
+			f.multi = x.Type().Format().IsList()
+			f.ptr = !f.multi && !f.typ.generic() && (inChoice || !b.required(s, x))
+		default:
+			continue
+		}
+		s.fields = append(s.fields, f)
+		added = append(added, f)
+	}
+	return added
+}
+
+// required leafs always have a value so they do not need to be pointers
+func (b *builder) required(s *goStruct, l meta.Leafable) bool {
+	if list, isList := s.def.(*meta.List); isList {
+		for _, k := range list.KeyMeta() {
+			if k.Ident() == l.Ident() {
+				return true
+			}
+		}
+	}
+	d, valid := l.(meta.HasMandatory)
+	return valid && d.Mandatory()
+}
+
+func (b *builder) action(parent *goStruct, rpc *meta.Rpc) {
+	name := parent.prefix + camel(rpc.Ident())
+	h := &hook{
+		name:   name,
+		path:   meta.SchemaPath(rpc),
+		parent: parent,
+		ident:  rpc.Ident(),
+		desc:   rpc.Description(),
+		action: true,
+	}
+	if rpc.Input() != nil {
+		h.input = b.object(rpc.Input(), name+"Input")
+	}
+	if rpc.Output() != nil {
+		h.output = b.object(rpc.Output(), name+"Output")
+	}
+	parent.hooks = append(parent.hooks, h)
+	b.hooks = append(b.hooks, h)
+}
+
+func (b *builder) notification(parent *goStruct, n *meta.Notification) {
+	name := parent.prefix + camel(n.Ident())
+	h := &hook{
+		name:   name,
+		path:   meta.SchemaPath(n),
+		parent: parent,
+		ident:  n.Ident(),
+		desc:   n.Description(),
+	}
+	h.msg = b.object(n, name)
+	parent.hooks = append(parent.hooks, h)
+	b.hooks = append(b.hooks, h)
+}
+
+func (b *builder) leafType(t *meta.Type, name string) *goType {
+	f := t.Format().Single()
+	switch f {
+	case val.FmtEnum:
+		e := b.enum(t, name)
+		return &goType{name: e.name, format: f, enum: e}
+	case val.FmtIdentityRef:
+		b.hasIdentRef = true
+		base := ""
+		if t.Base() != nil {
+			base = t.Base().Ident()
+		}
+		return &goType{name: "Identity", format: f, base: base}
+	}
+	if s, found := scalars[f]; found {
+		return &goType{name: s.goType, format: f}
+	}
+	return &goType{name: "val.Value", format: f}
+}
+
+func (b *builder) enum(t *meta.Type, name string) *goEnum {
+	var id interface{} = t
+	desc := ""
+	if td, found := b.module.Typedefs()[t.Ident()]; found {
+		id = td.Ident()
+		name = camel(td.Ident())
+		desc = td.Description()
+	}
+	if e, found := b.enums[id]; found {
+		return e
+	}
+	e := &goEnum{name: b.names.unique(name, name+"Enum"), desc: desc}
+	for _, item := range t.Enum() {
+		e.labels = append(e.labels, item.Label)
+		e.ids = append(e.ids, item.Id)
+		c := b.names.unique(e.name+camel(item.Label), e.name+"_"+camel(item.Label))
+		e.consts = append(e.consts, c)
+	}
+	b.enums[id] = e
+	b.enumOrder = append(b.enumOrder, e)
+	return e
+}
+
+// names makes sure names do not collide
+type names map[string]bool
+
+func newNames(reserved ...string) names {
+	n := make(names)
+	for _, name := range reserved {
+		n[name] = true
+	}
+	return n
+}
+
+// unique returns preferred name if not already taken, otherwise the
+// alternative with a number appended if that is also taken.
+func (n names) unique(preferred string, alternative string) string {
+	candidate := preferred
+	for i := 2; n[candidate]; i++ {
+		if candidate == preferred && !n[alternative] {
+			candidate = alternative
+			break
+		}
+		candidate = fmt.Sprintf("%s%d", alternative, i)
+	}
+	n[candidate] = true
+	return candidate
+}
+
+// camel turns YANG identifiers like "max-speed" into exported Go identifiers
+// like "MaxSpeed"
+func camel(ident string) string {
+	var s strings.Builder
+	upper := true
+	for _, r := range ident {
+		switch {
+		case r == '-' || r == '_' || r == '.' || r == ':':
+			upper = true
+		case upper:
+			s.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		default:
+			s.WriteRune(r)
+		}
+	}
+	name := s.String()
+	if name == "" || !isLetter(name[0]) {
+		return "X" + name
+	}
+	return name
+}
+
+func lowerFirst(name string) string {
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func packageName(ident string) string {
+	var s strings.Builder
+	for _, r := range strings.ToLower(ident) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			s.WriteRune(r)
+		}
+	}
+	name := s.String()
+	if name == "" || !isLetter(name[0]) {
+		return "x" + name
+	}
+	return name
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch x := m.(type) {
+	case map[string]*meta.Rpc:
+		for k := range x {
+			keys = append(keys, k)
+		}
+	case map[string]*meta.Notification:
+		for k := range x {
+			keys = append(keys, k)
+		}
+	case map[string]*meta.Identity:
+		for k := range x {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
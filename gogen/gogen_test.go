@@ -0,0 +1,25 @@
+package gogen
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+var updateFlag = flag.Bool("update", false, "update golden files instead of verifying against them")
+
+func TestGo(t *testing.T) {
+	m := parser.RequireModule(source.Dir("./testdata"), "car")
+	var actual bytes.Buffer
+	w := &Wtr{Out: &actual}
+	if err := w.Write(m); err != nil {
+		t.Fatal(err)
+	}
+	// gold is a package of it's own so generated code is also compiled and
+	// tested
+	fc.Gold(t, *updateFlag, actual.Bytes(), "testdata/car/car.go")
+}
@@ -0,0 +1,78 @@
+package gogen_test
+
+import (
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/gogen/testdata/car"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/nodeutil"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+	"github.com/freeconf/yang/val"
+)
+
+func TestGeneratedNode(t *testing.T) {
+	m := parser.RequireModule(source.Dir("./testdata"), "car")
+	var rotated []int8
+	var tuned *car.Engine
+	hooks := &car.Hooks{
+		Rotate: func(x *car.Car, in *car.RotateInput) error {
+			rotated = in.Order
+			return nil
+		},
+		EngineTune: func(x *car.Engine, in *car.EngineTuneInput) (*car.EngineTuneOutput, error) {
+			tuned = x
+			x.Mode = in.Mode
+			return &car.EngineTuneOutput{Gains: []int32{1, 2}}, nil
+		},
+		WheelFlat: func(x *car.Wheel, send func(*car.WheelFlat)) (node.NotifyCloser, error) {
+			psi := val.Decimal64{Mantissa: 1050, FractionDigits: 2}
+			send(&car.WheelFlat{Psi: &psi})
+			return func() error { return nil }, nil
+		},
+	}
+	data := &car.Car{}
+	b := node.NewBrowser(m, data.Node(hooks))
+	in := `{"engine":{"model":"v8","mode":"sport","history":["eco","sport"]},` +
+		`"wheel":[{"pos":1,"brand":"car:goodyear","pressure":32,"tread":[3,4],"nuts":5},{"pos":2,"bolts":6}],` +
+		`"trip":[{"distance":100,"status":"done"}]}`
+	fc.AssertEqual(t, nil, b.Root().UpsertFrom(nodeutil.ReadJSON(in)).LastErr)
+
+	fc.AssertEqual(t, "v8", data.Engine.Model)
+	fc.AssertEqual(t, car.DriveModeSport, *data.Engine.Mode)
+	fc.AssertEqual(t, 2, len(data.Wheel))
+	fc.AssertEqual(t, car.IdentityGoodyear, *data.Wheel[0].Brand)
+	fc.AssertEqual(t, uint8(5), *data.Wheel[0].Nuts)
+	fc.AssertEqual(t, uint8(6), *data.Wheel[1].Bolts)
+	fc.AssertEqual(t, car.TripStatusDone, *data.Trip[0].Status)
+
+	actual, err := nodeutil.WriteJSON(b.Root())
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, in, actual)
+
+	fc.AssertEqual(t, nil, b.Root().Find("wheel=2").Delete())
+	fc.AssertEqual(t, 1, len(data.Wheel))
+
+	fc.AssertEqual(t, nil, b.Root().Find("rotate").Action(nodeutil.ReadJSON(`{"order":[2,1]}`)).LastErr)
+	fc.AssertEqual(t, []int8{2, 1}, rotated)
+
+	out := b.Root().Find("engine/tune").Action(nodeutil.ReadJSON(`{"mode":"eco"}`))
+	fc.AssertEqual(t, nil, out.LastErr)
+	outJSON, err := nodeutil.WriteJSON(out)
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, `{"gains":[1,2]}`, outJSON)
+	fc.AssertEqual(t, data.Engine, tuned)
+	fc.AssertEqual(t, car.DriveModeEco, *data.Engine.Mode)
+
+	var msg string
+	closer, err := b.Root().Find("wheel=1/flat").Notifications(func(n node.Selection) {
+		msg, _ = nodeutil.WriteJSON(n)
+	})
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, `{"psi":10.5}`, msg)
+	fc.AssertEqual(t, nil, closer())
+
+	err = b.Root().Find("reset").Action(nil).LastErr
+	fc.AssertEqual(t, true, err != nil)
+}
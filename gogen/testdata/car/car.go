@@ -0,0 +1,593 @@
+// Code generated by fc-yang gen go from module car. DO NOT EDIT.
+
+package car
+
+import (
+	"fmt"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/nodeutil"
+	"github.com/freeconf/yang/val"
+)
+
+// Hooks implement the rpcs, actions and notifications of car. Any
+// hook left nil is reported as not implemented when called.
+type Hooks struct {
+	// EngineTune implements car/engine/tune
+	EngineTune func(x *Engine, in *EngineTuneInput) (*EngineTuneOutput, error)
+
+	// WheelFlat sends events of car/wheel/flat to subscriber until closed
+	WheelFlat func(x *Wheel, send func(*WheelFlat)) (node.NotifyCloser, error)
+
+	// Reset implements car/reset
+	//
+	// back to factory settings
+	Reset func(x *Car) error
+
+	// Rotate implements car/rotate
+	Rotate func(x *Car, in *RotateInput) error
+
+	// Service sends events of car/service to subscriber until closed
+	Service func(x *Car, send func(*Service)) (node.NotifyCloser, error)
+}
+
+// Identity is the value of an identityref
+type Identity string
+
+const (
+	IdentityGoodyear  Identity = "goodyear"
+	IdentityMichelin  Identity = "michelin"
+	IdentityTireBrand Identity = "tire-brand"
+)
+
+// how engine responds
+type DriveMode int
+
+const (
+	DriveModeEco   DriveMode = 0
+	DriveModeSport DriveMode = 10
+)
+
+func (e DriveMode) String() string {
+	switch e {
+	case DriveModeEco:
+		return "eco"
+	case DriveModeSport:
+		return "sport"
+	}
+	return ""
+}
+
+// TripStatus is an enumeration
+type TripStatus int
+
+const (
+	TripStatusActive TripStatus = 0
+	TripStatusDone   TripStatus = 1
+)
+
+func (e TripStatus) String() string {
+	switch e {
+	case TripStatusActive:
+		return "active"
+	case TripStatusDone:
+		return "done"
+	}
+	return ""
+}
+
+// Car with many wheels
+type Car struct {
+	Engine *Engine
+	Wheel  []*Wheel
+	Trip   []*Trip
+}
+
+// Node to read and write Car
+func (x *Car) Node(h *Hooks) node.Node {
+	return &nodeutil.Basic{
+		Peekable: x,
+		OnChild: func(r node.ChildRequest) (node.Node, error) {
+			switch r.Meta.Ident() {
+			case "engine":
+				if r.New {
+					x.Engine = &Engine{}
+				} else if r.Delete {
+					x.Engine = nil
+				}
+				if x.Engine != nil {
+					return x.Engine.Node(h), nil
+				}
+			case "wheel":
+				if r.New {
+					x.Wheel = []*Wheel{}
+				} else if r.Delete {
+					x.Wheel = nil
+				}
+				if x.Wheel != nil {
+					return wheelList(&x.Wheel, h), nil
+				}
+			case "trip":
+				if r.New {
+					x.Trip = []*Trip{}
+				} else if r.Delete {
+					x.Trip = nil
+				}
+				if x.Trip != nil {
+					return tripList(&x.Trip, h), nil
+				}
+			}
+			return nil, nil
+		},
+		OnAction: func(r node.ActionRequest) (node.Node, error) {
+			switch r.Meta.Ident() {
+			case "reset":
+				if h == nil || h.Reset == nil {
+					break
+				}
+				return nil, h.Reset(x)
+			case "rotate":
+				if h == nil || h.Rotate == nil {
+					break
+				}
+				in := &RotateInput{}
+				if err := r.Input.InsertInto(in.Node(h)).LastErr; err != nil {
+					return nil, err
+				}
+				return nil, h.Rotate(x, in)
+			}
+			return nil, fmt.Errorf("%w. %s", fc.NotImplementedError, r.Meta.Ident())
+		},
+		OnNotify: func(r node.NotifyRequest) (node.NotifyCloser, error) {
+			switch r.Meta.Ident() {
+			case "service":
+				if h == nil || h.Service == nil {
+					break
+				}
+				return h.Service(x, func(msg *Service) {
+					r.Send(msg.Node(h))
+				})
+			}
+			return nil, fmt.Errorf("%w. %s", fc.NotImplementedError, r.Meta.Ident())
+		},
+	}
+}
+
+// Engine is car/engine
+type Engine struct {
+	Model   string
+	Mode    *DriveMode
+	Rpm     *uint32
+	History []DriveMode
+}
+
+// Node to read and write Engine
+func (x *Engine) Node(h *Hooks) node.Node {
+	return &nodeutil.Basic{
+		Peekable: x,
+		OnField: func(r node.FieldRequest, hnd *node.ValueHandle) error {
+			switch r.Meta.Ident() {
+			case "model":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Model = ""
+				} else if r.Write {
+					x.Model = string(hnd.Val.(val.String))
+				} else {
+					hnd.Val = val.String(x.Model)
+				}
+			case "mode":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Mode = nil
+				} else if r.Write {
+					v := DriveMode(hnd.Val.(val.Enum).Id)
+					x.Mode = &v
+				} else if x.Mode != nil {
+					hnd.Val = val.Enum{Id: int(*x.Mode), Label: (*x.Mode).String()}
+				}
+			case "rpm":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Rpm = nil
+				} else if r.Write {
+					v := uint32(hnd.Val.(val.UInt32))
+					x.Rpm = &v
+				} else if x.Rpm != nil {
+					hnd.Val = val.UInt32(*x.Rpm)
+				}
+			case "history":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.History = nil
+				} else if r.Write {
+					var l []DriveMode
+					for _, v := range hnd.Val.(val.EnumList) {
+						l = append(l, DriveMode(v.Id))
+					}
+					x.History = l
+				} else if x.History != nil {
+					l := make(val.EnumList, len(x.History))
+					for i, v := range x.History {
+						l[i] = val.Enum{Id: int(v), Label: v.String()}
+					}
+					hnd.Val = l
+				}
+			}
+			return nil
+		},
+		OnAction: func(r node.ActionRequest) (node.Node, error) {
+			switch r.Meta.Ident() {
+			case "tune":
+				if h == nil || h.EngineTune == nil {
+					break
+				}
+				in := &EngineTuneInput{}
+				if err := r.Input.InsertInto(in.Node(h)).LastErr; err != nil {
+					return nil, err
+				}
+				out, err := h.EngineTune(x, in)
+				if err != nil || out == nil {
+					return nil, err
+				}
+				return out.Node(h), nil
+			}
+			return nil, fmt.Errorf("%w. %s", fc.NotImplementedError, r.Meta.Ident())
+		},
+	}
+}
+
+// EngineTuneInput is input of car/engine/tune
+type EngineTuneInput struct {
+	Mode *DriveMode
+}
+
+// Node to read and write EngineTuneInput
+func (x *EngineTuneInput) Node(h *Hooks) node.Node {
+	return &nodeutil.Basic{
+		Peekable: x,
+		OnField: func(r node.FieldRequest, hnd *node.ValueHandle) error {
+			switch r.Meta.Ident() {
+			case "mode":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Mode = nil
+				} else if r.Write {
+					v := DriveMode(hnd.Val.(val.Enum).Id)
+					x.Mode = &v
+				} else if x.Mode != nil {
+					hnd.Val = val.Enum{Id: int(*x.Mode), Label: (*x.Mode).String()}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// EngineTuneOutput is output of car/engine/tune
+type EngineTuneOutput struct {
+	Gains []int32
+}
+
+// Node to read and write EngineTuneOutput
+func (x *EngineTuneOutput) Node(h *Hooks) node.Node {
+	return &nodeutil.Basic{
+		Peekable: x,
+		OnField: func(r node.FieldRequest, hnd *node.ValueHandle) error {
+			switch r.Meta.Ident() {
+			case "gains":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Gains = nil
+				} else if r.Write {
+					var l []int32
+					for _, v := range hnd.Val.(val.Int32List) {
+						l = append(l, int32(v))
+					}
+					x.Gains = l
+				} else if x.Gains != nil {
+					l := make(val.Int32List, len(x.Gains))
+					for i, v := range x.Gains {
+						l[i] = int(v)
+					}
+					hnd.Val = l
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// Wheel is car/wheel
+type Wheel struct {
+	Pos      int8
+	Brand    *Identity
+	Pressure val.Value
+	Tread    []int32
+	Bolts    *uint8
+	Nuts     *uint8
+	Locking  *bool
+}
+
+// Node to read and write Wheel
+func (x *Wheel) Node(h *Hooks) node.Node {
+	return &nodeutil.Basic{
+		Peekable: x,
+		OnField: func(r node.FieldRequest, hnd *node.ValueHandle) error {
+			switch r.Meta.Ident() {
+			case "pos":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Pos = 0
+				} else if r.Write {
+					x.Pos = int8(hnd.Val.(val.Int8))
+				} else {
+					hnd.Val = val.Int8(x.Pos)
+				}
+			case "brand":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Brand = nil
+				} else if r.Write {
+					v := Identity(hnd.Val.(val.IdentRef).Label)
+					x.Brand = &v
+				} else if x.Brand != nil {
+					hnd.Val = val.IdentRef{Base: "tire-brand", Label: string(*x.Brand)}
+				}
+			case "pressure":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Pressure = nil
+				} else if r.Write {
+					x.Pressure = hnd.Val
+				} else {
+					hnd.Val = x.Pressure
+				}
+			case "tread":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Tread = nil
+				} else if r.Write {
+					var l []int32
+					for _, v := range hnd.Val.(val.Int32List) {
+						l = append(l, int32(v))
+					}
+					x.Tread = l
+				} else if x.Tread != nil {
+					l := make(val.Int32List, len(x.Tread))
+					for i, v := range x.Tread {
+						l[i] = int(v)
+					}
+					hnd.Val = l
+				}
+			case "bolts":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Bolts = nil
+				} else if r.Write {
+					v := uint8(hnd.Val.(val.UInt8))
+					x.Bolts = &v
+				} else if x.Bolts != nil {
+					hnd.Val = val.UInt8(*x.Bolts)
+				}
+			case "nuts":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Nuts = nil
+				} else if r.Write {
+					v := uint8(hnd.Val.(val.UInt8))
+					x.Nuts = &v
+				} else if x.Nuts != nil {
+					hnd.Val = val.UInt8(*x.Nuts)
+				}
+			case "locking":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Locking = nil
+				} else if r.Write {
+					v := bool(hnd.Val.(val.Bool))
+					x.Locking = &v
+				} else if x.Locking != nil {
+					hnd.Val = val.Bool(*x.Locking)
+				}
+			}
+			return nil
+		},
+		OnChoose: func(sel node.Selection, choice *meta.Choice) (*meta.ChoiceCase, error) {
+			switch choice.Ident() {
+			case "fastener":
+				if x.Bolts != nil {
+					return choice.Cases()["bolts"], nil
+				}
+				if x.Nuts != nil || x.Locking != nil {
+					return choice.Cases()["lug"], nil
+				}
+			}
+			return nil, nil
+		},
+		OnNotify: func(r node.NotifyRequest) (node.NotifyCloser, error) {
+			switch r.Meta.Ident() {
+			case "flat":
+				if h == nil || h.WheelFlat == nil {
+					break
+				}
+				return h.WheelFlat(x, func(msg *WheelFlat) {
+					r.Send(msg.Node(h))
+				})
+			}
+			return nil, fmt.Errorf("%w. %s", fc.NotImplementedError, r.Meta.Ident())
+		},
+	}
+}
+
+func wheelList(l *[]*Wheel, h *Hooks) node.Node {
+	return &nodeutil.Basic{
+		OnNext: func(r node.ListRequest) (node.Node, []val.Value, error) {
+			key := r.Key
+			var item *Wheel
+			if r.New {
+				item = &Wheel{}
+				*l = append(*l, item)
+			} else if key != nil {
+				for i, candidate := range *l {
+					if val.EqualVals(candidate.key(), key) {
+						if r.Delete {
+							*l = append((*l)[:i], (*l)[i+1:]...)
+						} else {
+							item = candidate
+						}
+						break
+					}
+				}
+			} else if r.Row < len(*l) {
+				item = (*l)[r.Row]
+				key = item.key()
+			}
+			if item == nil {
+				return nil, nil, nil
+			}
+			return item.Node(h), key, nil
+		},
+	}
+}
+
+func (x *Wheel) key() []val.Value {
+	return []val.Value{val.Int8(x.Pos)}
+}
+
+// WheelFlat is car/wheel/flat
+type WheelFlat struct {
+	Psi *val.Decimal64
+}
+
+// Node to read and write WheelFlat
+func (x *WheelFlat) Node(h *Hooks) node.Node {
+	return &nodeutil.Basic{
+		Peekable: x,
+		OnField: func(r node.FieldRequest, hnd *node.ValueHandle) error {
+			switch r.Meta.Ident() {
+			case "psi":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Psi = nil
+				} else if r.Write {
+					v := val.Decimal64(hnd.Val.(val.Decimal64))
+					x.Psi = &v
+				} else if x.Psi != nil {
+					hnd.Val = val.Decimal64(*x.Psi)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// Trip is car/trip
+type Trip struct {
+	Distance *uint64
+	Start    val.Value
+	Status   *TripStatus
+}
+
+// Node to read and write Trip
+func (x *Trip) Node(h *Hooks) node.Node {
+	return &nodeutil.Basic{
+		Peekable: x,
+		OnField: func(r node.FieldRequest, hnd *node.ValueHandle) error {
+			switch r.Meta.Ident() {
+			case "distance":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Distance = nil
+				} else if r.Write {
+					v := uint64(hnd.Val.(val.UInt64))
+					x.Distance = &v
+				} else if x.Distance != nil {
+					hnd.Val = val.UInt64(*x.Distance)
+				}
+			case "start":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Start = nil
+				} else if r.Write {
+					x.Start = hnd.Val
+				} else {
+					hnd.Val = x.Start
+				}
+			case "status":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Status = nil
+				} else if r.Write {
+					v := TripStatus(hnd.Val.(val.Enum).Id)
+					x.Status = &v
+				} else if x.Status != nil {
+					hnd.Val = val.Enum{Id: int(*x.Status), Label: (*x.Status).String()}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func tripList(l *[]*Trip, h *Hooks) node.Node {
+	return &nodeutil.Basic{
+		OnNext: func(r node.ListRequest) (node.Node, []val.Value, error) {
+			key := r.Key
+			var item *Trip
+			if r.New {
+				item = &Trip{}
+				*l = append(*l, item)
+			} else if r.Row < len(*l) {
+				item = (*l)[r.Row]
+			}
+			if item == nil {
+				return nil, nil, nil
+			}
+			return item.Node(h), key, nil
+		},
+	}
+}
+
+// RotateInput is input of car/rotate
+type RotateInput struct {
+	Order []int8
+}
+
+// Node to read and write RotateInput
+func (x *RotateInput) Node(h *Hooks) node.Node {
+	return &nodeutil.Basic{
+		Peekable: x,
+		OnField: func(r node.FieldRequest, hnd *node.ValueHandle) error {
+			switch r.Meta.Ident() {
+			case "order":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Order = nil
+				} else if r.Write {
+					x.Order = []int8(hnd.Val.(val.Int8List))
+				} else if x.Order != nil {
+					hnd.Val = val.Int8List(x.Order)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// Service is car/service
+type Service struct {
+	Brands []Identity
+}
+
+// Node to read and write Service
+func (x *Service) Node(h *Hooks) node.Node {
+	return &nodeutil.Basic{
+		Peekable: x,
+		OnField: func(r node.FieldRequest, hnd *node.ValueHandle) error {
+			switch r.Meta.Ident() {
+			case "brands":
+				if r.Clear || (r.Write && hnd.Val == nil) {
+					x.Brands = nil
+				} else if r.Write {
+					var l []Identity
+					for _, v := range hnd.Val.(val.IdentRefList) {
+						l = append(l, Identity(v.Label))
+					}
+					x.Brands = l
+				} else if x.Brands != nil {
+					l := make(val.IdentRefList, len(x.Brands))
+					for i, v := range x.Brands {
+						l[i] = val.IdentRef{Base: "tire-brand", Label: string(v)}
+					}
+					hnd.Val = l
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,303 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/freeconf/yang/meta"
+)
+
+// Draft is the JSON Schema dialect written
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Wtr writes a JSON Schema that validates data of a module in the JSON that
+// freeconf reads and writes.  Useful to validate configuration files in
+// editors and CI before they are ever sent to a server.
+//
+// Containers are objects that do not allow unknown properties, lists are
+// arrays, choices are "oneOf" each case and YANG types are mapped to JSON
+// Schema types with their ranges, lengths, patterns and enumerations.
+// Typedefs and groupings defined at the top of the module are written once
+// under "$defs" and referenced with "$ref" wherever they are used unchanged.
+//
+// JSON Schema has no way to say items of an array are unique by only some of
+// their properties so list keys are listed in "x-yang-key" for tools that can
+// use it.
+//
+// Module must be compiled.
+type Wtr struct {
+	Out io.Writer
+
+	// ConfigOnly leaves out all read-only definitions, useful to validate
+	// configuration files
+	ConfigOnly bool
+
+	// adds extra indenting and line feeds
+	Pretty bool
+}
+
+// WriteJSONSchema writes JSON Schema of module to a string
+func WriteJSONSchema(m *meta.Module) (string, error) {
+	var buf bytes.Buffer
+	w := &Wtr{Out: &buf, Pretty: true}
+	err := w.Write(m)
+	return buf.String(), err
+}
+
+// Write JSON Schema of module to stream
+func (w *Wtr) Write(m *meta.Module) error {
+	b := &builder{
+		module:     m,
+		configOnly: w.ConfigOnly,
+		defs:       make(map[string]*schema),
+		groupings:  make(map[*meta.Grouping]*groupingDef),
+		objects:    make(map[meta.Definition]*object),
+	}
+	s := b.object(m)
+	s.Schema = Draft
+	s.Title = m.Ident()
+	if len(b.defs) > 0 {
+		s.Defs = b.defs
+	}
+	enc := json.NewEncoder(w.Out)
+	enc.SetEscapeHTML(false)
+	if w.Pretty {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(s)
+}
+
+type builder struct {
+	module     *meta.Module
+	configOnly bool
+	defs       map[string]*schema
+	groupings  map[*meta.Grouping]*groupingDef
+	objects    map[meta.Definition]*object
+}
+
+// object is where schema of container or list is first used so it can be
+// replaced with a reference if it is used again
+type object struct {
+	holder  *schema
+	content *schema
+	ref     *schema
+}
+
+// groupingDef is built from the first place a grouping is used. Anywhere
+// else it is used, only the definitions that were not refined or otherwise
+// changed are taken from the grouping.
+type groupingDef struct {
+	ref   *schema
+	def   *schema
+	owner *schema
+}
+
+func (b *builder) object(x meta.HasDataDefinitions) *schema {
+	if o, seen := b.objects[x]; seen {
+		// schemas used more than once, including recursive schemas, are
+		// moved to $defs
+		if o.ref == nil {
+			key := "node." + strings.Replace(meta.SchemaPath(x), "/", ".", -1)
+			o.ref = &schema{Ref: "#/$defs/" + key}
+			b.defs[key] = o.content
+			*o.holder = *o.ref
+		}
+		return o.ref
+	}
+	s := &schema{
+		Type:                  "object",
+		Properties:            make(map[string]*schema),
+		UnevaluatedProperties: &falseVal,
+	}
+	o := &object{holder: &schema{}, content: s}
+	b.objects[x] = o
+	if d, valid := x.(meta.Describable); valid {
+		s.Description = d.Description()
+	}
+	b.members(s, x.DataDefinitions())
+	if l, isList := x.(*meta.List); isList {
+		for _, k := range l.KeyMeta() {
+			s.Required = appendUnique(s.Required, k.Ident())
+		}
+	}
+	if len(s.Properties) == 0 {
+		s.Properties = nil
+	}
+	if o.ref != nil {
+		*o.holder = *o.ref
+	} else {
+		*o.holder = *s
+	}
+	return o.holder
+}
+
+func (b *builder) members(s *schema, defs []meta.Definition) {
+	for _, def := range defs {
+		if b.skip(def) {
+			continue
+		}
+		if choice, isChoice := def.(*meta.Choice); isChoice {
+			s.AllOf = append(s.AllOf, b.choice(choice))
+			continue
+		}
+		p := b.property(def)
+		if p == nil {
+			continue
+		}
+		if d, valid := def.(meta.HasMandatory); valid && d.Mandatory() {
+			s.Required = appendUnique(s.Required, def.Ident())
+		}
+		if g := meta.OriginalGrouping(def); g != nil && g.Parent() == b.module {
+			if b.fromGrouping(s, g, def.Ident(), p) {
+				continue
+			}
+		}
+		s.Properties[def.Ident()] = p
+	}
+}
+
+func (b *builder) skip(def meta.Definition) bool {
+	if !b.configOnly {
+		return false
+	}
+	d, valid := def.(meta.HasConfig)
+	return valid && !d.Config()
+}
+
+// fromGrouping is true if property is exactly as it is in the grouping so
+// object can reference grouping instead
+func (b *builder) fromGrouping(s *schema, g *meta.Grouping, ident string, p *schema) bool {
+	gd, found := b.groupings[g]
+	if !found {
+		key := "grouping." + g.Ident()
+		gd = &groupingDef{
+			ref:   &schema{Ref: "#/$defs/" + key},
+			def:   &schema{Properties: make(map[string]*schema)},
+			owner: s,
+		}
+		b.groupings[g] = gd
+		b.defs[key] = gd.def
+	}
+	if gd.owner == s {
+		gd.def.Properties[ident] = p
+	} else if existing, found := gd.def.Properties[ident]; !found || !equal(existing, p) {
+		return false
+	}
+	for _, candidate := range s.AllOf {
+		if candidate == gd.ref {
+			return true
+		}
+	}
+	s.AllOf = append(s.AllOf, gd.ref)
+	return true
+}
+
+// choice allows properties of at most one case, exactly one case when
+// choice is mandatory
+func (b *builder) choice(choice *meta.Choice) *schema {
+	s := &schema{Description: choice.Description()}
+	var all []*schema
+	var cases [][]*schema
+	for _, kaseIdent := range choice.CaseIdents() {
+		kase := choice.Cases()[kaseIdent]
+		c := &schema{
+			Properties: make(map[string]*schema),
+		}
+		b.members(c, kase.DataDefinitions())
+		var present []*schema
+		for _, ident := range b.caseIdents(kase) {
+			present = append(present, &schema{Required: []string{ident}})
+		}
+		all = append(all, present...)
+		cases = append(cases, present)
+		if len(c.Properties) == 0 {
+			c.Properties = nil
+		}
+		s.OneOf = append(s.OneOf, c)
+	}
+	// cases are made exclusive by requiring at least one property of the
+	// case and none of the properties of the other cases
+	for i, c := range s.OneOf {
+		c.AnyOf = cases[i]
+		var others []*schema
+		for j, other := range cases {
+			if j != i {
+				others = append(others, other...)
+			}
+		}
+		if len(others) > 0 {
+			c.Not = &schema{AnyOf: others}
+		}
+	}
+	if !choice.Mandatory() && len(all) > 0 {
+		s.OneOf = append(s.OneOf, &schema{Not: &schema{AnyOf: all}})
+	}
+	return s
+}
+
+// caseIdents are the property names of a case including those in nested
+// choices
+func (b *builder) caseIdents(x meta.HasDataDefinitions) []string {
+	var idents []string
+	for _, def := range x.DataDefinitions() {
+		if b.skip(def) {
+			continue
+		}
+		if choice, isChoice := def.(*meta.Choice); isChoice {
+			for _, kaseIdent := range choice.CaseIdents() {
+				idents = append(idents, b.caseIdents(choice.Cases()[kaseIdent])...)
+			}
+			continue
+		}
+		idents = append(idents, def.Ident())
+	}
+	return idents
+}
+
+func (b *builder) property(def meta.Definition) *schema {
+	switch x := def.(type) {
+	case *meta.Container:
+		return b.object(x)
+	case *meta.List:
+		s := &schema{
+			Type:        "array",
+			Description: x.Description(),
+			Items:       b.object(x),
+			UniqueItems: true,
+		}
+		for _, k := range x.KeyMeta() {
+			s.Key = append(s.Key, k.Ident())
+		}
+		if x.IsMinElementsSet() {
+			min := x.MinElements()
+			s.MinItems = &min
+		}
+		if !x.Unbounded() {
+			max := x.MaxElements()
+			s.MaxItems = &max
+		}
+		return s
+	case meta.Leafable:
+		return b.leaf(x)
+	case *meta.Any:
+		return &schema{Description: x.Description()}
+	}
+	return nil
+}
+
+func equal(a *schema, b *schema) bool {
+	ajson, aerr := json.Marshal(a)
+	bjson, berr := json.Marshal(b)
+	return aerr == nil && berr == nil && bytes.Equal(ajson, bjson)
+}
+
+func appendUnique(l []string, s string) []string {
+	for _, candidate := range l {
+		if candidate == s {
+			return l
+		}
+	}
+	return append(l, s)
+}
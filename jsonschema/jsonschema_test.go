@@ -0,0 +1,33 @@
+package jsonschema
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+var updateFlag = flag.Bool("update", false, "update golden files instead of verifying against them")
+
+func TestJSONSchema(t *testing.T) {
+	m := parser.RequireModule(source.Dir("./testdata"), "car")
+	tests := []struct {
+		configOnly bool
+		gold       string
+	}{
+		{configOnly: false, gold: "testdata/gold/car.json"},
+		{configOnly: true, gold: "testdata/gold/car-config.json"},
+	}
+	for _, test := range tests {
+		t.Log(test.gold)
+		var actual bytes.Buffer
+		w := &Wtr{Out: &actual, ConfigOnly: test.configOnly, Pretty: true}
+		if err := w.Write(m); err != nil {
+			t.Fatal(err)
+		}
+		fc.Gold(t, *updateFlag, actual.Bytes(), test.gold)
+	}
+}
@@ -0,0 +1,265 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/val"
+)
+
+var falseVal = false
+
+// schema is the subset of JSON Schema that YANG maps to
+type schema struct {
+	Schema                string             `json:"$schema,omitempty"`
+	Ref                   string             `json:"$ref,omitempty"`
+	Title                 string             `json:"title,omitempty"`
+	Description           string             `json:"description,omitempty"`
+	Type                  string             `json:"type,omitempty"`
+	Properties            map[string]*schema `json:"properties,omitempty"`
+	Required              []string           `json:"required,omitempty"`
+	UnevaluatedProperties *bool              `json:"unevaluatedProperties,omitempty"`
+	Items                 *schema            `json:"items,omitempty"`
+	MinItems              *int               `json:"minItems,omitempty"`
+	MaxItems              *int               `json:"maxItems,omitempty"`
+	UniqueItems           bool               `json:"uniqueItems,omitempty"`
+	Key                   []string           `json:"x-yang-key,omitempty"`
+	Enum                  []string           `json:"enum,omitempty"`
+	Default               interface{}        `json:"default,omitempty"`
+	Minimum               json.Number        `json:"minimum,omitempty"`
+	Maximum               json.Number        `json:"maximum,omitempty"`
+	MinLength             *int               `json:"minLength,omitempty"`
+	MaxLength             *int               `json:"maxLength,omitempty"`
+	Pattern               string             `json:"pattern,omitempty"`
+	ContentEncoding       string             `json:"contentEncoding,omitempty"`
+	AllOf                 []*schema          `json:"allOf,omitempty"`
+	AnyOf                 []*schema          `json:"anyOf,omitempty"`
+	OneOf                 []*schema          `json:"oneOf,omitempty"`
+	Not                   *schema            `json:"not,omitempty"`
+	Defs                  map[string]*schema `json:"$defs,omitempty"`
+}
+
+func (b *builder) leaf(l meta.Leafable) *schema {
+	s := b.leafType(l.Type())
+	if l.Type().Format().IsList() {
+		s = &schema{
+			Type:  "array",
+			Items: s,
+		}
+		if d, valid := l.(meta.HasConfig); valid && d.Config() {
+			// config leaf-list values must be unique
+			s.UniqueItems = true
+		}
+	}
+	if d, valid := l.(meta.Describable); valid {
+		s.Description = d.Description()
+	}
+	if l.HasDefault() {
+		s.Default = DefaultValue(l)
+	}
+	return s
+}
+
+// leafType references typedef when type is a typedef from this module that
+// has no further restrictions
+func (b *builder) leafType(t *meta.Type) *schema {
+	s := typeSchema(t)
+	td, found := b.module.Typedefs()[t.Ident()]
+	if !found {
+		return s
+	}
+	key := "typedef." + td.Ident()
+	def, found := b.defs[key]
+	if !found {
+		def = typeSchema(td.Type())
+		def.Description = td.Description()
+	}
+	tdType := *def
+	tdType.Description = ""
+	if !equal(&tdType, s) {
+		return s
+	}
+	b.defs[key] = def
+	return &schema{Ref: "#/$defs/" + key}
+}
+
+func typeSchema(t *meta.Type) *schema {
+	s := &schema{}
+	switch t.Format().Single() {
+	case val.FmtString:
+		s.Type = "string"
+		if len(t.Length()) > 0 {
+			min, max := intersectRanges(t.Length())
+			s.MinLength = intPtr(min)
+			s.MaxLength = intPtr(max)
+		}
+		for i, pattern := range Patterns(t) {
+			if i == 0 {
+				s.Pattern = pattern
+			} else {
+				s.AllOf = append(s.AllOf, &schema{Pattern: pattern})
+			}
+		}
+	case val.FmtInt8:
+		integer(s, t, "-128", "127")
+	case val.FmtInt16:
+		integer(s, t, "-32768", "32767")
+	case val.FmtInt32:
+		integer(s, t, "-2147483648", "2147483647")
+	case val.FmtInt64:
+		integer(s, t, "-9223372036854775808", "9223372036854775807")
+	case val.FmtUInt8:
+		integer(s, t, "0", "255")
+	case val.FmtUInt16:
+		integer(s, t, "0", "65535")
+	case val.FmtUInt32:
+		integer(s, t, "0", "4294967295")
+	case val.FmtUInt64:
+		integer(s, t, "0", "18446744073709551615")
+	case val.FmtDecimal64:
+		s.Type = "number"
+		s.Minimum, s.Maximum = intersectRanges(t.Range())
+	case val.FmtBool:
+		s.Type = "boolean"
+	case val.FmtEmpty:
+		// [null] from RFC7951 Section 6.9
+		one := 1
+		s.Type = "array"
+		s.Items = &schema{Type: "null"}
+		s.MinItems = &one
+		s.MaxItems = &one
+	case val.FmtEnum:
+		s.Type = "string"
+		for _, e := range t.Enum() {
+			s.Enum = append(s.Enum, e.Label)
+		}
+	case val.FmtIdentityRef:
+		s.Type = "string"
+		if t.Base() != nil {
+			for ident := range t.Base().Derived() {
+				s.Enum = append(s.Enum, ident)
+			}
+			sort.Strings(s.Enum)
+		}
+	case val.FmtBinary:
+		s.Type = "string"
+		s.ContentEncoding = "base64"
+	case val.FmtBits, val.FmtInstanceRef:
+		s.Type = "string"
+	case val.FmtLeafRef:
+		return typeSchema(t.Resolve())
+	case val.FmtUnion:
+		// any, not one, because values can satisfy more than one type
+		for _, u := range t.Union() {
+			s.AnyOf = append(s.AnyOf, typeSchema(u))
+		}
+	}
+	return s
+}
+
+// Patterns of string type as JSON Schema patterns. Unlike JSON Schema, YANG
+// patterns always match the entire value and a value has to match all of
+// them.
+func Patterns(t *meta.Type) []string {
+	patterns := make([]string, len(t.Patterns()))
+	for i, p := range t.Patterns() {
+		patterns[i] = "^(?:" + p.Pattern + ")$"
+	}
+	return patterns
+}
+
+func integer(s *schema, t *meta.Type, min string, max string) {
+	s.Type = "integer"
+	s.Minimum, s.Maximum = json.Number(min), json.Number(max)
+	if len(t.Range()) == 0 {
+		return
+	}
+	rmin, rmax := intersectRanges(t.Range())
+	if rmin != "" {
+		s.Minimum = rmin
+	}
+	if rmax != "" {
+		s.Maximum = rmax
+	}
+}
+
+// intersectRanges is the highest lower bound and lowest upper bound of ranges
+// of a type and the types it derives from which all have to be satisfied
+func intersectRanges(ranges []*meta.Range) (min json.Number, max json.Number) {
+	for _, r := range ranges {
+		rmin, rmax := rangeBounds(r)
+		if rmin != "" && (min == "" || less(min, rmin)) {
+			min = rmin
+		}
+		if rmax != "" && (max == "" || less(rmax, max)) {
+			max = rmax
+		}
+	}
+	return
+}
+
+func less(a json.Number, b json.Number) bool {
+	x, _ := strconv.ParseFloat(a.String(), 64)
+	y, _ := strconv.ParseFloat(b.String(), 64)
+	return x < y
+}
+
+// rangeBounds of a single range, bounds are empty when they are "min" or "max"
+func rangeBounds(r *meta.Range) (json.Number, json.Number) {
+	max := rangeBound(r.Max)
+	if r.Min == "" {
+		// single value
+		return max, max
+	}
+	return rangeBound(r.Min), max
+}
+
+func rangeBound(s string) json.Number {
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return ""
+	}
+	return json.Number(s)
+}
+
+func intPtr(n json.Number) *int {
+	if n == "" {
+		return nil
+	}
+	i, err := strconv.Atoi(n.String())
+	if err != nil {
+		return nil
+	}
+	return &i
+}
+
+// DefaultValue is default value of leaf or leaf-list as it would appear in
+// JSON that freeconf writes
+func DefaultValue(l meta.Leafable) interface{} {
+	v, err := node.NewValue(l.Type(), l.Default())
+	if err != nil || v == nil {
+		return l.Default()
+	}
+	if !v.Format().IsList() {
+		return jsonValue(v)
+	}
+	var items []interface{}
+	val.ForEach(v, func(i int, item val.Value) {
+		items = append(items, jsonValue(item))
+	})
+	return items
+}
+
+func jsonValue(v val.Value) interface{} {
+	switch v.Format() {
+	case val.FmtEnum:
+		return v.(val.Enum).Label
+	case val.FmtBool, val.FmtDecimal64,
+		val.FmtInt8, val.FmtInt16, val.FmtInt32, val.FmtInt64,
+		val.FmtUInt8, val.FmtUInt16, val.FmtUInt32, val.FmtUInt64:
+		return v.Value()
+	}
+	return v.String()
+}
@@ -0,0 +1,280 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+// Rule identifies a check made on a module
+type Rule string
+
+// Checks that are made. Most follow the guidelines for authors of YANG
+// modules in RFC 8407
+const (
+	MissingDescription   Rule = "missing-description"
+	MissingRevision      Rule = "missing-revision"
+	RevisionDate         Rule = "revision-date"
+	Namespace            Rule = "namespace"
+	Prefix               Rule = "prefix"
+	UnusedGrouping       Rule = "unused-grouping"
+	UnusedTypedef        Rule = "unused-typedef"
+	UnusedImport         Rule = "unused-import"
+	ConfigFalseListNoKey Rule = "config-false-list-no-key"
+	TopLevelMandatory    Rule = "top-level-mandatory"
+	WhenMustPath         Rule = "when-must-path"
+	KebabCase            Rule = "kebab-case"
+
+	// Compile is a problem parser or compiler found so module cannot be used
+	Compile Rule = "compile"
+)
+
+// Rules are all the checks that are made
+var Rules = []Rule{
+	MissingDescription,
+	MissingRevision,
+	RevisionDate,
+	Namespace,
+	Prefix,
+	UnusedGrouping,
+	UnusedTypedef,
+	UnusedImport,
+	ConfigFalseListNoKey,
+	TopLevelMandatory,
+	WhenMustPath,
+	KebabCase,
+	Compile,
+}
+
+// Level is how serious a problem found by a rule is
+type Level string
+
+const (
+	Off     Level = "off"
+	Warning Level = "warning"
+	Error   Level = "error"
+)
+
+// ParseLevel from name of level
+func ParseLevel(s string) (Level, error) {
+	switch l := Level(s); l {
+	case Off, Warning, Error:
+		return l, nil
+	}
+	return "", fmt.Errorf("unknown level %q. expected off, warning or error", s)
+}
+
+// DefaultLevels are the levels of each rule when they are not given in
+// Options. Rules that would make a module unusable or fail at runtime are
+// errors.
+var DefaultLevels = map[Rule]Level{
+	MissingDescription:   Warning,
+	MissingRevision:      Error,
+	RevisionDate:         Error,
+	Namespace:            Error,
+	Prefix:               Warning,
+	UnusedGrouping:       Warning,
+	UnusedTypedef:        Warning,
+	UnusedImport:         Warning,
+	ConfigFalseListNoKey: Warning,
+	TopLevelMandatory:    Warning,
+	WhenMustPath:         Error,
+	KebabCase:            Warning,
+	Compile:              Error,
+}
+
+// Options for which rules to check
+type Options struct {
+
+	// Levels override the default level of rules. Level Off disables rule.
+	Levels map[Rule]Level
+}
+
+func (o Options) level(rule Rule) Level {
+	if l, found := o.Levels[rule]; found {
+		return l
+	}
+	return DefaultLevels[rule]
+}
+
+// Problem is a single place module does not follow a rule
+type Problem struct {
+	Rule  Rule  `json:"rule"`
+	Level Level `json:"level"`
+
+	// Path is schema path of definition with problem
+	Path string `json:"path"`
+
+	// File, Line and Col are where problem is in YANG file, if known
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+	Col  int    `json:"col,omitempty"`
+
+	Message string `json:"message"`
+}
+
+func (p *Problem) String() string {
+	s := fmt.Sprintf("%s %s %s: %s", p.Level, p.Rule, p.Path, p.Message)
+	if p.Line > 0 {
+		loc := meta.Location{File: p.File, Line: p.Line, Col: p.Col}
+		s = loc.String() + " " + s
+	}
+	return s
+}
+
+// Report is all the problems found in a module
+type Report struct {
+	Module   string     `json:"module"`
+	Problems []*Problem `json:"problems"`
+}
+
+// HasErrors is true if any problem is at error level
+func (r *Report) HasErrors() bool {
+	for _, p := range r.Problems {
+		if p.Level == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteText writes a line for each problem
+func (r *Report) WriteText(out io.Writer) error {
+	for _, p := range r.Problems {
+		if _, err := fmt.Fprintln(out, p.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes report for other tools to read
+func (r *Report) WriteJSON(out io.Writer) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Lint loads YANG file both as it was written and compiled and checks it
+// against rules. Problems the parser or compiler finds are reported under
+// rule Compile and when module does not compile, only the rules that need
+// the module as it was written are checked. Error is only returned when
+// module cannot be found.
+func Lint(ypath source.Opener, yangfile string, options Options) (*Report, error) {
+	src, err := parser.LoadModuleWithOptions(ypath, yangfile, parser.Options{Uncompiled: true})
+	if err != nil {
+		diags := meta.DiagnosticsOf(err)
+		if !located(diags) {
+			return nil, err
+		}
+		l := newLinter(yangfile, options)
+		l.diagnostics(diags)
+		return l.done(), nil
+	}
+	compiled, err := parser.LoadModule(ypath, yangfile)
+	if err != nil {
+		l := newLinter(src.Ident(), options)
+		l.diagnostics(meta.DiagnosticsOf(err))
+		l.source(src)
+		return l.done(), nil
+	}
+	return Check(src, compiled, options), nil
+}
+
+// Check modules against rules. Some rules need the module as it was written,
+// src, and some need the module compiled.  Both must be the same module.
+func Check(src *meta.Module, compiled *meta.Module, options Options) *Report {
+	l := newLinter(src.Ident(), options)
+	l.source(src)
+	l.schema(compiled)
+	return l.done()
+}
+
+// located is true when any diagnostic has a location, otherwise error is
+// likely the file could not be found at all
+func located(diags meta.Diagnostics) bool {
+	for _, d := range diags {
+		if d.Location != nil {
+			return true
+		}
+	}
+	return false
+}
+
+type linter struct {
+	options Options
+	report  *Report
+}
+
+func newLinter(module string, options Options) *linter {
+	return &linter{
+		options: options,
+		report:  &Report{Module: module, Problems: []*Problem{}},
+	}
+}
+
+// done sorts problems by where they are in files
+func (l *linter) done() *Report {
+	sort.SliceStable(l.report.Problems, func(i, j int) bool {
+		a, b := l.report.Problems[i], l.report.Problems[j]
+		if a.Line == 0 || b.Line == 0 {
+			return a.Line != 0
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Col < b.Col
+	})
+	return l.report
+}
+
+// diagnostics from parser or compiler. Warnings stay warnings even when rule
+// is at level error.
+func (l *linter) diagnostics(diags meta.Diagnostics) {
+	level := l.options.level(Compile)
+	if level == Off {
+		return
+	}
+	for _, d := range diags {
+		p := &Problem{
+			Rule:    Compile,
+			Level:   level,
+			Path:    l.report.Module,
+			Message: d.Message,
+		}
+		if d.Severity == meta.SeverityWarning {
+			p.Level = Warning
+		}
+		if d.Location != nil {
+			p.File, p.Line, p.Col = d.Location.File, d.Location.Line, d.Location.Col
+		}
+		l.report.Problems = append(l.report.Problems, p)
+	}
+}
+
+func (l *linter) add(rule Rule, m interface{}, path string, msg string, args ...interface{}) {
+	level := l.options.level(rule)
+	if level == Off {
+		return
+	}
+	p := &Problem{
+		Rule:    rule,
+		Level:   level,
+		Path:    path,
+		Message: fmt.Sprintf(msg, args...),
+	}
+	if x, valid := m.(meta.HasLocation); valid {
+		if loc := x.Location(); loc != nil {
+			p.File, p.Line, p.Col = loc.File, loc.Line, loc.Col
+		}
+	}
+	l.report.Problems = append(l.report.Problems, p)
+}
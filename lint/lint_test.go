@@ -0,0 +1,78 @@
+package lint
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/source"
+)
+
+var updateFlag = flag.Bool("update", false, "update golden files instead of verifying against them")
+
+func TestLint(t *testing.T) {
+	ypath := source.Dir("./testdata")
+	good, err := Lint(ypath, "good", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, 0, len(good.Problems))
+
+	bad, err := Lint(ypath, "bad", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, true, bad.HasErrors())
+	var actual bytes.Buffer
+	if err := bad.WriteText(&actual); err != nil {
+		t.Fatal(err)
+	}
+	fc.Gold(t, *updateFlag, actual.Bytes(), "testdata/gold/bad.txt")
+
+	actual.Reset()
+	if err := bad.WriteJSON(&actual); err != nil {
+		t.Fatal(err)
+	}
+	fc.Gold(t, *updateFlag, actual.Bytes(), "testdata/gold/bad.json")
+}
+
+func TestLintLevels(t *testing.T) {
+	levels := make(map[Rule]Level)
+	for _, rule := range Rules {
+		levels[rule] = Off
+	}
+	levels[KebabCase] = Error
+	r, err := Lint(source.Dir("./testdata"), "bad", Options{Levels: levels})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, 1, len(r.Problems))
+	fc.AssertEqual(t, KebabCase, r.Problems[0].Rule)
+	fc.AssertEqual(t, Error, r.Problems[0].Level)
+}
+
+func TestLintCompile(t *testing.T) {
+	ypath := source.Dir("./testdata")
+	r, err := Lint(ypath, "broken", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, true, r.HasErrors())
+	var actual bytes.Buffer
+	if err := r.WriteText(&actual); err != nil {
+		t.Fatal(err)
+	}
+	fc.Gold(t, *updateFlag, actual.Bytes(), "testdata/gold/broken.txt")
+
+	r, err = Lint(ypath, "unparsable", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, 1, len(r.Problems))
+	fc.AssertEqual(t, Compile, r.Problems[0].Rule)
+	fc.AssertEqual(t, 7, r.Problems[0].Line)
+
+	_, err = Lint(ypath, "missing", Options{})
+	fc.AssertEqual(t, true, err != nil)
+}
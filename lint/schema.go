@@ -0,0 +1,220 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/xpath"
+)
+
+// schema checks compiled module where groupings are expanded and imports
+// are loaded
+func (l *linter) schema(m *meta.Module) {
+	// imports are by prefix once compiled
+	for _, prefix := range keys(m.Imports()) {
+		i := m.Imports()[prefix]
+		if i.Module() != nil && prefix != i.Module().Prefix() {
+			l.add(Prefix, i, m.Ident(), "module %s imported as %s instead of its own prefix %s", i.Module().Ident(), prefix, i.Module().Prefix())
+		}
+	}
+	for _, def := range m.DataDefinitions() {
+		if mandatory(def) {
+			l.add(TopLevelMandatory, def, meta.SchemaPath(def), "top-level %s is mandatory", keyword(def))
+		}
+	}
+	w := &schemaWalker{
+		linter: l,
+		seen:   make(map[meta.Definition]bool),
+	}
+	w.definitions(m, true)
+}
+
+type schemaWalker struct {
+	*linter
+
+	// recursive schemas would otherwise never end
+	seen map[meta.Definition]bool
+}
+
+// definitions checks data definitions, actions and notifications. Config is
+// only known for data definitions outside of actions and notifications.
+func (w *schemaWalker) definitions(parent meta.HasDataDefinitions, data bool) {
+	for _, def := range parent.DataDefinitions() {
+		w.definition(def, data)
+	}
+	if x, valid := parent.(meta.HasActions); valid {
+		for _, ident := range keys(x.Actions()) {
+			a := x.Actions()[ident]
+			if a.Input() != nil {
+				w.definition(a.Input(), false)
+			}
+			if a.Output() != nil {
+				w.definition(a.Output(), false)
+			}
+		}
+	}
+	if x, valid := parent.(meta.HasNotifications); valid {
+		for _, ident := range keys(x.Notifications()) {
+			w.definition(x.Notifications()[ident], false)
+		}
+	}
+}
+
+func (w *schemaWalker) definition(def meta.Definition, data bool) {
+	w.constraints(def)
+	switch x := def.(type) {
+	case *meta.List:
+		if data && !x.Config() && len(x.KeyMeta()) == 0 {
+			w.add(ConfigFalseListNoKey, x, meta.SchemaPath(x), "config false list has no key")
+		}
+	case *meta.Choice:
+		for _, ident := range x.CaseIdents() {
+			w.definition(x.Cases()[ident], data)
+		}
+	}
+	if x, valid := def.(meta.HasDataDefinitions); valid {
+		if w.seen[def] {
+			return
+		}
+		w.seen[def] = true
+		w.definitions(x, data)
+	}
+}
+
+// constraints checks paths in "when" and "must" expressions are to nodes
+// that exist
+func (w *schemaWalker) constraints(def meta.Definition) {
+	if x, valid := def.(meta.HasWhen); valid && x.When() != nil {
+		w.paths(def, x.When(), "when", x.When().Expression())
+	}
+	if x, valid := def.(meta.HasMusts); valid {
+		for _, must := range x.Musts() {
+			w.paths(def, must, "must", must.Expression())
+		}
+	}
+}
+
+func (w *schemaWalker) paths(def meta.Definition, stmt meta.HasLocation, keyword string, expr string) {
+	var located interface{} = def
+	if stmt.Location() != nil {
+		located = stmt
+	}
+	// expressions are relative to definition but "when" from a "uses" is
+	// relative to the parent and freeconf evaluates "when" on leafs relative
+	// to the parent too so either is accepted
+	contexts := []meta.Meta{def}
+	if p := dataParent(def); p != nil {
+		contexts = append(contexts, p)
+	}
+	paths, err := xpath.LocationPaths(expr)
+	if err != nil {
+		w.add(WhenMustPath, located, meta.SchemaPath(def), "%s %q is not a valid expression. %s", keyword, expr, err)
+		return
+	}
+	for _, p := range paths {
+		found := false
+		for _, context := range contexts {
+			if resolve(context, p) != nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			w.add(WhenMustPath, located, meta.SchemaPath(def), "%s %q refers to %s which does not exist", keyword, expr, p)
+		}
+	}
+}
+
+// resolve follows location path in schema from context node
+func resolve(context meta.Meta, p *xpath.LocationPath) meta.Meta {
+	found := context
+	steps := p.Steps
+	if p.Absolute {
+		found = meta.RootModule(context)
+		if colon := strings.IndexRune(steps[0], ':'); colon > 0 {
+			m := meta.FindModule(context, steps[0][:colon])
+			if m == nil {
+				return nil
+			}
+			found = m
+		}
+	}
+	for _, step := range steps {
+		switch step {
+		case ".":
+		case "..":
+			found = dataParent(found)
+		default:
+			found = child(found, step)
+		}
+		if found == nil {
+			return nil
+		}
+	}
+	return found
+}
+
+// child is data node with ident, looking into choices as they are not part
+// of the data tree
+func child(parent meta.Meta, ident string) meta.Meta {
+	if colon := strings.IndexRune(ident, ':'); colon >= 0 {
+		ident = ident[colon+1:]
+	}
+	if rpc, valid := parent.(*meta.Rpc); valid {
+		if ident == "input" && rpc.Input() != nil {
+			return rpc.Input()
+		}
+		if ident == "output" && rpc.Output() != nil {
+			return rpc.Output()
+		}
+		return nil
+	}
+	x, valid := parent.(meta.HasDataDefinitions)
+	if !valid {
+		return nil
+	}
+	for _, def := range x.DataDefinitions() {
+		if choice, isChoice := def.(*meta.Choice); isChoice {
+			for _, kase := range choice.Cases() {
+				if found := child(kase, ident); found != nil {
+					return found
+				}
+			}
+		} else if def.Ident() == ident {
+			return def
+		}
+	}
+	return nil
+}
+
+// dataParent is nearest ancestor in data tree, skipping choices and cases
+func dataParent(m meta.Meta) meta.Meta {
+	for p := m.Parent(); p != nil; p = p.Parent() {
+		switch p.(type) {
+		case *meta.Choice, *meta.ChoiceCase:
+			continue
+		}
+		return p
+	}
+	return nil
+}
+
+// mandatory is true for nodes that clients must supply
+func mandatory(def meta.Definition) bool {
+	if x, valid := def.(meta.HasMandatory); valid && x.Mandatory() {
+		return true
+	}
+	if x, valid := def.(meta.HasMinMax); valid && x.MinElements() > 0 {
+		return true
+	}
+	// non-presence containers with mandatory nodes are mandatory too. A
+	// recursive container cannot be mandatory or it would never end.
+	if x, valid := def.(*meta.Container); valid && x.Presence() == "" && !x.IsRecursive() {
+		for _, child := range x.DataDefinitions() {
+			if mandatory(child) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,368 @@
+package lint
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/freeconf/yang/meta"
+)
+
+// source checks module as it was written before groupings are expanded and
+// types resolved so definitions are only checked once and unused definitions
+// can be found.
+func (l *linter) source(m *meta.Module) {
+	w := &sourceWalker{
+		linter:    l,
+		module:    m,
+		prefixes:  make(map[string]bool),
+		groupings: make(map[string]bool),
+		typedefs:  make(map[string]bool),
+	}
+	if len(m.RevisionHistory()) == 0 {
+		l.add(MissingRevision, m, m.Ident(), "no revision statement")
+	}
+	for _, r := range m.RevisionHistory() {
+		if _, err := time.Parse("2006-01-02", r.Ident()); err != nil {
+			l.add(RevisionDate, r, m.Ident(), "revision %q is not a date in format YYYY-MM-DD", r.Ident())
+		}
+	}
+	if u, err := url.Parse(m.Namespace()); err != nil || u.Scheme == "" {
+		l.add(Namespace, m, m.Ident(), "namespace %q is not a URI", m.Namespace())
+	}
+	w.walk(m, true)
+
+	for _, name := range keys(m.Imports()) {
+		i := m.Imports()[name]
+		if !w.prefixes[i.Prefix()] {
+			l.add(UnusedImport, i, m.Ident(), "module %s imported as %s is not used", name, i.Prefix())
+		}
+	}
+	// top-level groupings and typedefs are for other modules to use too so
+	// only nested ones can be unused
+	for _, g := range w.nestedGroupings {
+		if !w.groupings[g.Ident()] {
+			l.add(UnusedGrouping, g, meta.SchemaPath(g), "grouping is not used")
+		}
+	}
+	for _, t := range w.nestedTypedefs {
+		if !w.typedefs[t.Ident()] {
+			l.add(UnusedTypedef, t, meta.SchemaPath(t), "typedef is not used")
+		}
+	}
+}
+
+type sourceWalker struct {
+	*linter
+	module *meta.Module
+
+	// prefixes that were used to refer to imported modules
+	prefixes map[string]bool
+
+	// idents of local groupings and typedefs that were used
+	groupings map[string]bool
+	typedefs  map[string]bool
+
+	nestedGroupings []*meta.Grouping
+	nestedTypedefs  []*meta.Typedef
+}
+
+func (w *sourceWalker) walk(x meta.Meta, top bool) {
+	w.describe(x)
+	w.kebabCase(x)
+	w.extensions(x)
+	if hw, valid := x.(meta.HasWhen); valid && hw.When() != nil {
+		w.path(hw.When().Expression())
+	}
+	if hm, valid := x.(meta.HasMusts); valid {
+		for _, must := range hm.Musts() {
+			w.path(must.Expression())
+		}
+	}
+	if hi, valid := x.(meta.HasIfFeatures); valid {
+		for _, f := range hi.IfFeatures() {
+			w.path(f.Expression())
+		}
+	}
+	if hd, valid := x.(meta.HasDefault); valid && hd.HasDefault() {
+		w.path(fmt.Sprint(hd.Default()))
+	}
+	if ht, valid := x.(meta.HasType); valid {
+		w.typ(ht.Type())
+	}
+	if ht, valid := x.(interface {
+		Typedefs() map[string]*meta.Typedef
+	}); valid {
+		for _, ident := range keys(ht.Typedefs()) {
+			t := ht.Typedefs()[ident]
+			if !top {
+				w.nestedTypedefs = append(w.nestedTypedefs, t)
+			}
+			w.walk(t, false)
+		}
+	}
+	if hg, valid := x.(interface {
+		Groupings() map[string]*meta.Grouping
+	}); valid {
+		for _, ident := range keys(hg.Groupings()) {
+			g := hg.Groupings()[ident]
+			if !top {
+				w.nestedGroupings = append(w.nestedGroupings, g)
+			}
+			w.walk(g, false)
+		}
+	}
+	switch y := x.(type) {
+	case *meta.Module:
+		for _, r := range y.RevisionHistory() {
+			w.extensions(r)
+		}
+		for _, name := range keys(y.Imports()) {
+			w.extensions(y.Imports()[name])
+		}
+		for _, i := range y.Includes() {
+			w.extensions(i)
+		}
+		for _, ident := range keys(y.ExtensionDefs()) {
+			w.walk(y.ExtensionDefs()[ident], false)
+		}
+		for _, ident := range keys(y.Features()) {
+			w.walk(y.Features()[ident], false)
+		}
+		for _, ident := range keys(y.Identities()) {
+			identity := y.Identities()[ident]
+			for _, base := range identity.BaseIds() {
+				w.qualified(base)
+			}
+			w.walk(identity, false)
+		}
+		for _, d := range y.Deviations() {
+			w.path(d.Ident())
+			w.extensions(d)
+			if d.Add != nil {
+				w.deviate(d.Add, d.Add.Musts())
+			}
+			if d.Replace != nil {
+				w.deviate(d.Replace, nil)
+			}
+			if d.Delete != nil {
+				w.deviate(d.Delete, d.Delete.Musts())
+			}
+		}
+	case *meta.Uses:
+		if local := w.qualified(y.Ident()); local != "" {
+			w.groupings[local] = true
+		}
+		for _, r := range y.Refinements() {
+			w.path(r.Ident())
+			w.walk(r, false)
+		}
+	case *meta.Augment:
+		w.path(y.Ident())
+	case *meta.Choice:
+		for _, ident := range y.CaseIdents() {
+			w.walk(y.Cases()[ident], false)
+		}
+	case *meta.Rpc:
+		if y.Input() != nil {
+			w.walk(y.Input(), false)
+		}
+		if y.Output() != nil {
+			w.walk(y.Output(), false)
+		}
+	}
+	if hd, valid := x.(meta.HasDataDefinitions); valid {
+		for _, def := range hd.DataDefinitions() {
+			w.walk(def, false)
+		}
+	}
+	if ha, valid := x.(interface{ Actions() map[string]*meta.Rpc }); valid {
+		for _, ident := range keys(ha.Actions()) {
+			w.walk(ha.Actions()[ident], false)
+		}
+	}
+	if hn, valid := x.(interface {
+		Notifications() map[string]*meta.Notification
+	}); valid {
+		for _, ident := range keys(hn.Notifications()) {
+			w.walk(hn.Notifications()[ident], false)
+		}
+	}
+	if ha, valid := x.(meta.HasAugments); valid {
+		for _, a := range ha.Augments() {
+			w.walk(a, false)
+		}
+	}
+}
+
+func (w *sourceWalker) deviate(d meta.HasExtensions, musts []*meta.Must) {
+	w.extensions(d)
+	for _, must := range musts {
+		w.path(must.Expression())
+	}
+}
+
+func (w *sourceWalker) typ(t *meta.Type) {
+	if t == nil {
+		return
+	}
+	if local := w.qualified(t.Ident()); local != "" {
+		w.typedefs[local] = true
+	}
+	w.qualified(t.BaseId())
+	w.path(t.Path())
+	w.extensions(t)
+	for _, u := range t.Union() {
+		w.typ(u)
+	}
+}
+
+// qualified records the prefix of an identifier like "x:foo" and returns the
+// identifier if it refers to this module
+func (w *sourceWalker) qualified(ident string) string {
+	colon := strings.IndexRune(ident, ':')
+	if colon < 0 {
+		return ident
+	}
+	prefix := ident[:colon]
+	w.prefixes[prefix] = true
+	if prefix == w.module.Prefix() {
+		return ident[colon+1:]
+	}
+	return ""
+}
+
+var prefixRegex = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_.\-]*):`)
+
+// path records prefixes in schema paths and xpath expressions
+func (w *sourceWalker) path(p string) {
+	for _, match := range prefixRegex.FindAllStringSubmatch(p, -1) {
+		w.prefixes[match[1]] = true
+	}
+}
+
+func (w *sourceWalker) extensions(x meta.HasExtensions) {
+	for _, e := range x.Extensions() {
+		w.prefixes[e.Prefix()] = true
+		w.extensions(e)
+	}
+}
+
+// describe checks statements that RFC 8407 Sec. 4.14 says must have a
+// description
+func (w *sourceWalker) describe(x meta.Meta) {
+	switch x.(type) {
+	case *meta.Module, *meta.Typedef, *meta.Grouping, *meta.Identity,
+		*meta.Feature, *meta.ExtensionDef, *meta.Container, *meta.List,
+		*meta.Leaf, *meta.LeafList, *meta.Any, *meta.Choice, *meta.Rpc,
+		*meta.Notification, *meta.Augment:
+	default:
+		return
+	}
+	if x.(meta.Describable).Description() == "" {
+		w.add(MissingDescription, x, meta.SchemaPath(x), "%s has no description", keyword(x))
+	}
+}
+
+var kebabRegex = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// kebabCase checks names of new definitions follow RFC 8407 Sec. 4.3.1. Other
+// identifiers like augment paths refer to existing definitions.
+func (w *sourceWalker) kebabCase(x meta.Meta) {
+	switch x.(type) {
+	case *meta.Module, *meta.Typedef, *meta.Grouping, *meta.Identity,
+		*meta.Feature, *meta.ExtensionDef, *meta.Container, *meta.List,
+		*meta.Leaf, *meta.LeafList, *meta.Any, *meta.Choice, *meta.ChoiceCase,
+		*meta.Rpc, *meta.Notification:
+	default:
+		return
+	}
+	ident := x.(meta.Identifiable).Ident()
+	if !kebabRegex.MatchString(ident) {
+		w.add(KebabCase, x, meta.SchemaPath(x), "%s should be lowercase letters, numbers and dashes", ident)
+	}
+}
+
+func keyword(x meta.Meta) string {
+	switch x.(type) {
+	case *meta.Module:
+		return "module"
+	case *meta.Typedef:
+		return "typedef"
+	case *meta.Grouping:
+		return "grouping"
+	case *meta.Identity:
+		return "identity"
+	case *meta.Feature:
+		return "feature"
+	case *meta.ExtensionDef:
+		return "extension"
+	case *meta.Container:
+		return "container"
+	case *meta.List:
+		return "list"
+	case *meta.Leaf:
+		return "leaf"
+	case *meta.LeafList:
+		return "leaf-list"
+	case *meta.Any:
+		return "anydata"
+	case *meta.Choice:
+		return "choice"
+	case *meta.ChoiceCase:
+		return "case"
+	case *meta.Rpc:
+		if _, isModule := x.Parent().(*meta.Module); isModule {
+			return "rpc"
+		}
+		return "action"
+	case *meta.Notification:
+		return "notification"
+	case *meta.Augment:
+		return "augment"
+	}
+	return fmt.Sprintf("%T", x)
+}
+
+func keys(m interface{}) []string {
+	var idents []string
+	switch x := m.(type) {
+	case map[string]*meta.Import:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	case map[string]*meta.Typedef:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	case map[string]*meta.Grouping:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	case map[string]*meta.Rpc:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	case map[string]*meta.Notification:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	case map[string]*meta.ExtensionDef:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	case map[string]*meta.Feature:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	case map[string]*meta.Identity:
+		for k := range x {
+			idents = append(idents, k)
+		}
+	}
+	sort.Strings(idents)
+	return idents
+}
@@ -0,0 +1,250 @@
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+// document is a YANG file open in the editor. Text may not be valid YANG
+// while it's being edited so statements are found with a forgiving scanner
+// and meta is from the last time the text was parsed.
+type document struct {
+	uri   string
+	path  string
+	text  string
+	lines []int
+
+	stmts []*statement
+
+	// module is as it was written, groupings and typedefs have not been
+	// expanded so definitions can be found where they were written
+	module *meta.Module
+
+	// compiled is from the last time module compiled without any problems so
+	// schema paths can still be found while text is being edited
+	compiled *meta.Module
+
+	diags meta.Diagnostics
+
+	// defs are meta from this file by where they are in the text
+	defs map[int]meta.Meta
+}
+
+func newDocument(uri string, text string) *document {
+	d := &document{uri: uri, path: uriPath(uri)}
+	d.setText(text)
+	return d
+}
+
+func (d *document) setText(text string) {
+	d.text = text
+	d.lines = []int{0}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			d.lines = append(d.lines, i+1)
+		}
+	}
+	d.stmts = scan(text)
+}
+
+// dir is where imported and included modules are looked for first
+func (d *document) dir() string {
+	return filepath.Dir(d.path)
+}
+
+func (d *document) load(ypath source.Opener) {
+	d.module, _ = parser.LoadModuleFromStringWithOptions(ypath, d.text, parser.Options{Uncompiled: true})
+	m, err := parser.LoadModuleFromString(ypath, d.text)
+	d.diags = meta.DiagnosticsOf(err)
+	if err == nil {
+		d.compiled = m
+	}
+	d.defs = make(map[int]meta.Meta)
+	if d.module != nil {
+		walk(d.module, func(x meta.Meta) {
+			if loc := location(x); loc != nil && loc.File == "" {
+				d.defs[d.offset(loc.Line-1, loc.Col-1)] = x
+			}
+		})
+	}
+}
+
+func (d *document) offset(line int, col int) int {
+	if line < 0 {
+		return 0
+	}
+	if line >= len(d.lines) {
+		return len(d.text)
+	}
+	offset := d.lines[line] + col
+	if offset > len(d.text) {
+		return len(d.text)
+	}
+	return offset
+}
+
+func (d *document) offsetOf(p Position) int {
+	return d.offset(p.Line, p.Character)
+}
+
+func (d *document) position(offset int) Position {
+	line := sort.Search(len(d.lines), func(i int) bool {
+		return d.lines[i] > offset
+	}) - 1
+	return Position{Line: line, Character: offset - d.lines[line]}
+}
+
+func (d *document) span(start int, end int) Range {
+	return Range{Start: d.position(start), End: d.position(end)}
+}
+
+// enclosing is the chain of statements that contain offset, outermost first
+func (d *document) enclosing(offset int) []*statement {
+	var chain []*statement
+	stmts := d.stmts
+	for {
+		var found *statement
+		for _, s := range stmts {
+			if s.start <= offset && offset < s.end {
+				found = s
+				break
+			}
+		}
+		if found == nil {
+			return chain
+		}
+		chain = append(chain, found)
+		stmts = found.children
+	}
+}
+
+// def is meta that statement defined, nil if statement does not define
+// anything that the meta tree keeps
+func (d *document) def(s *statement) meta.Meta {
+	return d.defs[s.start]
+}
+
+// statement is a YANG statement as written in the text with offsets of its
+// parts.
+type statement struct {
+	keyword string
+
+	// arg has quotes removed and concatenated strings joined
+	arg string
+
+	// start of keyword to end after ';' or '}'
+	start, end int
+
+	// argStart to argEnd is all of argument including quotes
+	argStart, argEnd int
+
+	parent   *statement
+	children []*statement
+}
+
+func (s *statement) inArg(offset int) bool {
+	return s.argStart < s.argEnd && s.argStart <= offset && offset <= s.argEnd
+}
+
+// scan finds statements in text without failing on any errors so that it can
+// be used while text is being edited
+func scan(text string) []*statement {
+	tokens := parser.Tokens(text)
+	var top []*statement
+	var parent *statement
+	for i := 0; i < len(tokens); {
+		t := tokens[i]
+		i++
+		if t.Text == "}" && !t.Quoted {
+			if parent != nil {
+				parent.end = t.End
+				parent = parent.parent
+			}
+			continue
+		}
+		if (t.Text == ";" || t.Text == "{") && !t.Quoted {
+			continue
+		}
+		s := &statement{keyword: t.Text, start: t.Start, end: t.End, parent: parent}
+		if parent == nil {
+			top = append(top, s)
+		} else {
+			parent.children = append(parent.children, s)
+		}
+		var arg []string
+		for ; i < len(tokens); i++ {
+			a := tokens[i]
+			if !a.Quoted && (a.Text == ";" || a.Text == "{" || a.Text == "}") {
+				break
+			}
+			if !a.Quoted && a.Text == "+" {
+				continue
+			}
+			if len(arg) == 0 {
+				s.argStart = a.Start
+			}
+			s.argEnd = a.End
+			arg = append(arg, a.Text)
+		}
+		s.arg = strings.Join(arg, "")
+		if i >= len(tokens) {
+			s.end = len(text)
+			break
+		}
+		end := tokens[i]
+		switch end.Text {
+		case ";":
+			s.end = end.End
+			i++
+		case "{":
+			s.end = len(text)
+			parent = s
+			i++
+		default:
+			// missing ';' so statement ends where next one starts
+			if s.argEnd > 0 {
+				s.end = s.argEnd
+			}
+		}
+	}
+	return top
+}
+
+// word is identifier, prefixed identifier or path in text around offset
+func word(text string, offset int) (string, int) {
+	isWord := func(c byte) bool {
+		return c == '_' || c == '-' || c == '.' || c == ':' || c == '/' ||
+			('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+	}
+	start := offset
+	for start > 0 && isWord(text[start-1]) {
+		start--
+	}
+	end := offset
+	for end < len(text) && isWord(text[end]) {
+		end++
+	}
+	return text[start:end], start
+}
+
+func uriPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func pathURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return u.String()
+}
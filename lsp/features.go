@@ -0,0 +1,342 @@
+package lsp
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/parser"
+)
+
+// From RFC7950 Section 14 - YANG ABNF Grammar
+var statementKeywords = []string{
+	"action", "anydata", "anyxml", "argument", "augment", "base",
+	"belongs-to", "bit", "case", "choice", "config", "contact", "container",
+	"default", "description", "deviate", "deviation", "enum",
+	"error-app-tag", "error-message", "extension", "feature",
+	"fraction-digits", "grouping", "identity", "if-feature", "import",
+	"include", "input", "key", "leaf", "leaf-list", "length", "list",
+	"mandatory", "max-elements", "min-elements", "modifier", "module",
+	"must", "namespace", "notification", "ordered-by", "organization",
+	"output", "path", "pattern", "position", "prefix", "presence", "range",
+	"reference", "refine", "require-instance", "revision", "revision-date",
+	"rpc", "status", "submodule", "type", "typedef", "unique", "units",
+	"uses", "value", "when", "yang-version", "yin-element",
+}
+
+// From RFC7950 Section 4.2.4 - Built-In Types
+var builtinTypes = []string{
+	"binary", "bits", "boolean", "decimal64", "empty", "enumeration",
+	"identityref", "instance-identifier", "int8", "int16", "int32", "int64",
+	"leafref", "string", "uint8", "uint16", "uint32", "uint64", "union",
+}
+
+// scoped finds definitions of one kind that x may have, nil if x does not
+// have any
+type scoped func(x meta.Meta) map[string]meta.Meta
+
+func groupings(x meta.Meta) map[string]meta.Meta {
+	defs := make(map[string]meta.Meta)
+	if hg, valid := x.(interface {
+		Groupings() map[string]*meta.Grouping
+	}); valid {
+		for ident, g := range hg.Groupings() {
+			defs[ident] = g
+		}
+	}
+	return defs
+}
+
+func typedefs(x meta.Meta) map[string]meta.Meta {
+	defs := make(map[string]meta.Meta)
+	if ht, valid := x.(interface {
+		Typedefs() map[string]*meta.Typedef
+	}); valid {
+		for ident, t := range ht.Typedefs() {
+			defs[ident] = t
+		}
+	}
+	return defs
+}
+
+func identities(x meta.Meta) map[string]meta.Meta {
+	defs := make(map[string]meta.Meta)
+	if m, valid := x.(*meta.Module); valid {
+		for ident, i := range m.Identities() {
+			defs[ident] = i
+		}
+	}
+	return defs
+}
+
+func features(x meta.Meta) map[string]meta.Meta {
+	defs := make(map[string]meta.Meta)
+	if m, valid := x.(*meta.Module); valid {
+		for ident, f := range m.Features() {
+			defs[ident] = f
+		}
+	}
+	return defs
+}
+
+// references are the statements whose argument names a definition and where
+// to find it
+var references = map[string]scoped{
+	"uses":       groupings,
+	"type":       typedefs,
+	"base":       identities,
+	"if-feature": features,
+}
+
+// definition is where thing under cursor is defined
+func (s *Server) definition(d *document, p Position) *Location {
+	x := s.target(d, d.offsetOf(p))
+	if x == nil {
+		return nil
+	}
+	loc := location(x)
+	if loc == nil {
+		return nil
+	}
+	if loc.File == "" {
+		start := d.offset(loc.Line-1, loc.Col-1)
+		r := d.span(start, start)
+		if path := d.enclosing(start); len(path) > 0 {
+			if stmt := path[len(path)-1]; stmt.start == start && stmt.argEnd > 0 {
+				r = d.span(stmt.argStart, stmt.argEnd)
+			}
+		}
+		return &Location{URI: d.uri, Range: r}
+	}
+	fname := s.file(d, loc.File)
+	if fname == "" {
+		return nil
+	}
+	start := Position{Line: loc.Line - 1, Character: loc.Col - 1}
+	return &Location{URI: pathURI(fname), Range: Range{Start: start, End: start}}
+}
+
+// hover is the description of thing under cursor
+func (s *Server) hover(d *document, p Position) *Hover {
+	x := s.target(d, d.offsetOf(p))
+	if x == nil {
+		return nil
+	}
+	var md strings.Builder
+	md.WriteString("```yang\n")
+	md.WriteString(keyword(x))
+	if id := ident(x); id != "" {
+		md.WriteString(" ")
+		md.WriteString(id)
+	}
+	md.WriteString("\n```")
+	if desc := description(x); desc != "" {
+		md.WriteString("\n\n")
+		md.WriteString(desc)
+	}
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: md.String()}}
+}
+
+// target is the definition referenced by statement argument under cursor or
+// the definition of the statement itself
+func (s *Server) target(d *document, offset int) meta.Meta {
+	path := d.enclosing(offset)
+	if len(path) == 0 {
+		return nil
+	}
+	stmt := path[len(path)-1]
+	if stmt.inArg(offset) {
+		w, start := word(d.text, offset)
+		colon := strings.IndexRune(w, ':')
+		switch {
+		case stmt.keyword == "import":
+			return s.imported(d, stmt.arg)
+		case stmt.keyword == "augment" || stmt.keyword == "deviation":
+			return s.schema(d, w, offset-start)
+		case colon > 0 && offset-start <= colon && !strings.ContainsRune(w[:colon], '/'):
+			return s.importedByPrefix(d, w[:colon])
+		}
+		if find, valid := references[stmt.keyword]; valid {
+			return s.lookup(d, path, w, find)
+		}
+	}
+	return d.def(stmt)
+}
+
+// lookup finds definition by its possibly prefixed identifier starting
+// with the closest statement in path
+func (s *Server) lookup(d *document, path []*statement, id string, find scoped) meta.Meta {
+	if d.module == nil {
+		return nil
+	}
+	prefix, local := "", id
+	if colon := strings.IndexRune(id, ':'); colon >= 0 {
+		prefix, local = id[:colon], id[colon+1:]
+	}
+	if prefix != "" && prefix != d.module.Prefix() {
+		m := s.importedByPrefix(d, prefix)
+		if m == nil {
+			return nil
+		}
+		return find(m)[local]
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		if x := d.def(path[i]); x != nil {
+			if found, valid := find(x)[local]; valid {
+				return found
+			}
+		}
+	}
+	return find(d.module)[local]
+}
+
+// schema is definition in schema path up to segment under cursor
+func (s *Server) schema(d *document, path string, offset int) meta.Meta {
+	if d.compiled == nil || !strings.HasPrefix(path, "/") {
+		return nil
+	}
+	if end := strings.IndexRune(path[offset:], '/'); end >= 0 {
+		path = path[:offset+end]
+	}
+	if path == "/" {
+		return nil
+	}
+	def := meta.Find(d.compiled, path)
+	if def == nil {
+		return nil
+	}
+	return def
+}
+
+func (s *Server) importedByPrefix(d *document, prefix string) meta.Meta {
+	if d.module == nil {
+		return nil
+	}
+	if prefix == d.module.Prefix() {
+		return d.module
+	}
+	for name, i := range d.module.Imports() {
+		if i.Prefix() == prefix {
+			return s.imported(d, name)
+		}
+	}
+	return nil
+}
+
+// imported module as it was written
+func (s *Server) imported(d *document, name string) meta.Meta {
+	m, _ := parser.LoadModuleWithOptions(s.opener(d), name, parser.Options{Uncompiled: true})
+	if m == nil {
+		return nil
+	}
+	return m
+}
+
+// complete offers keywords at the start of a statement and definitions that
+// are in scope in arguments of statements that reference them
+func (s *Server) complete(d *document, p Position) []CompletionItem {
+	offset := d.offsetOf(p)
+	lineStart := d.offset(p.Line, 0)
+	before := d.text[lineStart:offset]
+	fields := strings.Fields(before)
+	inKeyword := len(fields) == 0 || (len(fields) == 1 && strings.HasSuffix(before, fields[0]))
+	if inKeyword {
+		items := make([]CompletionItem, len(statementKeywords))
+		for i, kywd := range statementKeywords {
+			items[i] = CompletionItem{Label: kywd, Kind: CompletionKeyword}
+		}
+		return items
+	}
+	find, valid := references[fields[0]]
+	if !valid || len(fields) > 2 || d.module == nil {
+		return nil
+	}
+	// statement being written is likely incomplete so scope is from the
+	// statements that enclose it
+	var path []*statement
+	for _, stmt := range d.enclosing(offset) {
+		if stmt.start < lineStart {
+			path = append(path, stmt)
+		}
+	}
+	var items []CompletionItem
+	add := func(defs map[string]meta.Meta, prefix string, kind int) {
+		for ident, x := range defs {
+			items = append(items, CompletionItem{Label: prefix + ident, Kind: kind, Detail: keyword(x)})
+		}
+	}
+	seen := make(map[string]bool)
+	for i := len(path) - 1; i >= 0; i-- {
+		if x := d.def(path[i]); x != nil {
+			defs := find(x)
+			for ident := range defs {
+				if seen[ident] {
+					delete(defs, ident)
+				}
+				seen[ident] = true
+			}
+			add(defs, "", CompletionReference)
+		}
+	}
+	if len(path) == 0 {
+		add(find(d.module), "", CompletionReference)
+	}
+	for name, i := range d.module.Imports() {
+		if m := s.imported(d, name); m != nil {
+			add(find(m), i.Prefix()+":", CompletionReference)
+		}
+	}
+	if fields[0] == "type" {
+		for _, t := range builtinTypes {
+			items = append(items, CompletionItem{Label: t, Kind: CompletionKeyword, Detail: "built-in type"})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Label < items[j].Label
+	})
+	return items
+}
+
+// symbols are outline of definitions in document from meta tree
+func (s *Server) symbols(d *document) []*DocumentSymbol {
+	if d.module == nil {
+		return nil
+	}
+	var top []*DocumentSymbol
+	parents := make(map[meta.Meta]*DocumentSymbol)
+	walk(d.module, func(x meta.Meta) {
+		kind := symbolKind(x)
+		loc := location(x)
+		if kind == 0 || loc == nil || loc.File != "" {
+			return
+		}
+		start := d.offset(loc.Line-1, loc.Col-1)
+		path := d.enclosing(start)
+		if len(path) == 0 {
+			return
+		}
+		stmt := path[len(path)-1]
+		sym := &DocumentSymbol{
+			Name:           ident(x),
+			Detail:         keyword(x),
+			Kind:           kind,
+			Range:          d.span(stmt.start, stmt.end),
+			SelectionRange: d.span(stmt.start, stmt.start+len(stmt.keyword)),
+		}
+		if stmt.argEnd > 0 {
+			sym.SelectionRange = d.span(stmt.argStart, stmt.argEnd)
+		}
+		if sym.Name == "" {
+			sym.Name = stmt.keyword
+		}
+		parents[x] = sym
+		for p := x.Parent(); p != nil; p = p.Parent() {
+			if parent, found := parents[p]; found {
+				parent.Children = append(parent.Children, sym)
+				return
+			}
+		}
+		top = append(top, sym)
+	})
+	return top
+}
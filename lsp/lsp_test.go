@@ -0,0 +1,161 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+)
+
+var updateFlag = flag.Bool("update", false, "update golden files instead of verifying against them")
+
+func openTestDoc(t *testing.T, s *Server, fname string) *document {
+	t.Helper()
+	text, err := ioutil.ReadFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := newDocument(pathURI(fname), string(text))
+	d.load(s.opener(d))
+	if d.module == nil {
+		t.Fatal(d.diags)
+	}
+	return d
+}
+
+func TestDefinition(t *testing.T) {
+	s := NewServer("")
+	d := openTestDoc(t, s, "testdata/car.yang")
+	tests := []struct {
+		at       Position
+		file     string
+		expected Position
+	}{
+		{at: Position{Line: 48, Character: 14}, file: "car.yang", expected: Position{Line: 22, Character: 13}},
+		{at: Position{Line: 28, Character: 18}, file: "car.yang", expected: Position{Line: 24, Character: 16}},
+		{at: Position{Line: 35, Character: 18}, file: "car.yang", expected: Position{Line: 17, Character: 12}},
+		{at: Position{Line: 38, Character: 24}, file: "car.yang", expected: Position{Line: 8, Character: 12}},
+		{at: Position{Line: 52, Character: 20}, file: "car.yang", expected: Position{Line: 32, Character: 14}},
+		{at: Position{Line: 13, Character: 16}, file: "types.yang", expected: Position{Line: 12, Character: 4}},
+		{at: Position{Line: 39, Character: 20}, file: "types.yang", expected: Position{Line: 5, Character: 4}},
+		{at: Position{Line: 49, Character: 16}, file: "types.yang", expected: Position{Line: 16, Character: 4}},
+		{at: Position{Line: 49, Character: 13}, file: "types.yang", expected: Position{Line: 0, Character: 0}},
+		{at: Position{Line: 3, Character: 12}, file: "types.yang", expected: Position{Line: 0, Character: 0}},
+	}
+	for _, test := range tests {
+		loc := s.definition(d, test.at)
+		if loc == nil {
+			t.Errorf("no definition at %v", test.at)
+			continue
+		}
+		if !strings.HasSuffix(loc.URI, "/testdata/"+test.file) || !fc.AssertEqual(t, test.expected, loc.Range.Start) {
+			t.Errorf("at %v got %s", test.at, loc.URI)
+		}
+	}
+	fc.AssertEqual(t, true, s.definition(d, Position{Line: 46, Character: 18}) == nil)
+}
+
+func TestHover(t *testing.T) {
+	s := NewServer("")
+	d := openTestDoc(t, s, "testdata/car.yang")
+	h := s.hover(d, Position{Line: 35, Character: 18})
+	fc.AssertEqual(t, "```yang\ntypedef rpm\n```\n\nrevolutions per minute", h.Contents.Value)
+	h = s.hover(d, Position{Line: 32, Character: 15})
+	fc.AssertEqual(t, "```yang\ncontainer engine\n```\n\nmakes car go", h.Contents.Value)
+	h = s.hover(d, Position{Line: 13, Character: 16})
+	fc.AssertEqual(t, "```yang\nidentity vehicle\n```\n\nanything that moves", h.Contents.Value)
+}
+
+func TestComplete(t *testing.T) {
+	s := NewServer("")
+	d := openTestDoc(t, s, "testdata/car.yang")
+	labels := func(items []CompletionItem) []string {
+		var l []string
+		for _, item := range items {
+			l = append(l, item.Label)
+		}
+		return l
+	}
+	fc.AssertEqual(t, []string{"t:named", "wheel"}, labels(s.complete(d, Position{Line: 48, Character: 13})))
+	types := strings.Join(labels(s.complete(d, Position{Line: 28, Character: 17})), " ")
+	fc.AssertEqual(t, true, strings.Contains(types, "pressure rpm string t:percent uint16"))
+	fc.AssertEqual(t, len(statementKeywords), len(s.complete(d, Position{Line: 7, Character: 0})))
+	fc.AssertEqual(t, len(statementKeywords), len(s.complete(d, Position{Line: 44, Character: 10})))
+	fc.AssertEqual(t, 0, len(s.complete(d, Position{Line: 44, Character: 12})))
+}
+
+func TestSymbols(t *testing.T) {
+	s := NewServer("")
+	d := openTestDoc(t, s, "testdata/car.yang")
+	actual, err := json.MarshalIndent(s.symbols(d), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.Gold(t, *updateFlag, actual, "testdata/gold/car-symbols.json")
+}
+
+func TestServe(t *testing.T) {
+	var in bytes.Buffer
+	send := func(id int, method string, params interface{}) {
+		msg := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+		if id > 0 {
+			msg["id"] = id
+		}
+		if err := writeMessage(&in, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	uri := pathURI("testdata/broken.yang")
+	broken := `module broken {
+	namespace "urn:x";
+	prefix "b";
+	leaf a {
+		type nope;
+	}
+}`
+	send(1, "initialize", map[string]interface{}{})
+	send(0, "initialized", map[string]interface{}{})
+	send(0, "textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri, "languageId": "yang", "version": 1, "text": broken},
+	})
+	send(2, "textDocument/hover", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri},
+		"position":     Position{Line: 3, Character: 7},
+	})
+	send(3, "bogus", map[string]interface{}{})
+	send(4, "shutdown", nil)
+	send(0, "exit", nil)
+
+	var out bytes.Buffer
+	if err := NewServer("").Serve(&in, &out); err != nil {
+		t.Fatal(err)
+	}
+	r := bufio.NewReader(&out)
+	var replies []map[string]interface{}
+	for {
+		msg, err := readMessage(r)
+		if err != nil {
+			break
+		}
+		raw, _ := json.Marshal(msg)
+		var reply map[string]interface{}
+		json.Unmarshal(raw, &reply)
+		replies = append(replies, reply)
+	}
+	if !fc.AssertEqual(t, 5, len(replies)) {
+		t.FailNow()
+	}
+	fc.AssertEqual(t, "textDocument/publishDiagnostics", replies[1]["method"])
+	diags := replies[1]["params"].(map[string]interface{})["diagnostics"].([]interface{})
+	fc.AssertEqual(t, 1, len(diags))
+	start := diags[0].(map[string]interface{})["range"].(map[string]interface{})["start"]
+	fc.AssertEqual(t, map[string]interface{}{"line": 3.0, "character": 1.0}, start)
+	hover := replies[2]["result"].(map[string]interface{})["contents"].(map[string]interface{})
+	fc.AssertEqual(t, "```yang\nleaf a\n```", hover["value"])
+	fc.AssertEqual(t, float64(codeMethodNotFound), replies[3]["error"].(map[string]interface{})["code"])
+}
@@ -0,0 +1,214 @@
+package lsp
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/freeconf/yang/meta"
+)
+
+// walk visits every definition of module as it was written, parents before
+// children
+func walk(x meta.Meta, visit func(meta.Meta)) {
+	visit(x)
+	var children []meta.Meta
+	if ht, valid := x.(interface {
+		Typedefs() map[string]*meta.Typedef
+	}); valid {
+		for _, t := range ht.Typedefs() {
+			children = append(children, t)
+		}
+	}
+	if hg, valid := x.(interface {
+		Groupings() map[string]*meta.Grouping
+	}); valid {
+		for _, g := range hg.Groupings() {
+			children = append(children, g)
+		}
+	}
+	switch y := x.(type) {
+	case *meta.Module:
+		for _, i := range y.Imports() {
+			children = append(children, i)
+		}
+		for _, i := range y.Includes() {
+			children = append(children, i)
+		}
+		for _, e := range y.ExtensionDefs() {
+			children = append(children, e)
+		}
+		for _, f := range y.Features() {
+			children = append(children, f)
+		}
+		for _, i := range y.Identities() {
+			children = append(children, i)
+		}
+		for _, d := range y.Deviations() {
+			children = append(children, d)
+		}
+	case *meta.Uses:
+		for _, r := range y.Refinements() {
+			children = append(children, r)
+		}
+	case *meta.Choice:
+		for _, c := range y.Cases() {
+			children = append(children, c)
+		}
+	case *meta.Rpc:
+		if y.Input() != nil {
+			children = append(children, y.Input())
+		}
+		if y.Output() != nil {
+			children = append(children, y.Output())
+		}
+	}
+	if hd, valid := x.(meta.HasDataDefinitions); valid {
+		for _, def := range hd.DataDefinitions() {
+			children = append(children, def)
+		}
+	}
+	if ha, valid := x.(interface{ Actions() map[string]*meta.Rpc }); valid {
+		for _, a := range ha.Actions() {
+			children = append(children, a)
+		}
+	}
+	if hn, valid := x.(interface {
+		Notifications() map[string]*meta.Notification
+	}); valid {
+		for _, n := range hn.Notifications() {
+			children = append(children, n)
+		}
+	}
+	if ha, valid := x.(meta.HasAugments); valid {
+		for _, a := range ha.Augments() {
+			children = append(children, a)
+		}
+	}
+	sortByLocation(children)
+	for _, child := range children {
+		walk(child, visit)
+	}
+}
+
+func sortByLocation(defs []meta.Meta) {
+	sort.SliceStable(defs, func(i, j int) bool {
+		a, b := location(defs[i]), location(defs[j])
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Col < b.Col
+	})
+}
+
+func location(x meta.Meta) *meta.Location {
+	if hl, valid := x.(meta.HasLocation); valid {
+		return hl.Location()
+	}
+	return nil
+}
+
+func description(x meta.Meta) string {
+	if d, valid := x.(meta.Describable); valid {
+		return d.Description()
+	}
+	return ""
+}
+
+func ident(x meta.Meta) string {
+	if i, valid := x.(meta.Identifiable); valid {
+		return i.Ident()
+	}
+	return ""
+}
+
+func keyword(x meta.Meta) string {
+	switch x.(type) {
+	case *meta.Module:
+		return "module"
+	case *meta.Typedef:
+		return "typedef"
+	case *meta.Grouping:
+		return "grouping"
+	case *meta.Identity:
+		return "identity"
+	case *meta.Feature:
+		return "feature"
+	case *meta.ExtensionDef:
+		return "extension"
+	case *meta.Container:
+		return "container"
+	case *meta.List:
+		return "list"
+	case *meta.Leaf:
+		return "leaf"
+	case *meta.LeafList:
+		return "leaf-list"
+	case *meta.Any:
+		return "anydata"
+	case *meta.Choice:
+		return "choice"
+	case *meta.ChoiceCase:
+		return "case"
+	case *meta.Rpc:
+		if _, isModule := x.Parent().(*meta.Module); isModule {
+			return "rpc"
+		}
+		return "action"
+	case *meta.RpcInput:
+		return "input"
+	case *meta.RpcOutput:
+		return "output"
+	case *meta.Notification:
+		return "notification"
+	case *meta.Augment:
+		return "augment"
+	case *meta.Uses:
+		return "uses"
+	case *meta.Deviation:
+		return "deviation"
+	}
+	return fmt.Sprintf("%T", x)
+}
+
+// symbolKind is closest LSP symbol kind to definition or 0 if definition
+// does not belong in document outline
+func symbolKind(x meta.Meta) int {
+	switch x.(type) {
+	case *meta.Module:
+		return SymbolModule
+	case *meta.Typedef:
+		return SymbolTypeParameter
+	case *meta.Grouping:
+		return SymbolClass
+	case *meta.Identity:
+		return SymbolConstant
+	case *meta.Feature:
+		return SymbolBoolean
+	case *meta.ExtensionDef:
+		return SymbolFunction
+	case *meta.Container, *meta.Any:
+		return SymbolObject
+	case *meta.List, *meta.LeafList:
+		return SymbolArray
+	case *meta.Leaf:
+		return SymbolField
+	case *meta.Choice:
+		return SymbolEnum
+	case *meta.ChoiceCase:
+		return SymbolEnumMember
+	case *meta.Rpc:
+		return SymbolMethod
+	case *meta.RpcInput, *meta.RpcOutput:
+		return SymbolObject
+	case *meta.Notification:
+		return SymbolEvent
+	case *meta.Augment, *meta.Deviation:
+		return SymbolNamespace
+	case *meta.Uses:
+		return SymbolInterface
+	}
+	return 0
+}
@@ -0,0 +1,266 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/source"
+)
+
+// Server answers Language Server Protocol requests for YANG files an editor
+// has open. Problems are reported when files are opened or saved and there is
+// go to definition, hover, completion and document outline.
+type Server struct {
+	// YangPath is list of directories separated by ':' to find imported
+	// modules in when they are not in the same directory as the file being
+	// edited.
+	YangPath string
+
+	docs     map[string]*document
+	out      io.Writer
+	shutdown bool
+}
+
+func NewServer(yangPath string) *Server {
+	return &Server{
+		YangPath: yangPath,
+		docs:     make(map[string]*document),
+	}
+}
+
+// Serve reads requests from in and writes responses to out until the client
+// sends exit or in is closed.
+func (s *Server) Serve(in io.Reader, out io.Writer) error {
+	s.out = out
+	r := bufio.NewReader(in)
+	for {
+		msg, err := readMessage(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return fmt.Errorf("exit before shutdown")
+			}
+			return nil
+		}
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) error {
+	if msg.Method == "" {
+		// server does not make requests so there should not be responses
+		return nil
+	}
+	result, err := s.call(msg.Method, msg.Params)
+	if msg.ID == nil {
+		// notifications have no response, even if there is an error
+		return nil
+	}
+	if err != nil {
+		return writeMessage(s.out, &errorResponse{JSONRPC: "2.0", ID: msg.ID, Error: *err})
+	}
+	return writeMessage(s.out, &response{JSONRPC: "2.0", ID: msg.ID, Result: result})
+}
+
+func (s *Server) call(method string, params json.RawMessage) (interface{}, *responseError) {
+	decode := func(v interface{}) *responseError {
+		if err := json.Unmarshal(params, v); err != nil {
+			return &responseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return nil
+	}
+	switch method {
+	case "initialize":
+		return s.initialize(), nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var p didOpenParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		d := newDocument(p.TextDocument.URI, p.TextDocument.Text)
+		s.docs[d.uri] = d
+		d.load(s.opener(d))
+		return nil, s.publish(d)
+	case "textDocument/didChange":
+		var p didChangeParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		d := s.docs[p.TextDocument.URI]
+		if d == nil || len(p.ContentChanges) == 0 {
+			return nil, nil
+		}
+		// only full text sync is offered so last change has all the text
+		d.setText(p.ContentChanges[len(p.ContentChanges)-1].Text)
+		d.load(s.opener(d))
+		return nil, nil
+	case "textDocument/didSave":
+		var p didSaveParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		d := s.docs[p.TextDocument.URI]
+		if d == nil {
+			return nil, nil
+		}
+		if p.Text != nil {
+			d.setText(*p.Text)
+		}
+		d.load(s.opener(d))
+		return nil, s.publish(d)
+	case "textDocument/didClose":
+		var p didCloseParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		delete(s.docs, p.TextDocument.URI)
+		return nil, nil
+	case "textDocument/definition":
+		var p textDocumentPositionParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		if d := s.docs[p.TextDocument.URI]; d != nil {
+			if loc := s.definition(d, p.Position); loc != nil {
+				return loc, nil
+			}
+		}
+		return nil, nil
+	case "textDocument/hover":
+		var p textDocumentPositionParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		if d := s.docs[p.TextDocument.URI]; d != nil {
+			if h := s.hover(d, p.Position); h != nil {
+				return h, nil
+			}
+		}
+		return nil, nil
+	case "textDocument/completion":
+		var p textDocumentPositionParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		items := []CompletionItem{}
+		if d := s.docs[p.TextDocument.URI]; d != nil {
+			items = append(items, s.complete(d, p.Position)...)
+		}
+		return items, nil
+	case "textDocument/documentSymbol":
+		var p documentSymbolParams
+		if err := decode(&p); err != nil {
+			return nil, err
+		}
+		symbols := []*DocumentSymbol{}
+		if d := s.docs[p.TextDocument.URI]; d != nil {
+			symbols = append(symbols, s.symbols(d)...)
+		}
+		return symbols, nil
+	}
+	if strings.HasPrefix(method, "$/") {
+		// optional protocol notifications are safe to ignore
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method not supported " + method}
+}
+
+func (s *Server) initialize() interface{} {
+	return map[string]interface{}{
+		"capabilities": map[string]interface{}{
+			"textDocumentSync": map[string]interface{}{
+				"openClose": true,
+				"change":    1, // full text
+				"save":      map[string]interface{}{"includeText": true},
+			},
+			"definitionProvider": true,
+			"hoverProvider":      true,
+			"completionProvider": map[string]interface{}{
+				"triggerCharacters": []string{":"},
+			},
+			"documentSymbolProvider": true,
+		},
+		"serverInfo": map[string]interface{}{
+			"name": "fc-yang",
+		},
+	}
+}
+
+// opener finds imported modules next to the file first and then on the yang
+// path
+func (s *Server) opener(d *document) source.Opener {
+	openers := []source.Opener{source.Dir(d.dir())}
+	if s.YangPath != "" {
+		openers = append(openers, source.Path(s.YangPath))
+	}
+	return source.Any(openers...)
+}
+
+// file is path to file that was loaded from the opener of d
+func (s *Server) file(d *document, fname string) string {
+	dirs := []string{d.dir()}
+	if s.YangPath != "" {
+		dirs = append(dirs, strings.Split(s.YangPath, ":")...)
+	}
+	for _, dir := range dirs {
+		candidate := filepath.Join(dir, fname)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func (s *Server) publish(d *document) *responseError {
+	params := publishDiagnosticsParams{URI: d.uri, Diagnostics: s.diagnostics(d)}
+	err := writeMessage(s.out, &notification{JSONRPC: "2.0", Method: "textDocument/publishDiagnostics", Params: params})
+	if err != nil {
+		return &responseError{Code: codeInternalError, Message: err.Error()}
+	}
+	return nil
+}
+
+// diagnostics are the problems from the last time the document was loaded.
+// Problems in other files, like an imported module, are reported at the
+// start of the document.
+func (s *Server) diagnostics(d *document) []Diagnostic {
+	diags := []Diagnostic{}
+	for _, diag := range d.diags {
+		problem := Diagnostic{Source: "fc-yang", Message: diag.Message, Severity: SeverityError}
+		if diag.Severity == meta.SeverityWarning {
+			problem.Severity = SeverityWarning
+		}
+		if loc := diag.Location; loc != nil {
+			if loc.File == "" {
+				start := d.offset(loc.Line-1, loc.Col-1)
+				end := start
+				if path := d.enclosing(start); len(path) > 0 && path[len(path)-1].start == start {
+					end = start + len(path[len(path)-1].keyword)
+				}
+				problem.Range = d.span(start, end)
+			} else {
+				problem.Message = loc.String() + " - " + diag.Message
+			}
+		}
+		diags = append(diags, problem)
+	}
+	return diags
+}
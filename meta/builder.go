@@ -40,6 +40,14 @@ func (b *Builder) Description(o interface{}, desc string) {
 	}
 }
 
+// Location records where definition is in YANG file.  Ignored for things
+// that have no location.
+func (b *Builder) Location(o interface{}, loc *Location) {
+	if h, valid := o.(HasLocation); valid {
+		h.setLocation(loc)
+	}
+}
+
 func (b *Builder) Presence(o interface{}, desc string) {
 	d, valid := o.(*Container)
 	if !valid {
@@ -610,6 +618,17 @@ func (b *Builder) MinElements(o interface{}, i int) {
 	}
 }
 
+// Unique adds space separated list of leaf paths that must be unique
+// together in a list
+func (b *Builder) Unique(o interface{}, paths string) {
+	h, valid := o.(HasUnique)
+	if valid {
+		h.setUnique(append(h.Unique(), strings.Fields(paths)))
+	} else {
+		b.setErr(fmt.Errorf("%T does not support unique", o))
+	}
+}
+
 func (b *Builder) OrderedBy(o interface{}, order OrderedBy) {
 	h, valid := o.(HasOrderedBy)
 	if valid {
@@ -3,6 +3,7 @@ package meta
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/freeconf/yang/val"
 )
@@ -11,18 +12,36 @@ import (
 // all together.
 
 func Compile(root *Module) error {
+	return CompileWithOptions(root, CompileOptions{})
+}
+
+// CompileWithOptions is like Compile but with more control on process.
+// Compiling continues after problems so error is Diagnostics with all the
+// problems found.
+func CompileWithOptions(root *Module, options CompileOptions) error {
 	c := &compiler{
-		root: root,
+		root:     root,
+		handlers: options.Extensions,
 	}
 	// resolve uses with groupings
-	if err := resolve(root); err != nil {
-		return err
+	var err error
+	if c.errs, err = resolve(root, options.Deviations); err != nil {
+		return c.errs.appendErr(err)
 	}
-	return c.module(root)
+	if err := c.module(root); err != nil {
+		c.errs = c.errs.appendErr(err)
+	}
+	if len(c.errs) > 0 {
+		return c.errs
+	}
+	root.links = &identityLinks{}
+	return nil
 }
 
 type compiler struct {
-	root *Module
+	root     *Module
+	handlers ExtensionHandlers
+	errs     Diagnostics
 }
 
 func (c *compiler) module(y *Module) error {
@@ -32,15 +51,11 @@ func (c *compiler) module(y *Module) error {
 		}
 	}
 	for _, i := range y.identities {
-		if err := c.compile(i); err != nil {
-			return err
-		}
+		c.compile(i)
 	}
 
 	for _, r := range y.rev {
-		if err := c.compile(r); err != nil {
-			return err
-		}
+		c.compile(r)
 	}
 
 	for _, im := range y.imports {
@@ -49,14 +64,13 @@ func (c *compiler) module(y *Module) error {
 		}
 	}
 
-	return c.compile(y)
+	c.compile(y)
+	return nil
 }
 
 func (c *compiler) compileImport(m *Module) error {
 	for _, i := range m.identities {
-		if err := c.compile(i); err != nil {
-			return err
-		}
+		c.compile(i)
 	}
 	for _, im := range m.imports {
 		if err := c.compileImport(im.module); err != nil {
@@ -66,22 +80,32 @@ func (c *compiler) compileImport(m *Module) error {
 	return nil
 }
 
-func (c *compiler) compile(o interface{}) error {
+// compile records errors with location of o, unless error already has
+// location, and continues so all problems are found
+func (c *compiler) compile(o interface{}) {
+	if err := c.compileMeta(o); err != nil {
+		c.errs = c.errs.appendErr(locateErr(o, err))
+	}
+}
 
+func (c *compiler) compileMeta(o interface{}) error {
+	// handlers go first so changes they make to definition are compiled
+	if x, ok := o.(Meta); ok {
+		for _, y := range x.Extensions() {
+			c.compile(y)
+			c.handleExtension(x, y)
+		}
+	}
 	if x, ok := o.(HasTypedefs); ok {
 		for _, y := range x.Typedefs() {
-			if err := c.compile(y); err != nil {
-				return err
-			}
+			c.compile(y)
 		}
 	}
 	if x, ok := o.(HasType); ok {
 		if err := c.compileType(x.Type(), x.(Leafable)); err != nil {
 			return err
 		}
-		if err := c.compile(x.Type()); err != nil {
-			return err
-		}
+		c.compile(x.Type())
 	}
 
 	if x, ok := o.(HasConfig); ok {
@@ -105,14 +129,10 @@ func (c *compiler) compile(o interface{}) error {
 		}
 	case *Rpc:
 		if x.input != nil {
-			if err := c.compile(x.input); err != nil {
-				return err
-			}
+			c.compile(x.input)
 		}
 		if x.output != nil {
-			if err := c.compile(x.output); err != nil {
-				return err
-			}
+			c.compile(x.output)
 		}
 	case *List:
 		if err := c.list(x); err != nil {
@@ -120,45 +140,42 @@ func (c *compiler) compile(o interface{}) error {
 		}
 	case *Choice:
 		for _, k := range x.Cases() {
-			if err := c.compile(k); err != nil {
-				return err
-			}
+			c.compile(k)
 		}
 	}
 
 	if x, ok := o.(HasDataDefinitions); ok {
 		if !x.IsRecursive() {
 			for _, y := range x.DataDefinitions() {
-				if err := c.compile(y); err != nil {
-					return err
-				}
+				c.compile(y)
 			}
 		}
 	}
 	if x, ok := o.(HasActions); ok {
 		for _, y := range x.Actions() {
-			if err := c.compile(y); err != nil {
-				return err
-			}
+			c.compile(y)
 		}
 	}
 	if x, ok := o.(HasNotifications); ok {
 		for _, y := range x.Notifications() {
-			if err := c.compile(y); err != nil {
-				return err
-			}
-		}
-	}
-	if x, ok := o.(Meta); ok {
-		for _, y := range x.Extensions() {
-			if err := c.compile(y); err != nil {
-				return err
-			}
+			c.compile(y)
 		}
 	}
 	return nil
 }
 
+// extensions are shared by all copies of a definition from a grouping so
+// parent of extension is not necessarily the definition it is on
+func (c *compiler) handleExtension(parent Meta, e *Extension) {
+	if e.handler == nil {
+		return
+	}
+	if err := e.handler.Compile(parent, e); err != nil {
+		err = fmt.Errorf("%s - %s:%s - %w", SchemaPath(parent), e.prefix, e.ident, err)
+		c.errs = c.errs.appendErr(locateErr(e, err))
+	}
+}
+
 func (c *compiler) inheritConfig(m Meta) bool {
 	if x, ok := m.(HasDetails); ok {
 		if !x.IsConfigSet() {
@@ -198,6 +215,8 @@ func (c *compiler) extension(e *Extension) error {
 	}
 
 	// TODO: check args of extension match the allowed args of the definition
+
+	e.handler = c.handlers.find(e.def)
 	return nil
 }
 
@@ -222,13 +241,60 @@ func (c *compiler) identity(y *Identity) error {
 		}
 		y.base = append(y.base, identity)
 		identity.derived = append(identity.derived, y)
-		if err := c.compile(identity); err != nil {
-			return err
-		}
+		c.compile(identity)
 	}
 	return nil
 }
 
+// identityLinks links identities once for a module and all the modules it
+// imports or for a module set
+type identityLinks struct {
+	once sync.Once
+}
+
+// linkIdentities so every copy of a base identity has the identities derived
+// from it in any module. A module is loaded again for each module that imports
+// it so otherwise an identity is only derived from the base in the copy its
+// own module imported.
+func linkIdentities(root *Module) {
+	copies := make(map[string][]*Identity)
+	var all []*Identity
+	seen := make(map[*Module]bool)
+	var visit func(m *Module)
+	visit = func(m *Module) {
+		if m == nil || seen[m] {
+			return
+		}
+		seen[m] = true
+		for _, i := range m.identities {
+			copies[i.qualifiedIdent()] = append(copies[i.qualifiedIdent()], i)
+			all = append(all, i)
+		}
+		for _, im := range m.imports {
+			visit(im.module)
+		}
+	}
+	visit(root)
+	for _, y := range all {
+		for _, base := range y.base {
+			for _, copy := range copies[base.qualifiedIdent()] {
+				if !hasDerived(copy, y) {
+					copy.derived = append(copy.derived, y)
+				}
+			}
+		}
+	}
+}
+
+func hasDerived(base *Identity, y *Identity) bool {
+	for _, x := range base.derived {
+		if x.qualifiedIdent() == y.qualifiedIdent() {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *compiler) compileType(y *Type, parent Leafable) error {
 	if y == nil {
 		return errors.New("no type set on " + SchemaPath(parent))
@@ -247,6 +313,7 @@ func (c *compiler) compileType(y *Type, parent Leafable) error {
 		// Don't use resolve here because if a typedef is a leafref, you want
 		// the unresolved here and resolve it below
 		tdef.dtype.mixin(y)
+		y.typedef = tdef
 
 		if !parent.HasDefault() {
 			parent.setDefault(tdef.Default())
@@ -334,6 +401,53 @@ func (c *compiler) compileType(y *Type, parent Leafable) error {
 		}
 	}
 
+	if err := checkBounds(y); err != nil {
+		return errors.New(SchemaPath(parent) + " - " + err.Error())
+	}
+
+	return nil
+}
+
+// checkBounds of ranges and lengths are in lexical form of type so integer
+// types do not get fractions and decimals have no more digits than
+// fraction-digits allows.
+func checkBounds(y *Type) error {
+	for _, r := range y.lengths {
+		if err := checkRange(r, val.FmtUInt64, 0); err != nil {
+			return err
+		}
+	}
+	f := y.format.Single()
+	switch f {
+	case val.FmtInt8, val.FmtInt16, val.FmtInt32, val.FmtInt64,
+		val.FmtUInt8, val.FmtUInt16, val.FmtUInt32, val.FmtUInt64,
+		val.FmtDecimal64:
+	default:
+		return nil
+	}
+	for _, r := range y.ranges {
+		if err := checkRange(r, f, y.fractionDigits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkRange(r *Range, f val.Format, fractionDigits int) error {
+	for _, bound := range []string{r.Min, r.Max} {
+		if bound == "" || bound == "min" || bound == "max" {
+			continue
+		}
+		v, err := val.Parse(f, bound)
+		if err != nil {
+			return fmt.Errorf("invalid bound %s in range %s", bound, r)
+		}
+		if d, isDecimal := v.(val.Decimal64); isDecimal && fractionDigits > 0 {
+			if _, err := d.Scale(fractionDigits); err != nil {
+				return fmt.Errorf("invalid bound %s in range %s", bound, r)
+			}
+		}
+	}
 	return nil
 }
 
@@ -380,9 +494,7 @@ func (c *compiler) findTypedef(y *Type, parent Definition, qualifiedIdent string
 	}
 
 	// this will recurse if typedef references another typedef
-	if err := c.compile(found); err != nil {
-		return nil, err
-	}
+	c.compile(found)
 
 	return found, nil
 }
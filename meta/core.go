@@ -40,6 +40,13 @@ type Module struct {
 	extensionDefs map[string]*ExtensionDef
 	featureSet    FeatureSet
 	extensions    []*Extension
+	set           *moduleSet
+	deviatedBy    []*Module
+	loc           *Location
+
+	// linkTo is module that imported this module or module set it is in
+	linkTo *Module
+	links  *identityLinks
 }
 
 func (y *Module) Revision() *Revision {
@@ -97,6 +104,15 @@ func (y *Module) FeatureSet() FeatureSet {
 	return y.featureSet
 }
 
+// FeatureEnabled is true if feature is enabled by the feature set the module was
+// loaded with. All features are enabled without a feature set.
+func (y *Module) FeatureEnabled(ident string) (bool, error) {
+	if y.featureSet == nil {
+		return true, nil
+	}
+	return y.featureSet.Resolve(&IfFeature{parent: y, expr: ident})
+}
+
 func (y *Module) ExtensionDefs() map[string]*ExtensionDef {
 	return y.extensionDefs
 }
@@ -105,6 +121,21 @@ func (y *Module) Deviations() []*Deviation {
 	return y.deviations
 }
 
+// DeviatedBy are the modules whose deviations were applied to this module
+// including this module if it deviates itself.
+func (y *Module) DeviatedBy() []*Module {
+	return y.deviatedBy
+}
+
+func (y *Module) addDeviatedBy(m *Module) {
+	for _, existing := range y.deviatedBy {
+		if existing == m {
+			return
+		}
+	}
+	y.deviatedBy = append(y.deviatedBy, m)
+}
+
 func (y *Module) ModuleByPrefix(prefix string) (*Module, error) {
 	if y.Prefix() == prefix {
 		return y, nil
@@ -126,6 +157,7 @@ type Import struct {
 	module     *Module
 	loader     Loader
 	extensions []*Extension
+	loc        *Location
 }
 
 func (y *Import) Module() *Module {
@@ -144,6 +176,12 @@ type Include struct {
 	parent     *Module
 	loader     Loader
 	extensions []*Extension
+	loc        *Location
+}
+
+// Name of submodule
+func (y *Include) Name() string {
+	return y.subName
 }
 
 func (y *Include) Revision() *Revision {
@@ -164,6 +202,7 @@ type Choice struct {
 	cases          map[string]*ChoiceCase
 	ifs            []*IfFeature
 	extensions     []*Extension
+	loc            *Location
 }
 
 func (y *Choice) Cases() map[string]*ChoiceCase {
@@ -192,6 +231,7 @@ type ChoiceCase struct {
 	ifs            []*IfFeature
 	extensions     []*Extension
 	recursive      bool
+	loc            *Location
 }
 
 // Revision is like a version for a module.  Format is YYYY-MM-DD and should match
@@ -202,6 +242,7 @@ type Revision struct {
 	desc       string
 	ref        string
 	extensions []*Extension
+	loc        *Location
 }
 
 type Container struct {
@@ -225,6 +266,7 @@ type Container struct {
 	musts          []*Must
 	extensions     []*Extension
 	recursive      bool
+	loc            *Location
 }
 
 type OrderedBy int
@@ -260,6 +302,7 @@ type List struct {
 	extensions     []*Extension
 	unique         [][]string
 	recursive      bool
+	loc            *Location
 }
 
 func (y *List) KeyMeta() (keyMeta []Leafable) {
@@ -281,6 +324,7 @@ type Leaf struct {
 	ifs            []*IfFeature
 	musts          []*Must
 	extensions     []*Extension
+	loc            *Location
 }
 
 type LeafList struct {
@@ -302,6 +346,7 @@ type LeafList struct {
 	ifs            []*IfFeature
 	musts          []*Must
 	extensions     []*Extension
+	loc            *Location
 }
 
 var anyType = newType("any")
@@ -318,6 +363,7 @@ type Any struct {
 	ifs            []*IfFeature
 	musts          []*Must
 	extensions     []*Extension
+	loc            *Location
 }
 
 func (y *Any) HasDefault() bool {
@@ -377,6 +423,7 @@ type Grouping struct {
 	// no when
 
 	extensions []*Extension
+	loc        *Location
 }
 
 type Uses struct {
@@ -391,6 +438,7 @@ type Uses struct {
 	ifs            []*IfFeature
 	augments       []*Augment
 	extensions     []*Extension
+	loc            *Location
 }
 
 func (y *Uses) Refinements() []*Refine {
@@ -412,6 +460,7 @@ type Refine struct {
 	ifs            []*IfFeature
 	musts          []*Must
 	extensions     []*Extension
+	loc            *Location
 }
 
 func (y *Refine) splitIdent() (string, string) {
@@ -434,6 +483,7 @@ type RpcInput struct {
 	ifs            []*IfFeature
 	musts          []*Must
 	extensions     []*Extension
+	loc            *Location
 }
 
 func (y *RpcInput) Ident() string {
@@ -452,6 +502,7 @@ type RpcOutput struct {
 	ifs            []*IfFeature
 	musts          []*Must
 	extensions     []*Extension
+	loc            *Location
 }
 
 func (y *RpcOutput) Ident() string {
@@ -470,6 +521,7 @@ type Rpc struct {
 	output         *RpcOutput
 	ifs            []*IfFeature
 	extensions     []*Extension
+	loc            *Location
 }
 
 func (y *Rpc) Input() *RpcInput {
@@ -492,6 +544,7 @@ type Notification struct {
 	dataDefsIndex  map[string]Definition
 	ifs            []*IfFeature
 	extensions     []*Extension
+	loc            *Location
 }
 
 type Typedef struct {
@@ -504,6 +557,7 @@ type Typedef struct {
 	defaultVal     interface{}
 	dtype          *Type
 	extensions     []*Extension
+	loc            *Location
 }
 
 type Augment struct {
@@ -519,6 +573,7 @@ type Augment struct {
 	when           *When
 	ifs            []*IfFeature
 	extensions     []*Extension
+	loc            *Location
 }
 
 type AddDeviate struct {
@@ -532,6 +587,7 @@ type AddDeviate struct {
 	unique         [][]string
 	defaultVal     interface{}
 	extensions     []*Extension
+	loc            *Location
 }
 
 type ReplaceDeviate struct {
@@ -544,6 +600,7 @@ type ReplaceDeviate struct {
 	minElementsPtr *int
 	maxElementsPtr *int
 	extensions     []*Extension
+	loc            *Location
 }
 
 type DeleteDeviate struct {
@@ -553,6 +610,7 @@ type DeleteDeviate struct {
 	unique     [][]string
 	defaultVal interface{}
 	extensions []*Extension
+	loc        *Location
 }
 
 // Deviation is a lot like refine but can be used without
@@ -578,6 +636,7 @@ type Deviation struct {
 	Delete *DeleteDeviate
 
 	extensions []*Extension
+	loc        *Location
 }
 
 type Type struct {
@@ -594,11 +653,13 @@ type Type struct {
 	path            string
 	fractionDigits  int
 	delegate        *Type
+	typedef         *Typedef
 	base            string
 	identity        *Identity
 	requireInstance bool
 	unionTypes      []*Type
 	extensions      []*Extension
+	loc             *Location
 }
 
 func newType(ident string) *Type {
@@ -639,10 +700,22 @@ func (y *Type) Enums() []*Enum {
 	return y.enums
 }
 
+// Typedef this type is from or nil if type is a built-in type. Type of the
+// typedef has the typedef it is from, if any.
+func (y *Type) Typedef() *Typedef {
+	return y.typedef
+}
+
 func (y *Type) Base() *Identity {
 	return y.identity
 }
 
+// BaseId is identity named in "base" statement of an identityref as it was
+// written, including prefix if there was one
+func (y *Type) BaseId() string {
+	return y.base
+}
+
 func (y *Type) Union() []*Type {
 	return y.unionTypes
 }
@@ -706,6 +779,9 @@ func (base *Type) mixin(derived *Type) {
 	if derived.identity == nil {
 		derived.identity = base.identity
 	}
+	if derived.fractionDigits == 0 {
+		derived.fractionDigits = base.fractionDigits
+	}
 	derived.format = base.format
 }
 
@@ -719,6 +795,7 @@ type Identity struct {
 	derived    []*Identity
 	ifs        []*IfFeature
 	extensions []*Extension
+	loc        *Location
 }
 
 func (y *Identity) BaseIds() []string {
@@ -730,10 +807,12 @@ func (y *Identity) Base() []*Identity {
 }
 
 func (y *Identity) DerivedDirect() []*Identity {
+	y.link()
 	return y.derived
 }
 
 func (y *Identity) Derived() map[string]*Identity {
+	y.link()
 	all := make(map[string]*Identity)
 	y.derivedRecursive(all)
 	return all
@@ -746,6 +825,46 @@ func (y *Identity) derivedRecursive(all map[string]*Identity) {
 	}
 }
 
+// AllDerived is identity and all the identities derived from it, directly or
+// not. Unlike Derived, identities with the same ident from different modules
+// are all here.
+func (y *Identity) AllDerived() []*Identity {
+	y.link()
+	var all []*Identity
+	y.allDerivedRecursive(make(map[string]bool), &all)
+	return all
+}
+
+func (y *Identity) allDerivedRecursive(seen map[string]bool, all *[]*Identity) {
+	if seen[y.qualifiedIdent()] {
+		return
+	}
+	seen[y.qualifiedIdent()] = true
+	*all = append(*all, y)
+	for _, x := range y.derived {
+		x.allDerivedRecursive(seen, all)
+	}
+}
+
+// link identities of module or module set identity is in the first time
+// derived identities are needed
+func (y *Identity) link() {
+	top := y.parent
+	for top.linkTo != nil {
+		top = top.linkTo
+	}
+	if top.links != nil {
+		top.links.once.Do(func() {
+			linkIdentities(top)
+		})
+	}
+}
+
+// qualifiedIdent is the same for an identity in every copy of a module
+func (y *Identity) qualifiedIdent() string {
+	return y.parent.ident + ":" + y.ident
+}
+
 type Feature struct {
 	parent     *Module
 	ident      string
@@ -753,12 +872,14 @@ type Feature struct {
 	ref        string
 	ifs        []*IfFeature
 	extensions []*Extension
+	loc        *Location
 }
 
 type IfFeature struct {
 	parent     Meta
 	expr       string
 	extensions []*Extension
+	loc        *Location
 }
 
 func (y *IfFeature) Expression() string {
@@ -871,6 +992,7 @@ type When struct {
 	desc       string
 	ref        string
 	extensions []*Extension
+	loc        *Location
 }
 
 func (y *When) Expression() string {
@@ -885,6 +1007,7 @@ type Must struct {
 	errorAppTag  string
 	expr         string
 	extensions   []*Extension
+	loc          *Location
 }
 
 func (y *Must) Expression() string {
@@ -901,6 +1024,7 @@ type ExtensionDef struct {
 
 	// yes, even extension dataDefsIndex can have extensions
 	extensions []*Extension
+	loc        *Location
 }
 
 func (y *ExtensionDef) Arguments() []*ExtensionDefArg {
@@ -914,6 +1038,7 @@ type ExtensionDefArg struct {
 	ref        string
 	yinElement bool
 	extensions []*Extension
+	loc        *Location
 }
 
 func (y *ExtensionDefArg) YinElement() bool {
@@ -944,9 +1069,11 @@ type Extension struct {
 	keyword string
 	def     *ExtensionDef
 	args    []string
+	handler ExtensionHandler
 
 	// yes even extensions can have extensions
 	extensions []*Extension
+	loc        *Location
 }
 
 // Prefix name of extention which according to YANG spec is ALWAYS required even
@@ -966,6 +1093,11 @@ func (y *Extension) Keyword() string {
 	return y.keyword
 }
 
+// Handler registered for this extension when module was compiled or nil
+func (y *Extension) Handler() ExtensionHandler {
+	return y.handler
+}
+
 // Arguments are optional argumes to extension.  The extension definition will
 // define what arguments are allowed if any.
 func (y *Extension) Arguments() []string {
@@ -978,6 +1110,7 @@ type Bit struct {
 	ref        string
 	Position   int
 	extensions []*Extension
+	loc        *Location
 }
 
 type Enum struct {
@@ -986,6 +1119,7 @@ type Enum struct {
 	ref        string
 	val        int
 	extensions []*Extension
+	loc        *Location
 }
 
 func (y *Enum) Value() int {
@@ -1000,9 +1134,8 @@ type Range struct {
 	Min          string
 	Max          string
 	notNil       bool
-	max          int
-	min          int
 	extensions   []*Extension
+	loc          *Location
 }
 
 func (r *Range) Empty() bool {
@@ -1016,20 +1149,20 @@ func newRange(encoded string) (r *Range, err error) {
 	// TODO: Support multiple ranges with '|'
 	segments := strings.Split(string(encoded), "..")
 	if len(segments) == 2 {
-		r.Min = segments[0]
+		r.Min = strings.TrimSpace(segments[0])
 		if r.Min != "min" {
-			if r.min, err = strconv.Atoi(r.Min); err != nil {
+			if _, err = strconv.ParseFloat(r.Min, 64); err != nil {
 				return
 			}
 		}
-		r.Max = segments[1]
+		r.Max = strings.TrimSpace(segments[1])
 	} else {
-		r.Max = segments[0]
+		r.Max = strings.TrimSpace(segments[0])
 	}
 	if r.Max != "max" {
-		if r.max, err = strconv.Atoi(segments[0]); err != nil {
-			return
-		}
+		// bounds are kept as written and checked against the type when
+		// compiled
+		_, err = strconv.ParseFloat(r.Max, 64)
 	}
 	return
 }
@@ -1076,4 +1209,5 @@ type Pattern struct {
 	errorMessage string
 	errorAppTag  string
 	extensions   []*Extension
+	loc          *Location
 }
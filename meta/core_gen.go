@@ -11,6 +11,16 @@ func (m *Module) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Module was defined. Nil if it was not parsed
+// from a file.
+func (m *Module) Location() *Location {
+	return m.loc
+}
+
+func (m *Module) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -70,11 +80,31 @@ func (m *Module) indexDataDefinition(def Definition) {
 	if m.dataDefsIndex == nil {
 		m.dataDefsIndex = make(map[string]Definition)
 	}
-	if _, exists := m.dataDefsIndex[def.Ident()]; exists {
+	ident := def.Ident()
+	if ns := NamespaceModule(def); ns != NamespaceModule(m) {
+		// augmented from another module so always findable by qualified
+		// ident and by plain ident only when nothing else has it
+		qualified := ns.Ident() + ":" + ident
+		if _, exists := m.dataDefsIndex[qualified]; exists {
+			// TODO: make this an error
+			panic(fmt.Sprintf("Conflict adding add %s to %s. ", qualified, m.Ident()))
+		}
+		m.dataDefsIndex[qualified] = def
+		existing, exists := m.dataDefsIndex[ident]
+		if !exists {
+			m.dataDefsIndex[ident] = def
+		} else if existing != nil && NamespaceModule(existing) != NamespaceModule(m) {
+			// more than one other module has ident so plain ident is
+			// ambiguous and qualified ident is required
+			m.dataDefsIndex[ident] = nil
+		}
+		return
+	}
+	if existing, exists := m.dataDefsIndex[ident]; exists && existing != nil && NamespaceModule(existing) == NamespaceModule(m) {
 		// TODO: make this an error
-		panic(fmt.Sprintf("Conflict adding add %s to %s. ", def.Ident(), m.Ident()))
-	}	
-	m.dataDefsIndex[def.Ident()] = def
+		panic(fmt.Sprintf("Conflict adding add %s to %s. ", ident, m.Ident()))
+	}
+	m.dataDefsIndex[ident] = def
 }
 
 func (m *Module) popDataDefinitions() []Definition {
@@ -196,12 +226,10 @@ func (m *Module) clone(parent Meta) interface{} {
 	}
 	
 	if m.dataDefs != nil {
-		copy.dataDefs = make([]Definition, len(m.dataDefs))
-		copy.dataDefsIndex = make(map[string]Definition, len(m.dataDefs))
-		for i, def := range m.dataDefs {
-			copyDef := def.(cloneable).clone(&copy).(Definition)
-			copy.dataDefs[i] = copyDef
-			copy.dataDefsIndex[def.Ident()] = copyDef
+		copy.dataDefs = make([]Definition, 0, len(m.dataDefs))
+		copy.dataDefsIndex = nil
+		for _, def := range m.dataDefs {
+			copy.addDataDefinition(def.(cloneable).clone(&copy).(Definition))
 		}
 	}
 	
@@ -211,6 +239,16 @@ func (m *Module) clone(parent Meta) interface{} {
 
 
 
+// Location in YANG file Import was defined. Nil if it was not parsed
+// from a file.
+func (m *Import) Location() *Location {
+	return m.loc
+}
+
+func (m *Import) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -246,6 +284,16 @@ func (m *Import) addExtension(extension *Extension) {
 
 
 
+// Location in YANG file Include was defined. Nil if it was not parsed
+// from a file.
+func (m *Include) Location() *Location {
+	return m.loc
+}
+
+func (m *Include) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -286,6 +334,16 @@ func (m *Choice) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Choice was defined. Nil if it was not parsed
+// from a file.
+func (m *Choice) Location() *Location {
+	return m.loc
+}
+
+func (m *Choice) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -406,6 +464,16 @@ func (m *ChoiceCase) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file ChoiceCase was defined. Nil if it was not parsed
+// from a file.
+func (m *ChoiceCase) Location() *Location {
+	return m.loc
+}
+
+func (m *ChoiceCase) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -465,11 +533,31 @@ func (m *ChoiceCase) indexDataDefinition(def Definition) {
 	if m.dataDefsIndex == nil {
 		m.dataDefsIndex = make(map[string]Definition)
 	}
-	if _, exists := m.dataDefsIndex[def.Ident()]; exists {
+	ident := def.Ident()
+	if ns := NamespaceModule(def); ns != NamespaceModule(m) {
+		// augmented from another module so always findable by qualified
+		// ident and by plain ident only when nothing else has it
+		qualified := ns.Ident() + ":" + ident
+		if _, exists := m.dataDefsIndex[qualified]; exists {
+			// TODO: make this an error
+			panic(fmt.Sprintf("Conflict adding add %s to %s. ", qualified, m.Ident()))
+		}
+		m.dataDefsIndex[qualified] = def
+		existing, exists := m.dataDefsIndex[ident]
+		if !exists {
+			m.dataDefsIndex[ident] = def
+		} else if existing != nil && NamespaceModule(existing) != NamespaceModule(m) {
+			// more than one other module has ident so plain ident is
+			// ambiguous and qualified ident is required
+			m.dataDefsIndex[ident] = nil
+		}
+		return
+	}
+	if existing, exists := m.dataDefsIndex[ident]; exists && existing != nil && NamespaceModule(existing) == NamespaceModule(m) {
 		// TODO: make this an error
-		panic(fmt.Sprintf("Conflict adding add %s to %s. ", def.Ident(), m.Ident()))
-	}	
-	m.dataDefsIndex[def.Ident()] = def
+		panic(fmt.Sprintf("Conflict adding add %s to %s. ", ident, m.Ident()))
+	}
+	m.dataDefsIndex[ident] = def
 }
 
 func (m *ChoiceCase) popDataDefinitions() []Definition {
@@ -524,12 +612,10 @@ func (m *ChoiceCase) clone(parent Meta) interface{} {
 	copy := *m
 	copy.parent = parent
 	if m.dataDefs != nil {
-		copy.dataDefs = make([]Definition, len(m.dataDefs))
-		copy.dataDefsIndex = make(map[string]Definition, len(m.dataDefs))
-		for i, def := range m.dataDefs {
-			copyDef := def.(cloneable).clone(&copy).(Definition)
-			copy.dataDefs[i] = copyDef
-			copy.dataDefsIndex[def.Ident()] = copyDef
+		copy.dataDefs = make([]Definition, 0, len(m.dataDefs))
+		copy.dataDefsIndex = nil
+		for _, def := range m.dataDefs {
+			copy.addDataDefinition(def.(cloneable).clone(&copy).(Definition))
 		}
 	}
 	
@@ -544,6 +630,16 @@ func (m *Revision) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Revision was defined. Nil if it was not parsed
+// from a file.
+func (m *Revision) Location() *Location {
+	return m.loc
+}
+
+func (m *Revision) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -584,6 +680,16 @@ func (m *Container) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Container was defined. Nil if it was not parsed
+// from a file.
+func (m *Container) Location() *Location {
+	return m.loc
+}
+
+func (m *Container) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -651,11 +757,31 @@ func (m *Container) indexDataDefinition(def Definition) {
 	if m.dataDefsIndex == nil {
 		m.dataDefsIndex = make(map[string]Definition)
 	}
-	if _, exists := m.dataDefsIndex[def.Ident()]; exists {
+	ident := def.Ident()
+	if ns := NamespaceModule(def); ns != NamespaceModule(m) {
+		// augmented from another module so always findable by qualified
+		// ident and by plain ident only when nothing else has it
+		qualified := ns.Ident() + ":" + ident
+		if _, exists := m.dataDefsIndex[qualified]; exists {
+			// TODO: make this an error
+			panic(fmt.Sprintf("Conflict adding add %s to %s. ", qualified, m.Ident()))
+		}
+		m.dataDefsIndex[qualified] = def
+		existing, exists := m.dataDefsIndex[ident]
+		if !exists {
+			m.dataDefsIndex[ident] = def
+		} else if existing != nil && NamespaceModule(existing) != NamespaceModule(m) {
+			// more than one other module has ident so plain ident is
+			// ambiguous and qualified ident is required
+			m.dataDefsIndex[ident] = nil
+		}
+		return
+	}
+	if existing, exists := m.dataDefsIndex[ident]; exists && existing != nil && NamespaceModule(existing) == NamespaceModule(m) {
 		// TODO: make this an error
-		panic(fmt.Sprintf("Conflict adding add %s to %s. ", def.Ident(), m.Ident()))
-	}	
-	m.dataDefsIndex[def.Ident()] = def
+		panic(fmt.Sprintf("Conflict adding add %s to %s. ", ident, m.Ident()))
+	}
+	m.dataDefsIndex[ident] = def
 }
 
 func (m *Container) popDataDefinitions() []Definition {
@@ -837,12 +963,10 @@ func (m *Container) clone(parent Meta) interface{} {
 	}
 	
 	if m.dataDefs != nil {
-		copy.dataDefs = make([]Definition, len(m.dataDefs))
-		copy.dataDefsIndex = make(map[string]Definition, len(m.dataDefs))
-		for i, def := range m.dataDefs {
-			copyDef := def.(cloneable).clone(&copy).(Definition)
-			copy.dataDefs[i] = copyDef
-			copy.dataDefsIndex[def.Ident()] = copyDef
+		copy.dataDefs = make([]Definition, 0, len(m.dataDefs))
+		copy.dataDefsIndex = nil
+		for _, def := range m.dataDefs {
+			copy.addDataDefinition(def.(cloneable).clone(&copy).(Definition))
 		}
 	}
 	
@@ -864,6 +988,16 @@ func (m *List) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file List was defined. Nil if it was not parsed
+// from a file.
+func (m *List) Location() *Location {
+	return m.loc
+}
+
+func (m *List) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -923,11 +1057,31 @@ func (m *List) indexDataDefinition(def Definition) {
 	if m.dataDefsIndex == nil {
 		m.dataDefsIndex = make(map[string]Definition)
 	}
-	if _, exists := m.dataDefsIndex[def.Ident()]; exists {
+	ident := def.Ident()
+	if ns := NamespaceModule(def); ns != NamespaceModule(m) {
+		// augmented from another module so always findable by qualified
+		// ident and by plain ident only when nothing else has it
+		qualified := ns.Ident() + ":" + ident
+		if _, exists := m.dataDefsIndex[qualified]; exists {
+			// TODO: make this an error
+			panic(fmt.Sprintf("Conflict adding add %s to %s. ", qualified, m.Ident()))
+		}
+		m.dataDefsIndex[qualified] = def
+		existing, exists := m.dataDefsIndex[ident]
+		if !exists {
+			m.dataDefsIndex[ident] = def
+		} else if existing != nil && NamespaceModule(existing) != NamespaceModule(m) {
+			// more than one other module has ident so plain ident is
+			// ambiguous and qualified ident is required
+			m.dataDefsIndex[ident] = nil
+		}
+		return
+	}
+	if existing, exists := m.dataDefsIndex[ident]; exists && existing != nil && NamespaceModule(existing) == NamespaceModule(m) {
 		// TODO: make this an error
-		panic(fmt.Sprintf("Conflict adding add %s to %s. ", def.Ident(), m.Ident()))
-	}	
-	m.dataDefsIndex[def.Ident()] = def
+		panic(fmt.Sprintf("Conflict adding add %s to %s. ", ident, m.Ident()))
+	}
+	m.dataDefsIndex[ident] = def
 }
 
 func (m *List) popDataDefinitions() []Definition {
@@ -1164,12 +1318,10 @@ func (m *List) clone(parent Meta) interface{} {
 	}
 	
 	if m.dataDefs != nil {
-		copy.dataDefs = make([]Definition, len(m.dataDefs))
-		copy.dataDefsIndex = make(map[string]Definition, len(m.dataDefs))
-		for i, def := range m.dataDefs {
-			copyDef := def.(cloneable).clone(&copy).(Definition)
-			copy.dataDefs[i] = copyDef
-			copy.dataDefsIndex[def.Ident()] = copyDef
+		copy.dataDefs = make([]Definition, 0, len(m.dataDefs))
+		copy.dataDefsIndex = nil
+		for _, def := range m.dataDefs {
+			copy.addDataDefinition(def.(cloneable).clone(&copy).(Definition))
 		}
 	}
 	
@@ -1191,6 +1343,16 @@ func (m *Leaf) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Leaf was defined. Nil if it was not parsed
+// from a file.
+func (m *Leaf) Location() *Location {
+	return m.loc
+}
+
+func (m *Leaf) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -1332,6 +1494,16 @@ func (m *LeafList) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file LeafList was defined. Nil if it was not parsed
+// from a file.
+func (m *LeafList) Location() *Location {
+	return m.loc
+}
+
+func (m *LeafList) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -1528,6 +1700,16 @@ func (m *Any) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Any was defined. Nil if it was not parsed
+// from a file.
+func (m *Any) Location() *Location {
+	return m.loc
+}
+
+func (m *Any) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -1641,6 +1823,16 @@ func (m *Grouping) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Grouping was defined. Nil if it was not parsed
+// from a file.
+func (m *Grouping) Location() *Location {
+	return m.loc
+}
+
+func (m *Grouping) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -1700,11 +1892,31 @@ func (m *Grouping) indexDataDefinition(def Definition) {
 	if m.dataDefsIndex == nil {
 		m.dataDefsIndex = make(map[string]Definition)
 	}
-	if _, exists := m.dataDefsIndex[def.Ident()]; exists {
+	ident := def.Ident()
+	if ns := NamespaceModule(def); ns != NamespaceModule(m) {
+		// augmented from another module so always findable by qualified
+		// ident and by plain ident only when nothing else has it
+		qualified := ns.Ident() + ":" + ident
+		if _, exists := m.dataDefsIndex[qualified]; exists {
+			// TODO: make this an error
+			panic(fmt.Sprintf("Conflict adding add %s to %s. ", qualified, m.Ident()))
+		}
+		m.dataDefsIndex[qualified] = def
+		existing, exists := m.dataDefsIndex[ident]
+		if !exists {
+			m.dataDefsIndex[ident] = def
+		} else if existing != nil && NamespaceModule(existing) != NamespaceModule(m) {
+			// more than one other module has ident so plain ident is
+			// ambiguous and qualified ident is required
+			m.dataDefsIndex[ident] = nil
+		}
+		return
+	}
+	if existing, exists := m.dataDefsIndex[ident]; exists && existing != nil && NamespaceModule(existing) == NamespaceModule(m) {
 		// TODO: make this an error
-		panic(fmt.Sprintf("Conflict adding add %s to %s. ", def.Ident(), m.Ident()))
-	}	
-	m.dataDefsIndex[def.Ident()] = def
+		panic(fmt.Sprintf("Conflict adding add %s to %s. ", ident, m.Ident()))
+	}
+	m.dataDefsIndex[ident] = def
 }
 
 func (m *Grouping) popDataDefinitions() []Definition {
@@ -1821,12 +2033,10 @@ func (m *Grouping) clone(parent Meta) interface{} {
 	}
 	
 	if m.dataDefs != nil {
-		copy.dataDefs = make([]Definition, len(m.dataDefs))
-		copy.dataDefsIndex = make(map[string]Definition, len(m.dataDefs))
-		for i, def := range m.dataDefs {
-			copyDef := def.(cloneable).clone(&copy).(Definition)
-			copy.dataDefs[i] = copyDef
-			copy.dataDefsIndex[def.Ident()] = copyDef
+		copy.dataDefs = make([]Definition, 0, len(m.dataDefs))
+		copy.dataDefsIndex = nil
+		for _, def := range m.dataDefs {
+			copy.addDataDefinition(def.(cloneable).clone(&copy).(Definition))
 		}
 	}
 	
@@ -1841,6 +2051,16 @@ func (m *Uses) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Uses was defined. Nil if it was not parsed
+// from a file.
+func (m *Uses) Location() *Location {
+	return m.loc
+}
+
+func (m *Uses) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -1919,6 +2139,16 @@ func (m *Refine) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Refine was defined. Nil if it was not parsed
+// from a file.
+func (m *Refine) Location() *Location {
+	return m.loc
+}
+
+func (m *Refine) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -2070,6 +2300,16 @@ func (m *Refine) setDefault(d interface{}) {
 
 
 
+// Location in YANG file RpcInput was defined. Nil if it was not parsed
+// from a file.
+func (m *RpcInput) Location() *Location {
+	return m.loc
+}
+
+func (m *RpcInput) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -2129,11 +2369,31 @@ func (m *RpcInput) indexDataDefinition(def Definition) {
 	if m.dataDefsIndex == nil {
 		m.dataDefsIndex = make(map[string]Definition)
 	}
-	if _, exists := m.dataDefsIndex[def.Ident()]; exists {
+	ident := def.Ident()
+	if ns := NamespaceModule(def); ns != NamespaceModule(m) {
+		// augmented from another module so always findable by qualified
+		// ident and by plain ident only when nothing else has it
+		qualified := ns.Ident() + ":" + ident
+		if _, exists := m.dataDefsIndex[qualified]; exists {
+			// TODO: make this an error
+			panic(fmt.Sprintf("Conflict adding add %s to %s. ", qualified, m.Ident()))
+		}
+		m.dataDefsIndex[qualified] = def
+		existing, exists := m.dataDefsIndex[ident]
+		if !exists {
+			m.dataDefsIndex[ident] = def
+		} else if existing != nil && NamespaceModule(existing) != NamespaceModule(m) {
+			// more than one other module has ident so plain ident is
+			// ambiguous and qualified ident is required
+			m.dataDefsIndex[ident] = nil
+		}
+		return
+	}
+	if existing, exists := m.dataDefsIndex[ident]; exists && existing != nil && NamespaceModule(existing) == NamespaceModule(m) {
 		// TODO: make this an error
-		panic(fmt.Sprintf("Conflict adding add %s to %s. ", def.Ident(), m.Ident()))
-	}	
-	m.dataDefsIndex[def.Ident()] = def
+		panic(fmt.Sprintf("Conflict adding add %s to %s. ", ident, m.Ident()))
+	}
+	m.dataDefsIndex[ident] = def
 }
 
 func (m *RpcInput) popDataDefinitions() []Definition {
@@ -2217,12 +2477,10 @@ func (m *RpcInput) clone(parent Meta) interface{} {
 	copy := *m
 	copy.parent = parent
 	if m.dataDefs != nil {
-		copy.dataDefs = make([]Definition, len(m.dataDefs))
-		copy.dataDefsIndex = make(map[string]Definition, len(m.dataDefs))
-		for i, def := range m.dataDefs {
-			copyDef := def.(cloneable).clone(&copy).(Definition)
-			copy.dataDefs[i] = copyDef
-			copy.dataDefsIndex[def.Ident()] = copyDef
+		copy.dataDefs = make([]Definition, 0, len(m.dataDefs))
+		copy.dataDefsIndex = nil
+		for _, def := range m.dataDefs {
+			copy.addDataDefinition(def.(cloneable).clone(&copy).(Definition))
 		}
 	}
 	
@@ -2239,6 +2497,16 @@ func (m *RpcInput) clone(parent Meta) interface{} {
 
 
 
+// Location in YANG file RpcOutput was defined. Nil if it was not parsed
+// from a file.
+func (m *RpcOutput) Location() *Location {
+	return m.loc
+}
+
+func (m *RpcOutput) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -2298,11 +2566,31 @@ func (m *RpcOutput) indexDataDefinition(def Definition) {
 	if m.dataDefsIndex == nil {
 		m.dataDefsIndex = make(map[string]Definition)
 	}
-	if _, exists := m.dataDefsIndex[def.Ident()]; exists {
+	ident := def.Ident()
+	if ns := NamespaceModule(def); ns != NamespaceModule(m) {
+		// augmented from another module so always findable by qualified
+		// ident and by plain ident only when nothing else has it
+		qualified := ns.Ident() + ":" + ident
+		if _, exists := m.dataDefsIndex[qualified]; exists {
+			// TODO: make this an error
+			panic(fmt.Sprintf("Conflict adding add %s to %s. ", qualified, m.Ident()))
+		}
+		m.dataDefsIndex[qualified] = def
+		existing, exists := m.dataDefsIndex[ident]
+		if !exists {
+			m.dataDefsIndex[ident] = def
+		} else if existing != nil && NamespaceModule(existing) != NamespaceModule(m) {
+			// more than one other module has ident so plain ident is
+			// ambiguous and qualified ident is required
+			m.dataDefsIndex[ident] = nil
+		}
+		return
+	}
+	if existing, exists := m.dataDefsIndex[ident]; exists && existing != nil && NamespaceModule(existing) == NamespaceModule(m) {
 		// TODO: make this an error
-		panic(fmt.Sprintf("Conflict adding add %s to %s. ", def.Ident(), m.Ident()))
-	}	
-	m.dataDefsIndex[def.Ident()] = def
+		panic(fmt.Sprintf("Conflict adding add %s to %s. ", ident, m.Ident()))
+	}
+	m.dataDefsIndex[ident] = def
 }
 
 func (m *RpcOutput) popDataDefinitions() []Definition {
@@ -2386,12 +2674,10 @@ func (m *RpcOutput) clone(parent Meta) interface{} {
 	copy := *m
 	copy.parent = parent
 	if m.dataDefs != nil {
-		copy.dataDefs = make([]Definition, len(m.dataDefs))
-		copy.dataDefsIndex = make(map[string]Definition, len(m.dataDefs))
-		for i, def := range m.dataDefs {
-			copyDef := def.(cloneable).clone(&copy).(Definition)
-			copy.dataDefs[i] = copyDef
-			copy.dataDefsIndex[def.Ident()] = copyDef
+		copy.dataDefs = make([]Definition, 0, len(m.dataDefs))
+		copy.dataDefsIndex = nil
+		for _, def := range m.dataDefs {
+			copy.addDataDefinition(def.(cloneable).clone(&copy).(Definition))
 		}
 	}
 	
@@ -2413,6 +2699,16 @@ func (m *Rpc) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Rpc was defined. Nil if it was not parsed
+// from a file.
+func (m *Rpc) Location() *Location {
+	return m.loc
+}
+
+func (m *Rpc) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -2504,6 +2800,16 @@ func (m *Notification) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Notification was defined. Nil if it was not parsed
+// from a file.
+func (m *Notification) Location() *Location {
+	return m.loc
+}
+
+func (m *Notification) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -2563,11 +2869,31 @@ func (m *Notification) indexDataDefinition(def Definition) {
 	if m.dataDefsIndex == nil {
 		m.dataDefsIndex = make(map[string]Definition)
 	}
-	if _, exists := m.dataDefsIndex[def.Ident()]; exists {
+	ident := def.Ident()
+	if ns := NamespaceModule(def); ns != NamespaceModule(m) {
+		// augmented from another module so always findable by qualified
+		// ident and by plain ident only when nothing else has it
+		qualified := ns.Ident() + ":" + ident
+		if _, exists := m.dataDefsIndex[qualified]; exists {
+			// TODO: make this an error
+			panic(fmt.Sprintf("Conflict adding add %s to %s. ", qualified, m.Ident()))
+		}
+		m.dataDefsIndex[qualified] = def
+		existing, exists := m.dataDefsIndex[ident]
+		if !exists {
+			m.dataDefsIndex[ident] = def
+		} else if existing != nil && NamespaceModule(existing) != NamespaceModule(m) {
+			// more than one other module has ident so plain ident is
+			// ambiguous and qualified ident is required
+			m.dataDefsIndex[ident] = nil
+		}
+		return
+	}
+	if existing, exists := m.dataDefsIndex[ident]; exists && existing != nil && NamespaceModule(existing) == NamespaceModule(m) {
 		// TODO: make this an error
-		panic(fmt.Sprintf("Conflict adding add %s to %s. ", def.Ident(), m.Ident()))
-	}	
-	m.dataDefsIndex[def.Ident()] = def
+		panic(fmt.Sprintf("Conflict adding add %s to %s. ", ident, m.Ident()))
+	}
+	m.dataDefsIndex[ident] = def
 }
 
 func (m *Notification) popDataDefinitions() []Definition {
@@ -2638,12 +2964,10 @@ func (m *Notification) clone(parent Meta) interface{} {
 	copy := *m
 	copy.parent = parent
 	if m.dataDefs != nil {
-		copy.dataDefs = make([]Definition, len(m.dataDefs))
-		copy.dataDefsIndex = make(map[string]Definition, len(m.dataDefs))
-		for i, def := range m.dataDefs {
-			copyDef := def.(cloneable).clone(&copy).(Definition)
-			copy.dataDefs[i] = copyDef
-			copy.dataDefsIndex[def.Ident()] = copyDef
+		copy.dataDefs = make([]Definition, 0, len(m.dataDefs))
+		copy.dataDefsIndex = nil
+		for _, def := range m.dataDefs {
+			copy.addDataDefinition(def.(cloneable).clone(&copy).(Definition))
 		}
 	}
 	
@@ -2658,6 +2982,16 @@ func (m *Typedef) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Typedef was defined. Nil if it was not parsed
+// from a file.
+func (m *Typedef) Location() *Location {
+	return m.loc
+}
+
+func (m *Typedef) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -2730,6 +3064,16 @@ func (m *Augment) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Augment was defined. Nil if it was not parsed
+// from a file.
+func (m *Augment) Location() *Location {
+	return m.loc
+}
+
+func (m *Augment) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -2789,11 +3133,31 @@ func (m *Augment) indexDataDefinition(def Definition) {
 	if m.dataDefsIndex == nil {
 		m.dataDefsIndex = make(map[string]Definition)
 	}
-	if _, exists := m.dataDefsIndex[def.Ident()]; exists {
+	ident := def.Ident()
+	if ns := NamespaceModule(def); ns != NamespaceModule(m) {
+		// augmented from another module so always findable by qualified
+		// ident and by plain ident only when nothing else has it
+		qualified := ns.Ident() + ":" + ident
+		if _, exists := m.dataDefsIndex[qualified]; exists {
+			// TODO: make this an error
+			panic(fmt.Sprintf("Conflict adding add %s to %s. ", qualified, m.Ident()))
+		}
+		m.dataDefsIndex[qualified] = def
+		existing, exists := m.dataDefsIndex[ident]
+		if !exists {
+			m.dataDefsIndex[ident] = def
+		} else if existing != nil && NamespaceModule(existing) != NamespaceModule(m) {
+			// more than one other module has ident so plain ident is
+			// ambiguous and qualified ident is required
+			m.dataDefsIndex[ident] = nil
+		}
+		return
+	}
+	if existing, exists := m.dataDefsIndex[ident]; exists && existing != nil && NamespaceModule(existing) == NamespaceModule(m) {
 		// TODO: make this an error
-		panic(fmt.Sprintf("Conflict adding add %s to %s. ", def.Ident(), m.Ident()))
-	}	
-	m.dataDefsIndex[def.Ident()] = def
+		panic(fmt.Sprintf("Conflict adding add %s to %s. ", ident, m.Ident()))
+	}
+	m.dataDefsIndex[ident] = def
 }
 
 func (m *Augment) popDataDefinitions() []Definition {
@@ -2904,12 +3268,10 @@ func (m *Augment) clone(parent Meta) interface{} {
 	}
 	
 	if m.dataDefs != nil {
-		copy.dataDefs = make([]Definition, len(m.dataDefs))
-		copy.dataDefsIndex = make(map[string]Definition, len(m.dataDefs))
-		for i, def := range m.dataDefs {
-			copyDef := def.(cloneable).clone(&copy).(Definition)
-			copy.dataDefs[i] = copyDef
-			copy.dataDefsIndex[def.Ident()] = copyDef
+		copy.dataDefs = make([]Definition, 0, len(m.dataDefs))
+		copy.dataDefsIndex = nil
+		for _, def := range m.dataDefs {
+			copy.addDataDefinition(def.(cloneable).clone(&copy).(Definition))
 		}
 	}
 	
@@ -2919,6 +3281,16 @@ func (m *Augment) clone(parent Meta) interface{} {
 
 
 
+// Location in YANG file AddDeviate was defined. Nil if it was not parsed
+// from a file.
+func (m *AddDeviate) Location() *Location {
+	return m.loc
+}
+
+func (m *AddDeviate) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -3036,6 +3408,16 @@ func (m *AddDeviate) setDefault(d interface{}) {
 
 
 
+// Location in YANG file ReplaceDeviate was defined. Nil if it was not parsed
+// from a file.
+func (m *ReplaceDeviate) Location() *Location {
+	return m.loc
+}
+
+func (m *ReplaceDeviate) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -3137,6 +3519,16 @@ func (m *ReplaceDeviate) setDefault(d interface{}) {
 
 
 
+// Location in YANG file DeleteDeviate was defined. Nil if it was not parsed
+// from a file.
+func (m *DeleteDeviate) Location() *Location {
+	return m.loc
+}
+
+func (m *DeleteDeviate) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -3204,6 +3596,16 @@ func (m *Deviation) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Deviation was defined. Nil if it was not parsed
+// from a file.
+func (m *Deviation) Location() *Location {
+	return m.loc
+}
+
+func (m *Deviation) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -3244,6 +3646,16 @@ func (m *Type) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Type was defined. Nil if it was not parsed
+// from a file.
+func (m *Type) Location() *Location {
+	return m.loc
+}
+
+func (m *Type) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Description of Type
 func (m *Type) Description() string {
 	return m.desc
@@ -3277,6 +3689,16 @@ func (m *Identity) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Identity was defined. Nil if it was not parsed
+// from a file.
+func (m *Identity) Location() *Location {
+	return m.loc
+}
+
+func (m *Identity) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -3326,6 +3748,16 @@ func (m *Feature) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Feature was defined. Nil if it was not parsed
+// from a file.
+func (m *Feature) Location() *Location {
+	return m.loc
+}
+
+func (m *Feature) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -3370,6 +3802,16 @@ func (m *Feature) addIfFeature(i *IfFeature) {
 
 
 
+// Location in YANG file IfFeature was defined. Nil if it was not parsed
+// from a file.
+func (m *IfFeature) Location() *Location {
+	return m.loc
+}
+
+func (m *IfFeature) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -3390,6 +3832,16 @@ func (m *IfFeature) addExtension(extension *Extension) {
 
 
 
+// Location in YANG file When was defined. Nil if it was not parsed
+// from a file.
+func (m *When) Location() *Location {
+	return m.loc
+}
+
+func (m *When) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -3425,6 +3877,16 @@ func (m *When) addExtension(extension *Extension) {
 
 
 
+// Location in YANG file Must was defined. Nil if it was not parsed
+// from a file.
+func (m *Must) Location() *Location {
+	return m.loc
+}
+
+func (m *Must) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -3488,6 +3950,16 @@ func (m *ExtensionDef) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file ExtensionDef was defined. Nil if it was not parsed
+// from a file.
+func (m *ExtensionDef) Location() *Location {
+	return m.loc
+}
+
+func (m *ExtensionDef) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -3536,6 +4008,16 @@ func (m *ExtensionDefArg) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file ExtensionDefArg was defined. Nil if it was not parsed
+// from a file.
+func (m *ExtensionDefArg) Location() *Location {
+	return m.loc
+}
+
+func (m *ExtensionDefArg) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -3576,6 +4058,16 @@ func (m *Extension) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Extension was defined. Nil if it was not parsed
+// from a file.
+func (m *Extension) Location() *Location {
+	return m.loc
+}
+
+func (m *Extension) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Parent is where this extension is define unless the extension is a
 // secondary extension like a description and then this is the parent
 // of that description
@@ -3599,6 +4091,16 @@ func (m *Bit) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Bit was defined. Nil if it was not parsed
+// from a file.
+func (m *Bit) Location() *Location {
+	return m.loc
+}
+
+func (m *Bit) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Description of Bit
 func (m *Bit) Description() string {
 	return m.desc
@@ -3632,6 +4134,16 @@ func (m *Enum) Ident() string {
 	return m.ident
 }
 
+// Location in YANG file Enum was defined. Nil if it was not parsed
+// from a file.
+func (m *Enum) Location() *Location {
+	return m.loc
+}
+
+func (m *Enum) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Description of Enum
 func (m *Enum) Description() string {
 	return m.desc
@@ -3660,6 +4172,16 @@ func (m *Enum) addExtension(extension *Extension) {
 
 
 
+// Location in YANG file Range was defined. Nil if it was not parsed
+// from a file.
+func (m *Range) Location() *Location {
+	return m.loc
+}
+
+func (m *Range) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Description of Range
 func (m *Range) Description() string {
 	return m.desc
@@ -3704,6 +4226,16 @@ func (m *Range) setErrorAppTag(tag string) {
 
 
 
+// Location in YANG file Pattern was defined. Nil if it was not parsed
+// from a file.
+func (m *Pattern) Location() *Location {
+	return m.loc
+}
+
+func (m *Pattern) setLocation(loc *Location) {
+	m.loc = loc
+}
+
 // Description of Pattern
 func (m *Pattern) Description() string {
 	return m.desc
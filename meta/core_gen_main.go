@@ -69,6 +69,7 @@ type elem struct {
 	Unique              bool
 	OrderedBy           bool
 	ErrorMessage        bool
+	Location            bool
 }
 
 func buildElements() []*elem {
@@ -112,6 +113,8 @@ func (v *visitor) Visit(n ast.Node) ast.Visitor {
 			switch d.Names[0].Name {
 			case "parent":
 				v.elem.Parent = true
+			case "loc":
+				v.elem.Location = true
 			case "ident":
 				v.elem.Ident = true
 			case "desc":
@@ -0,0 +1,154 @@
+package meta
+
+import (
+	"errors"
+	"strings"
+)
+
+// Severity of a diagnostic
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Diagnostic is a problem found parsing or compiling a YANG file
+type Diagnostic struct {
+	Severity Severity
+
+	// Location is where problem is in YANG file or nil if not known
+	Location *Location
+
+	// Message describes the problem without the location
+	Message string
+
+	err error
+}
+
+// NewDiagnostic is an error diagnostic of err using location of err when
+// there is one
+func NewDiagnostic(err error) *Diagnostic {
+	d := &Diagnostic{
+		Severity: SeverityError,
+		Message:  err.Error(),
+		err:      err,
+	}
+	var located *LocationError
+	if errors.As(err, &located) {
+		d.Location = located.Location
+		d.Message = located.Err.Error()
+	}
+	return d
+}
+
+func (d *Diagnostic) Error() string {
+	if d.err != nil {
+		return d.err.Error()
+	}
+	if d.Location == nil {
+		return d.Message
+	}
+	return (&LocationError{Location: d.Location, Err: errors.New(d.Message)}).Error()
+}
+
+func (d *Diagnostic) Unwrap() error {
+	return d.err
+}
+
+// Diagnostics are all the problems found parsing or compiling a YANG file.
+// Errors from parser and Compile are Diagnostics so all problems can be
+// reported at once.
+type Diagnostics []*Diagnostic
+
+// DiagnosticsOf is all the problems in err
+func DiagnosticsOf(err error) Diagnostics {
+	if err == nil {
+		return nil
+	}
+	var diags Diagnostics
+	if errors.As(err, &diags) {
+		return diags
+	}
+	return Diagnostics{NewDiagnostic(err)}
+}
+
+// Error lists each diagnostic on it's own line
+func (d Diagnostics) Error() string {
+	msgs := make([]string, len(d))
+	for i, x := range d {
+		msgs[i] = x.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Is is true if any diagnostic is target. Walks diagnostics itself because
+// errors.Is only unwraps a list of errors in newer versions of Go.
+func (d Diagnostics) Is(target error) bool {
+	for _, x := range d {
+		if errors.Is(x, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds first diagnostic that is like target
+func (d Diagnostics) As(target interface{}) bool {
+	for _, x := range d {
+		if errors.As(x, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasErrors is true if any diagnostic is an error and not just a warning
+func (d Diagnostics) HasErrors() bool {
+	for _, x := range d {
+		if x.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// errorOrNil is nil when there are no diagnostics so callers can check for
+// nil error
+func (d Diagnostics) errorOrNil() error {
+	if len(d) == 0 {
+		return nil
+	}
+	return d
+}
+
+// appendErr adds all the problems in err that are not already reported. The
+// same definition can be checked more than once when it is shared.
+func (d Diagnostics) appendErr(err error) Diagnostics {
+	if err == nil {
+		return d
+	}
+	for _, x := range DiagnosticsOf(err) {
+		if !d.has(x) {
+			d = append(d, x)
+		}
+	}
+	return d
+}
+
+func (d Diagnostics) has(candidate *Diagnostic) bool {
+	msg := candidate.Error()
+	for _, x := range d {
+		if x.Error() == msg {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package meta
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+)
+
+func TestDiagnosticsIsAs(t *testing.T) {
+	loc := &LocationError{Location: &Location{File: "x.yang", Line: 2}, Err: errors.New("bad")}
+	var d Diagnostics
+	d = d.appendErr(errors.New("first"))
+	d = d.appendErr(fmt.Errorf("missing %w", fc.NotFoundError))
+	d = d.appendErr(loc)
+	fc.AssertEqual(t, true, errors.Is(d, fc.NotFoundError))
+	fc.AssertEqual(t, false, errors.Is(d, fc.BadRequestError))
+	var located *LocationError
+	fc.AssertEqual(t, true, errors.As(d, &located))
+	fc.AssertEqual(t, loc, located)
+}
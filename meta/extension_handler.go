@@ -0,0 +1,43 @@
+package meta
+
+// ExtensionHandler lets applications react to an extension wherever it is
+// used. Handlers may also implement interfaces in other packages to react to
+// extensions at runtime. For example node.ExtensionFieldHandler to change
+// values of leafs as they are read or written or node.ExtensionChildHandler
+// to react to containers and lists.
+type ExtensionHandler interface {
+
+	// Compile is called for each definition the extension is on when module is
+	// compiled. Use this to validate arguments or change the definition with
+	// a Builder. Called before the definition itself is compiled so groupings
+	// are expanded but types are not resolved yet.
+	Compile(parent Meta, e *Extension) error
+}
+
+// ExtensionHandlers are registered handlers by "prefix:extension" where prefix
+// is either the prefix the module that defines the extension declares for
+// itself or the name of that module.
+type ExtensionHandlers map[string]ExtensionHandler
+
+func (h ExtensionHandlers) find(def *ExtensionDef) ExtensionHandler {
+	if h == nil {
+		return nil
+	}
+	m := def.parent
+	if x, found := h[m.Prefix()+":"+def.Ident()]; found {
+		return x
+	}
+	return h[m.Ident()+":"+def.Ident()]
+}
+
+// CompileOptions are for non-standard options when compiling
+type CompileOptions struct {
+
+	// Extensions are handlers called for extensions used in module
+	Extensions ExtensionHandlers
+
+	// Deviations are modules loaded separately whose deviations are applied
+	// to module being compiled.  Deviations that target other modules are
+	// ignored.
+	Deviations []*Module
+}
@@ -2,27 +2,119 @@ package meta
 
 import "strings"
 
-// TODO: Support namespaces
+// Find definition by schema path relative to p. Path segments may be
+// qualified with either the prefix of a module as known by the root module
+// (e.g. "x:foo") or module name as in RFC7951 (e.g. "ietf-x:foo").  Qualifiers
+// are only required to tell apart definitions with the same ident that were
+// augmented in from different modules. Absolute paths may also be to
+// definitions in modules the root module imports.
 func Find(p Meta, path string) Definition {
+	return FindFrom(nil, p, path)
+}
+
+// FindFrom is Find for a path written in module y like the path in a when
+// expression. Prefixes are what y knows them as which in a module set may not
+// be what the root module knows them as.
+func FindFrom(y *Module, p Meta, path string) Definition {
 	if strings.HasPrefix(path, "../") {
-		return Find(p.Parent(), path[3:])
+		return FindFrom(y, p.Parent(), path[3:])
 	}
 	if strings.HasPrefix(path, "/") {
-		return Find(RootModule(p), path[1:])
+		root := RootModule(p)
+		if found := FindFrom(y, root, path[1:]); found != nil {
+			return found
+		}
+		return importedDefinition(root, path)
 	}
 	if seg := strings.IndexRune(path, '/'); seg > 0 {
-		if child := Find(p, path[:seg]); child != nil {
-			return Find(child, path[seg+1:])
+		if child := FindFrom(y, p, path[:seg]); child != nil {
+			return FindFrom(y, child, path[seg+1:])
 		}
 		return nil
 	}
-	if colon := strings.IndexRune(path, ':'); colon > 0 {
-		// TODO: qualify by namespace
-		// prefix := path[:colon]
-		path = path[colon+1:]
+	hd, ok := p.(HasDataDefinitions)
+	if !ok {
+		panic(SchemaPath(p) + " does not have definitions")
 	}
-	if hd, ok := p.(HasDataDefinitions); ok {
+	colon := strings.IndexRune(path, ':')
+	if colon < 0 {
 		return hd.Definition(path)
 	}
-	panic(SchemaPath(p) + " does not have definitions")
+	ident := path[colon+1:]
+	m := findModule(y, p, path[:colon])
+	if m == nil {
+		// augmented in from a module p does not know but still indexed by
+		// module name
+		return hd.Definition(path)
+	}
+	if def := hd.Definition(m.Ident() + ":" + ident); def != nil {
+		return def
+	}
+	if def := hd.Definition(ident); def != nil {
+		if NamespaceModule(def).Ident() == m.Ident() {
+			return def
+		}
+	}
+	return nil
+}
+
+// importedDefinition finds definition by absolute path in a module y
+// imports like the target of an augment. Imported module does not know the
+// prefixes y uses so they are replaced with module names.
+func importedDefinition(y *Module, path string) Definition {
+	segs := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	var target *Module
+	for i, seg := range segs {
+		colon := strings.IndexRune(seg, ':')
+		if colon < 0 {
+			return nil
+		}
+		m, err := y.ModuleByPrefix(seg[:colon])
+		if err != nil || m == nil {
+			return nil
+		}
+		if i == 0 {
+			target = m
+		}
+		// imported module does not know y or its prefixes but definitions y
+		// augmented in are indexed by module name
+		segs[i] = m.Ident() + seg[colon:]
+	}
+	if target == nil || target == y {
+		return nil
+	}
+	return Find(target, strings.Join(segs, "/"))
+}
+
+// FindModule by prefix or module name as known from the module p is in the
+// namespace of or else the root module of p.
+func FindModule(p Meta, qualifier string) *Module {
+	return findModule(nil, p, qualifier)
+}
+
+// findModule looks in module y that wrote the qualifier first as another
+// module may use the same prefix for a different module
+func findModule(y *Module, p Meta, qualifier string) *Module {
+	var candidates []*Module
+	if y != nil {
+		candidates = append(candidates, y)
+	}
+	if d, valid := p.(Definition); valid {
+		candidates = append(candidates, NamespaceModule(d))
+	}
+	candidates = append(candidates, RootModule(p))
+	for _, m := range candidates {
+		if m.Ident() == qualifier {
+			return m
+		}
+		if found, err := m.ModuleByPrefix(qualifier); err == nil {
+			return found
+		}
+		for _, i := range m.imports {
+			if i.moduleName == qualifier && i.module != nil {
+				return i.module
+			}
+		}
+	}
+	return nil
 }
@@ -0,0 +1,114 @@
+package meta
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Location is where a definition is in a YANG file
+type Location struct {
+	File string
+
+	// Line and Col start at 1
+	Line int
+	Col  int
+
+	// InstantiatedFrom is location of "uses" statement that copied this
+	// definition from a grouping, which may itself be instantiated from
+	// another "uses" statement. Nil if definition is not from a grouping.
+	InstantiatedFrom *Location
+}
+
+func (l *Location) String() string {
+	if l.File == "" {
+		// parsed from string
+		return fmt.Sprintf("%d:%d", l.Line, l.Col)
+	}
+	return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Col)
+}
+
+// instantiated is copy of location with "uses" location appended to the end
+// of the chain
+func (l *Location) instantiated(from *Location) *Location {
+	copy := *l
+	if copy.InstantiatedFrom == nil {
+		copy.InstantiatedFrom = from
+	} else {
+		copy.InstantiatedFrom = copy.InstantiatedFrom.instantiated(from)
+	}
+	return &copy
+}
+
+// LocationError is an error compiling a definition with the location of the
+// definition in YANG file
+type LocationError struct {
+	Location *Location
+	Err      error
+}
+
+func (e *LocationError) Error() string {
+	msg := e.Location.String() + " - " + e.Err.Error()
+	for from := e.Location.InstantiatedFrom; from != nil; from = from.InstantiatedFrom {
+		msg += ", instantiated from " + from.String()
+	}
+	return msg
+}
+
+func (e *LocationError) Unwrap() error {
+	return e.Err
+}
+
+// locateErr adds location of o to error unless error already has location of
+// a more specific definition
+func locateErr(o interface{}, err error) error {
+	if err == nil {
+		return nil
+	}
+	var located *LocationError
+	if errors.As(err, &located) {
+		return err
+	}
+	if h, valid := o.(HasLocation); valid && h.Location() != nil {
+		return &LocationError{Location: h.Location(), Err: err}
+	}
+	return err
+}
+
+// instantiate records the location of the "uses" statement on definitions
+// copied from a grouping and all their children
+func instantiate(o interface{}, from *Location) {
+	if from == nil {
+		return
+	}
+	if h, valid := o.(HasLocation); valid && h.Location() != nil {
+		h.setLocation(h.Location().instantiated(from))
+	}
+	switch x := o.(type) {
+	case *Choice:
+		for _, k := range x.Cases() {
+			instantiate(k, from)
+		}
+	case *Rpc:
+		if x.input != nil {
+			instantiate(x.input, from)
+		}
+		if x.output != nil {
+			instantiate(x.output, from)
+		}
+	}
+	if x, valid := o.(HasDataDefinitions); valid {
+		for _, def := range x.DataDefinitions() {
+			instantiate(def, from)
+		}
+	}
+	if x, valid := o.(HasActions); valid {
+		for _, a := range x.Actions() {
+			instantiate(a, from)
+		}
+	}
+	if x, valid := o.(HasNotifications); valid {
+		for _, n := range x.Notifications() {
+			instantiate(n, from)
+		}
+	}
+}
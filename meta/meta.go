@@ -65,6 +65,13 @@ type HasPresence interface {
 	setPresence(string)
 }
 
+// HasLocation is implemented by everything that can be parsed from a YANG
+// file
+type HasLocation interface {
+	Location() *Location
+	setLocation(*Location)
+}
+
 type HasUnique interface {
 	Meta
 	Unique() [][]string
@@ -219,3 +219,67 @@ func TestAugment(t *testing.T) {
 	}
 
 }
+
+func TestRangeBounds(t *testing.T) {
+	tests := []struct {
+		typ    string
+		digits int
+		rng    string
+		err    string
+	}{
+		{typ: "int32", rng: "1..3"},
+		{typ: "int32", rng: "-5..max"},
+		{typ: "int32", rng: "1.5..3", err: "m/x - invalid bound 1.5 in range 1.5..3"},
+		{typ: "uint8", rng: "0..256", err: "m/x - invalid bound 256 in range 0..256"},
+		{typ: "decimal64", digits: 2, rng: "1.25..3"},
+		{typ: "decimal64", digits: 1, rng: "1.25..3", err: "m/x - invalid bound 1.25 in range 1.25..3"},
+		{typ: "d", rng: "1.5..2"},
+		{typ: "d", rng: "1.55..2", err: "m/x - invalid bound 1.55 in range 1.55..2"},
+	}
+	for _, test := range tests {
+		b := &Builder{}
+		m := b.Module("m", nil)
+		td := b.Typedef(m, "d")
+		tdt := b.Type(td, "decimal64")
+		b.FractionDigits(tdt, 1)
+		l := b.Leaf(m, "x")
+		dt := b.Type(l, test.typ)
+		if test.digits > 0 {
+			b.FractionDigits(dt, test.digits)
+		}
+		b.ValueRange(dt, test.rng)
+		fc.AssertEqual(t, nil, b.LastErr)
+		err := Compile(m)
+		if test.err == "" {
+			fc.AssertEqual(t, nil, err)
+		} else if err == nil {
+			t.Errorf("%s %s expected error", test.typ, test.rng)
+		} else {
+			fc.AssertEqual(t, test.err, DiagnosticsOf(err)[0].Message)
+		}
+	}
+}
+
+func TestAugmentSameIdent(t *testing.T) {
+	b := &Builder{}
+	base := b.Module("base", nil)
+	b.Prefix(base, "b")
+	b.Container(base, "c")
+	loader := func(*Module, string, string, FeatureSet, Loader) (*Module, error) {
+		return base, nil
+	}
+	for _, ident := range []string{"p", "q"} {
+		m := b.Module(ident, nil)
+		b.Prefix(m, ident)
+		i := b.Import(m, "base", loader)
+		b.Prefix(i, "b")
+		a := b.Augment(m, "/b:c")
+		b.Type(b.Leaf(a, "w"), "string")
+		fc.AssertEqual(t, nil, b.LastErr)
+		fc.AssertEqual(t, nil, Compile(m))
+	}
+	// same ident from two modules requires qualified ident
+	fc.AssertEqual(t, nil, Find(base, "c/w"))
+	fc.AssertEqual(t, "p", NamespaceModule(Find(base, "c/p:w")).Ident())
+	fc.AssertEqual(t, "q", NamespaceModule(Find(base, "c/q:w")).Ident())
+}
@@ -0,0 +1,102 @@
+package meta
+
+import "fmt"
+
+// NewModuleSet combines compiled modules into a single module so data from
+// all the modules can be under a single root. Each top-level data definition,
+// action and notification is copied from the module that defines it and
+// remembers that module as it's namespace.  Definitions are found by their
+// ident or qualified with module name or prefix when more than one module
+// uses the same ident.
+//
+// Modules are also imported by their prefix and name so paths and xpath
+// expressions may reference definitions in any of the modules.
+func NewModuleSet(ident string, modules ...*Module) (*Module, error) {
+	root := &Module{
+		ident:   ident,
+		ver:     "1.1",
+		imports: make(map[string]*Import),
+		set: &moduleSet{
+			namespaces: make(map[Definition]*Module),
+		},
+	}
+	for _, m := range modules {
+		if m.parent != nil {
+			return nil, fmt.Errorf("%s is a submodule or import and cannot be in module set", m.ident)
+		}
+		for _, existing := range root.set.modules {
+			if existing.ident == m.ident {
+				return nil, fmt.Errorf("%s already in module set", m.ident)
+			}
+		}
+		i := &Import{
+			prefix:     m.prefix,
+			moduleName: m.ident,
+			parent:     root,
+			module:     m,
+		}
+		if _, taken := root.imports[m.prefix]; taken || m.prefix == "" {
+			// prefixes only need to be unique in module that imports them so
+			// these modules can only be referenced by name
+			i.prefix = m.ident
+		}
+		root.imports[i.prefix] = i
+		root.set.modules = append(root.set.modules, m)
+		m.linkTo = root
+
+		// register member before adding so definitions are indexed by
+		// qualified ident
+		for _, x := range m.DataDefinitions() {
+			copy := x.(cloneable).clone(root).(Definition)
+			root.set.namespaces[copy] = m
+			root.addDataDefinition(copy)
+		}
+		for _, x := range m.Actions() {
+			copy := x.clone(root).(*Rpc)
+			root.set.namespaces[copy] = m
+			if err := root.addMemberDefinition(copy); err != nil {
+				return nil, err
+			}
+		}
+		for _, x := range m.Notifications() {
+			copy := x.clone(root).(*Notification)
+			root.set.namespaces[copy] = m
+			if err := root.addMemberDefinition(copy); err != nil {
+				return nil, err
+			}
+		}
+	}
+	root.links = &identityLinks{}
+	return root, nil
+}
+
+type moduleSet struct {
+	modules []*Module
+
+	// top-level definitions to module they were copied from
+	namespaces map[Definition]*Module
+}
+
+// ModuleSetMembers are the modules combined by NewModuleSet or nil if module
+// is not a module set
+func (y *Module) ModuleSetMembers() []*Module {
+	if y.set == nil {
+		return nil
+	}
+	return y.set.modules
+}
+
+// addMemberDefinition adds action or notification under it's ident unless
+// another module already has that ident
+func (y *Module) addMemberDefinition(def Definition) error {
+	if y.Definition(def.Ident()) != nil {
+		return fmt.Errorf("%s is defined in more than one module of %s", def.Ident(), y.ident)
+	}
+	switch x := def.(type) {
+	case *Rpc:
+		y.addAction(x)
+	case *Notification:
+		y.addNotification(x)
+	}
+	return nil
+}
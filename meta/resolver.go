@@ -3,6 +3,7 @@ package meta
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 )
@@ -17,16 +18,20 @@ import (
 // 2.) process imports which triggers whole new, recursive chain of processing. This
 // is a form of resolving because imports are really just a way of grouping groupings into
 // separate files
-func resolve(m *Module) error {
+//
+// Problems with imports and includes stop resolving and are returned as error.
+// Other problems are in returned diagnostics and skipped so the rest of the
+// module can be resolved and compiled.
+func resolve(m *Module, deviations []*Module) (Diagnostics, error) {
 	r := &resolver{
-		builder:        &Builder{},
 		inProgressUses: make(map[interface{}]HasDataDefinitions),
 	}
 	if err := r.module(m); err != nil {
-		return err
+		return r.errs, err
 	}
+	r.applyExternalDeviations(m, deviations)
 	r.fillInRecursiveDefs()
-	return nil
+	return r.errs, nil
 }
 
 type recursiveEntry struct {
@@ -34,10 +39,17 @@ type recursiveEntry struct {
 	duplicate HasDataDefinitions
 }
 
+type usesKey struct {
+	module   *Module
+	schemaId interface{}
+}
+
 type resolver struct {
-	builder        *Builder
 	inProgressUses map[interface{}]HasDataDefinitions
 	recursives     []recursiveEntry
+
+	// problems that do not stop resolving the rest of the definitions
+	errs Diagnostics
 }
 
 func (r *resolver) module(y *Module) error {
@@ -60,12 +72,45 @@ func (r *resolver) module(y *Module) error {
 			}
 			_, err = i.loader(i.parent, i.subName, rev, i.parent.featureSet, i.loader)
 			if err != nil {
-				return errors.New(i.subName + " - " + err.Error())
+				return locateErr(i, fmt.Errorf("%s - %w", i.subName, err))
 			}
 		}
 	}
 
 	// expand all imports first because local uses may reference groupings in other files.
+	if err := r.imports(y); err != nil {
+		return err
+	}
+
+	//
+	// now we can go into definitions and resolve "uses"
+	//
+	if err := r.dataDef(y, y.popDataDefinitions()); err != nil {
+		return err
+	}
+
+	for _, a := range y.Augments() {
+		if err := r.expandAugment(a, y); err != nil {
+			r.errs = r.errs.appendErr(locateErr(a, err))
+		}
+	}
+
+	for _, d := range y.Deviations() {
+		if err := r.applyDeviation(y, d); err != nil {
+			r.errs = r.errs.appendErr(locateErr(d, err))
+		}
+	}
+
+	return nil
+}
+
+func (r *resolver) applyDeviation(y *Module, d *Deviation) error {
+	return applyDeviation(Find(y, d.Ident()), d)
+}
+
+// imports loads and resolves modules imported by module y and indexes them by
+// prefix
+func (r *resolver) imports(y *Module) error {
 	if len(y.imports) > 0 {
 		// imports were indexed by module name, but now that we know the
 		// prefix, we need to reindex them
@@ -74,10 +119,10 @@ func (r *resolver) module(y *Module) error {
 		for _, i := range byName {
 
 			if i.loader == nil {
-				return fmt.Errorf("%s - no module loader defined", i.moduleName)
+				return locateErr(i, fmt.Errorf("%s - no module loader defined", i.moduleName))
 			}
 			if i.prefix == "" {
-				return fmt.Errorf("%s - prefix required on import", i.moduleName)
+				return locateErr(i, fmt.Errorf("%s - prefix required on import", i.moduleName))
 			}
 			var err error
 			var rev string
@@ -86,8 +131,9 @@ func (r *resolver) module(y *Module) error {
 			}
 			i.module, err = i.loader(nil, i.moduleName, rev, i.parent.featureSet, i.loader)
 			if err != nil {
-				return fmt.Errorf("%s - %s", i.moduleName, err)
+				return locateErr(i, fmt.Errorf("%s - %w", i.moduleName, err))
 			}
+			i.module.linkTo = y
 
 			// recurse
 			if err = r.module(i.module); err != nil {
@@ -99,31 +145,183 @@ func (r *resolver) module(y *Module) error {
 			y.imports[i.Prefix()] = i
 		}
 	}
+	return nil
+}
 
-	//
-	// now we can go into definitions and resolve "uses"
-	//
-	if err := r.dataDef(y, y.popDataDefinitions()); err != nil {
-		return err
+// applyExternalDeviations applies deviations of modules loaded separately from
+// module y. Deviations of those modules that target other modules are
+// ignored. Prefixes in a deviation are as the deviation module knows them so
+// its imports are resolved and its types compiled before they are applied.
+func (r *resolver) applyExternalDeviations(y *Module, deviations []*Module) {
+	for _, dm := range deviations {
+		if err := r.imports(dm); err != nil {
+			r.errs = r.errs.appendErr(fmt.Errorf("%s - %w", dm.Ident(), err))
+			continue
+		}
+		for _, d := range dm.Deviations() {
+			path, targetsModule, err := externalDeviationPath(y, dm, d.Ident())
+			if err != nil {
+				r.errs = r.errs.appendErr(locateErr(d, fmt.Errorf("%s - %w", dm.Ident(), err)))
+				continue
+			}
+			if !targetsModule {
+				continue
+			}
+			target := Find(y, path)
+			if target != nil {
+				err = localizeDeviation(y, dm, target, d)
+			}
+			if err == nil {
+				err = applyDeviation(target, d)
+			}
+			if err != nil {
+				r.errs = r.errs.appendErr(locateErr(d, fmt.Errorf("%s - %w", dm.Ident(), err)))
+				continue
+			}
+			y.addDeviatedBy(dm)
+		}
 	}
+}
 
-	for _, a := range y.Augments() {
-		if err := r.expandAugment(a, y); err != nil {
-			return err
+// externalDeviationPath translates the absolute target path of a deviation
+// in module dm that uses dm's prefixes into a path from module y.  Segments
+// in y are left unqualified and segments augmented from other modules are
+// qualified by module name.
+func externalDeviationPath(y *Module, dm *Module, path string) (string, bool, error) {
+	segs := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, seg := range segs {
+		colon := strings.IndexRune(seg, ':')
+		if colon < 0 {
+			if i == 0 {
+				// targets deviation module itself
+				return "", false, nil
+			}
+			continue
+		}
+		prefix := seg[:colon]
+		if i == 0 {
+			if prefix == dm.Prefix() {
+				return "", false, nil
+			}
+			if name := importedModuleName(dm, prefix); name != "" && name != y.Ident() {
+				return "", false, nil
+			}
+		}
+		local, err := localIdent(y, dm, prefix, seg[colon+1:])
+		if err != nil {
+			return "", false, fmt.Errorf("%s - %w", path, err)
 		}
+		segs[i] = local
 	}
+	return "/" + strings.Join(segs, "/"), true, nil
+}
 
-	for _, d := range y.Deviations() {
-		if err := r.applyDeviation(y, d); err != nil {
-			return err
+// localIdent translates an identifier with a prefix of deviation module dm to
+// how module y knows it, unqualified for definitions in y and qualified by
+// module name otherwise.
+func localIdent(y *Module, dm *Module, prefix string, ident string) (string, error) {
+	if prefix == dm.Prefix() {
+		return "", errors.New("cannot reference definitions of deviation module")
+	}
+	moduleName := importedModuleName(dm, prefix)
+	if moduleName == "" {
+		return "", fmt.Errorf("prefix %s not imported", prefix)
+	}
+	if moduleName == y.Ident() {
+		return ident, nil
+	}
+	return moduleName + ":" + ident, nil
+}
+
+func importedModuleName(y *Module, prefix string) string {
+	for _, imp := range y.Imports() {
+		if imp.Prefix() == prefix {
+			return imp.moduleName
 		}
 	}
+	return ""
+}
 
-	return nil
+var xpathIdent = regexp.MustCompile(`'[^']*'|"[^"]*"|([A-Za-z_][\w.-]*):([A-Za-z_][\w.-]*)`)
+
+// localExpression translates the prefixes of a must expression or leafref
+// path written in deviation module dm to how module y knows them
+func localExpression(y *Module, dm *Module, expr string) (string, error) {
+	var err error
+	local := xpathIdent.ReplaceAllStringFunc(expr, func(match string) string {
+		parts := xpathIdent.FindStringSubmatch(match)
+		if parts[1] == "" || err != nil {
+			return match
+		}
+		var ident string
+		if ident, err = localIdent(y, dm, parts[1], parts[2]); err != nil {
+			return match
+		}
+		return ident
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s - %w", expr, err)
+	}
+	return local, nil
 }
 
-func (r *resolver) applyDeviation(y *Module, d *Deviation) error {
-	target := Find(y, d.Ident())
+// localizeDeviation makes a deviation from module dm usable in module y.
+// Prefixes in must expressions are translated and a replacement type is
+// compiled as dm knows its typedefs and identities so problems with it are
+// found before it is applied.
+func localizeDeviation(y *Module, dm *Module, target Definition, d *Deviation) error {
+	var musts []*Must
+	if d.Add != nil {
+		musts = append(musts, d.Add.musts...)
+	}
+	if d.Delete != nil {
+		musts = append(musts, d.Delete.musts...)
+	}
+	for _, must := range musts {
+		expr, err := localExpression(y, dm, must.expr)
+		if err != nil {
+			return err
+		}
+		must.expr = expr
+	}
+	if d.Replace == nil || d.Replace.dtype == nil {
+		return nil
+	}
+	// a leaf in deviation module at the position of the target so typedefs
+	// and identities resolve in dm and relative leafref paths resolve in y
+	var scope Leafable
+	switch target.(type) {
+	case *Leaf:
+		scope = &Leaf{ident: target.Ident(), parent: target.Parent(), originalParent: dm}
+	case *LeafList:
+		scope = &LeafList{ident: target.Ident(), parent: target.Parent(), originalParent: dm}
+	default:
+		// applyDeviation reports type is not supported
+		return nil
+	}
+	types := []*Type{d.Replace.dtype}
+	for len(types) > 0 {
+		t := types[0]
+		types = append(types[1:], t.unionTypes...)
+		if t.path == "" {
+			continue
+		}
+		path, err := localExpression(y, dm, t.path)
+		if err != nil {
+			return err
+		}
+		t.path = path
+	}
+	c := &compiler{root: RootModule(target)}
+	if err := c.compileType(d.Replace.dtype, scope); err != nil {
+		return err
+	}
+	return c.errs.errorOrNil()
+}
+
+// applyDeviation follows the restrictions in 7.20.3.2 of RFC7950. I assume
+// violations are errors, not silent ignores.
+func applyDeviation(target Definition, d *Deviation) error {
 	if target == nil {
 		return fmt.Errorf("could not find target for deviation %s", d.Ident())
 	}
@@ -136,7 +334,10 @@ func (r *resolver) applyDeviation(y *Module, d *Deviation) error {
 			notifs := target.Parent().(HasNotifications).Notifications()
 			delete(notifs, target.Ident())
 		default:
-			hasDDefs := target.Parent().(HasDataDefinitions)
+			hasDDefs, valid := target.Parent().(HasDataDefinitions)
+			if !valid {
+				return fmt.Errorf("%s cannot be removed from %T", d.Ident(), target.Parent())
+			}
 			existing := hasDDefs.popDataDefinitions()
 			for _, candidate := range existing {
 				if candidate != target {
@@ -146,116 +347,229 @@ func (r *resolver) applyDeviation(y *Module, d *Deviation) error {
 		}
 		return nil
 	}
-	// 7.20.3.2. in RFC details restrictions on deviations. I assume
-	// violations are errors, not silent ignores.
+	if d.Add != nil {
+		if err := applyAddDeviate(target, d.Ident(), d.Add); err != nil {
+			return err
+		}
+	}
+	if d.Replace != nil {
+		if err := applyReplaceDeviate(target, d.Ident(), d.Replace); err != nil {
+			return err
+		}
+	}
+	if d.Delete != nil {
+		if err := applyDeleteDeviate(target, d.Ident(), d.Delete); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deviateNotSupported(property string, path string, target Definition) error {
+	return fmt.Errorf("%s not supported on %s %s", property, deviateTargetType(target), path)
+}
+
+func deviateTargetType(target Definition) string {
+	switch target.(type) {
+	case *Container:
+		return "container"
+	case *List:
+		return "list"
+	case *Leaf:
+		return "leaf"
+	case *LeafList:
+		return "leaf-list"
+	case *Any:
+		return "anydata"
+	case *Choice:
+		return "choice"
+	}
+	return fmt.Sprintf("%T", target)
+}
+
+func applyAddDeviate(target Definition, path string, add *AddDeviate) error {
 	hasDets, _ := target.(HasDetails)
-	hasType, _ := target.(Leafable)
 	hasListDets, _ := target.(HasListDetails)
-	if d.Add != nil {
-		if d.Add.configPtr != nil {
-			if hasDets.IsConfigSet() {
-				return fmt.Errorf("config already set on %s", d.Ident())
-			}
-			hasDets.setConfig(*(d.Add).configPtr)
+	hasType, _ := target.(Leafable)
+	if add.configPtr != nil {
+		if hasDets == nil {
+			return deviateNotSupported("config", path, target)
 		}
-		if d.Add.mandatoryPtr != nil {
-			if hasDets.IsMandatorySet() {
-				return fmt.Errorf("mandatory already set on %s", d.Ident())
-			}
-			hasDets.setMandatory(*(d.Add).mandatoryPtr)
+		if hasDets.IsConfigSet() {
+			return fmt.Errorf("config already set on %s", path)
 		}
-		if d.Add.maxElementsPtr != nil {
-			if hasListDets.IsMaxElementsSet() {
-				return fmt.Errorf("max-elements already set on %s", d.Ident())
-			}
-			hasListDets.setMaxElements(*(d.Add).maxElementsPtr)
+		hasDets.setConfig(*add.configPtr)
+	}
+	if add.mandatoryPtr != nil {
+		if hasDets == nil {
+			return deviateNotSupported("mandatory", path, target)
 		}
-		if d.Add.minElementsPtr != nil {
-			if hasListDets.IsMinElementsSet() {
-				return fmt.Errorf("min-elements already set on %s", d.Ident())
-			}
-			hasListDets.setMinElements(*(d.Add).minElementsPtr)
+		if hasDets.IsMandatorySet() {
+			return fmt.Errorf("mandatory already set on %s", path)
 		}
-		for _, must := range d.Add.musts {
-			target.(HasMusts).addMust(must)
+		hasDets.setMandatory(*add.mandatoryPtr)
+	}
+	if add.maxElementsPtr != nil {
+		if hasListDets == nil {
+			return deviateNotSupported("max-elements", path, target)
 		}
-		if d.Add.units != "" {
-			if hasType.Units() != "" {
-				return fmt.Errorf("units already set on %s", d.Ident())
-			}
-			hasType.setUnits(d.Add.units)
+		if hasListDets.IsMaxElementsSet() {
+			return fmt.Errorf("max-elements already set on %s", path)
 		}
-		if d.Add.defaultVal != nil {
-			if hasType.Default() != nil {
-				return fmt.Errorf("default already set on %s", d.Ident())
-			}
-			hasType.setDefault(d.Add.defaultVal)
+		hasListDets.setMaxElements(*add.maxElementsPtr)
+	}
+	if add.minElementsPtr != nil {
+		if hasListDets == nil {
+			return deviateNotSupported("min-elements", path, target)
+		}
+		if hasListDets.IsMinElementsSet() {
+			return fmt.Errorf("min-elements already set on %s", path)
 		}
-		for _, unique := range d.Add.unique {
-			target.(*List).unique = append(target.(*List).unique, unique)
+		hasListDets.setMinElements(*add.minElementsPtr)
+	}
+	if add.units != "" {
+		if hasType == nil {
+			return deviateNotSupported("units", path, target)
 		}
-		for _, must := range d.Add.musts {
-			target.(HasMusts).addMust(must)
+		if hasType.Units() != "" {
+			return fmt.Errorf("units already set on %s", path)
 		}
+		hasType.setUnits(add.units)
 	}
-	if d.Replace != nil {
-		if d.Replace.configPtr != nil {
-			if !hasDets.IsConfigSet() {
-				return fmt.Errorf("config not set on %s", d.Ident())
-			}
-			hasDets.setConfig(*(d.Replace).configPtr)
+	if add.defaultVal != nil {
+		if hasType == nil {
+			return deviateNotSupported("default", path, target)
 		}
-		if d.Replace.mandatoryPtr != nil {
-			if !hasDets.IsMandatorySet() {
-				return fmt.Errorf("mandatory not set on %s", d.Ident())
-			}
-			hasDets.setMandatory(*(d.Replace).mandatoryPtr)
+		if hasType.HasDefault() {
+			return fmt.Errorf("default already set on %s", path)
 		}
-		if d.Replace.maxElementsPtr != nil {
-			if !hasListDets.IsMaxElementsSet() {
-				return fmt.Errorf("max-elements not set on %s", d.Ident())
-			}
-			hasListDets.setMaxElements(*(d.Replace).maxElementsPtr)
+		hasType.setDefault(add.defaultVal)
+	}
+	if len(add.unique) > 0 {
+		list, valid := target.(*List)
+		if !valid {
+			return deviateNotSupported("unique", path, target)
 		}
-		if d.Replace.minElementsPtr != nil {
-			if !hasListDets.IsMinElementsSet() {
-				return fmt.Errorf("min-elements not set on %s", d.Ident())
+		for _, unique := range add.unique {
+			for _, candidate := range list.unique {
+				if isArrayStringEqual(unique, candidate) {
+					return fmt.Errorf("unique entry %s already set on %s",
+						strings.Join(unique, " "), path)
+				}
 			}
-			hasListDets.setMinElements(*(d.Replace).minElementsPtr)
+			list.unique = append(list.unique, unique)
 		}
-		if d.Replace.units != "" {
-			if hasType.Units() == "" {
-				return fmt.Errorf("units not set on %s", d.Ident())
-			}
-			hasType.setUnits(d.Replace.units)
+	}
+	if len(add.musts) > 0 {
+		hasMusts, valid := target.(HasMusts)
+		if !valid {
+			return deviateNotSupported("must", path, target)
 		}
-		if d.Replace.defaultVal != nil {
-			if hasType.Default() == nil {
-				return fmt.Errorf("default not set on %s", d.Ident())
-			}
-			hasType.setDefault(d.Replace.defaultVal)
+		for _, must := range add.musts {
+			hasMusts.addMust(must)
 		}
 	}
-	if d.Delete != nil {
-		if d.Delete.units != "" {
-			if hasType.Units() == d.Delete.units {
-				return fmt.Errorf("cannot delete units '%s' != '%s' on %s",
-					d.Delete.units, hasType.Units(), d.Ident())
-			}
-			hasType.setUnits("")
+	return nil
+}
+
+func applyReplaceDeviate(target Definition, path string, replace *ReplaceDeviate) error {
+	hasDets, _ := target.(HasDetails)
+	hasListDets, _ := target.(HasListDetails)
+	hasType, _ := target.(Leafable)
+	if replace.dtype != nil {
+		if hasType == nil {
+			return deviateNotSupported("type", path, target)
 		}
-		if d.Delete.defaultVal != nil {
-			if hasType.Default() == d.Delete.defaultVal {
-				return fmt.Errorf("cannot delete units '%s' != '%s' on %s",
-					d.Delete.defaultVal, hasType.Default(),
-					d.Ident())
-			}
-			hasType.setDefault(nil)
+		hasType.setType(replace.dtype)
+	}
+	if replace.configPtr != nil {
+		if hasDets == nil {
+			return deviateNotSupported("config", path, target)
+		}
+		if !hasDets.IsConfigSet() {
+			return fmt.Errorf("config not set on %s", path)
+		}
+		hasDets.setConfig(*replace.configPtr)
+	}
+	if replace.mandatoryPtr != nil {
+		if hasDets == nil {
+			return deviateNotSupported("mandatory", path, target)
+		}
+		if !hasDets.IsMandatorySet() {
+			return fmt.Errorf("mandatory not set on %s", path)
+		}
+		hasDets.setMandatory(*replace.mandatoryPtr)
+	}
+	if replace.maxElementsPtr != nil {
+		if hasListDets == nil {
+			return deviateNotSupported("max-elements", path, target)
+		}
+		if !hasListDets.IsMaxElementsSet() {
+			return fmt.Errorf("max-elements not set on %s", path)
+		}
+		hasListDets.setMaxElements(*replace.maxElementsPtr)
+	}
+	if replace.minElementsPtr != nil {
+		if hasListDets == nil {
+			return deviateNotSupported("min-elements", path, target)
+		}
+		if !hasListDets.IsMinElementsSet() {
+			return fmt.Errorf("min-elements not set on %s", path)
+		}
+		hasListDets.setMinElements(*replace.minElementsPtr)
+	}
+	if replace.units != "" {
+		if hasType == nil {
+			return deviateNotSupported("units", path, target)
+		}
+		if hasType.Units() == "" {
+			return fmt.Errorf("units not set on %s", path)
+		}
+		hasType.setUnits(replace.units)
+	}
+	if replace.defaultVal != nil {
+		if hasType == nil {
+			return deviateNotSupported("default", path, target)
+		}
+		if !hasType.HasDefault() {
+			return fmt.Errorf("default not set on %s", path)
+		}
+		hasType.setDefault(replace.defaultVal)
+	}
+	return nil
+}
+
+func applyDeleteDeviate(target Definition, path string, del *DeleteDeviate) error {
+	hasType, _ := target.(Leafable)
+	if del.units != "" {
+		if hasType == nil {
+			return deviateNotSupported("units", path, target)
+		}
+		if hasType.Units() != del.units {
+			return fmt.Errorf("cannot delete units '%s' != '%s' on %s",
+				del.units, hasType.Units(), path)
+		}
+		hasType.setUnits("")
+	}
+	if del.defaultVal != nil {
+		if hasType == nil {
+			return deviateNotSupported("default", path, target)
+		}
+		if !hasType.HasDefault() || fmt.Sprint(hasType.Default()) != fmt.Sprint(del.defaultVal) {
+			return fmt.Errorf("cannot delete default '%v' != '%v' on %s",
+				del.defaultVal, hasType.Default(), path)
 		}
-		for _, unique := range d.Delete.unique {
+		hasType.setDefault(nil)
+	}
+	if len(del.unique) > 0 {
+		list, valid := target.(*List)
+		if !valid {
+			return deviateNotSupported("unique", path, target)
+		}
+		for _, unique := range del.unique {
 			found := false
 			var uniques [][]string
-			for _, candidate := range target.(*List).unique {
+			for _, candidate := range list.unique {
 				if isArrayStringEqual(unique, candidate) {
 					found = true
 				} else {
@@ -264,14 +578,20 @@ func (r *resolver) applyDeviation(y *Module, d *Deviation) error {
 			}
 			if !found {
 				return fmt.Errorf("unique entry %s not found on %s",
-					strings.Join(unique, " "), d.Ident())
+					strings.Join(unique, " "), path)
 			}
-			target.(*List).unique = uniques
+			list.unique = uniques
+		}
+	}
+	if len(del.musts) > 0 {
+		hasMusts, valid := target.(HasMusts)
+		if !valid {
+			return deviateNotSupported("must", path, target)
 		}
-		for _, must := range d.Delete.musts {
+		for _, must := range del.musts {
 			found := false
 			var musts []*Must
-			for _, candidate := range target.(HasMusts).Musts() {
+			for _, candidate := range hasMusts.Musts() {
 				if candidate.Expression() == must.Expression() {
 					found = true
 				} else {
@@ -280,11 +600,10 @@ func (r *resolver) applyDeviation(y *Module, d *Deviation) error {
 			}
 			if !found {
 				return fmt.Errorf("must entry %s not found on %s",
-					must.Expression(), d.Ident())
+					must.Expression(), path)
 			}
-			target.(HasMusts).setMusts(musts)
+			hasMusts.setMusts(musts)
 		}
-
 	}
 	return nil
 }
@@ -316,8 +635,12 @@ func isArrayStringEqual(a []string, b []string) bool {
 //     Enter d3, Leave d3, Leave b1, Leave M
 func (r *resolver) dataDef(x HasDataDefinitions, defs []Definition) error {
 	for _, def := range defs {
-		if more, err := r.addDataDef(x, def); err != nil || !more {
-			return err
+		more, err := r.addDataDef(x, def)
+		if err != nil {
+			// keep going to find problems in remaining definitions
+			r.errs = r.errs.appendErr(locateErr(def, err))
+		} else if !more {
+			return nil
 		}
 	}
 
@@ -369,18 +692,20 @@ func (r *resolver) addDataDef(parent HasDataDefinitions, child Definition) (bool
 		// probably a better way to detect recursive definitions and leverage
 		// caching to speed up uses/grouping resolution
 		if IsList(parent) || IsContainer(parent) {
-			if master, foundInCache := r.inProgressUses[u.schemaId]; foundInCache {
+			// ids are only unique within the module they were parsed in
+			key := usesKey{originalModule(u), u.schemaId}
+			if master, foundInCache := r.inProgressUses[key]; foundInCache {
 				// resolve this uses later
 				r.recursives = append(r.recursives, recursiveEntry{master, parent})
 				// fmt.Printf("%s : %s <= %s \n", u.ident, SchemaPath(master), SchemaPath(parent))
 				return false, nil
 			}
 
-			r.inProgressUses[u.schemaId] = parent
+			r.inProgressUses[key] = parent
 		}
 
 		// resolve all children
-		groupDefs := r.cloneDefs(parent, g.DataDefinitions(), u.when)
+		groupDefs := r.cloneDefs(parent, g.DataDefinitions(), u.when, u.loc)
 		err = r.dataDef(parent, groupDefs)
 		if err != nil {
 			return false, err
@@ -392,7 +717,7 @@ func (r *resolver) addDataDef(parent HasDataDefinitions, child Definition) (bool
 
 		for _, a := range u.augments {
 			if err := r.expandAugment(a, parent); err != nil {
-				return false, err
+				return false, locateErr(a, err)
 			}
 		}
 
@@ -403,14 +728,18 @@ func (r *resolver) addDataDef(parent HasDataDefinitions, child Definition) (bool
 			if !validActions {
 				return false, fmt.Errorf("cannot add %s. %s does not allow actions", u.ident, SchemaPath(u))
 			}
-			hasActions.addAction(a.clone(parent).(*Rpc))
+			copy := a.clone(parent).(*Rpc)
+			instantiate(copy, u.loc)
+			hasActions.addAction(copy)
 		}
 		for _, a := range g.Notifications() {
 			hasNotifs, validNotifs := parent.(HasNotifications)
 			if !validNotifs {
 				return false, fmt.Errorf("cannot add %s. %s does not allow notifications", u.ident, SchemaPath(u))
 			}
-			hasNotifs.addNotification(a.clone(parent).(*Notification))
+			copy := a.clone(parent).(*Notification)
+			instantiate(copy, u.loc)
+			hasNotifs.addNotification(copy)
 		}
 
 		return true, nil
@@ -453,10 +782,11 @@ func (r *resolver) fillInRecursiveDefs() {
 	}
 }
 
-func (r *resolver) cloneDefs(parent HasDataDefinitions, defs []Definition, when *When) []Definition {
+func (r *resolver) cloneDefs(parent HasDataDefinitions, defs []Definition, when *When, from *Location) []Definition {
 	copy := make([]Definition, len(defs))
 	for i, d := range defs {
 		copy[i] = d.(cloneable).clone(parent).(Definition)
+		instantiate(copy[i], from)
 		if when != nil {
 			copy[i].(HasWhen).setWhen(when)
 		}
@@ -516,49 +846,51 @@ func (r *resolver) applyRefinements(u *Uses, parent Definition) error {
 		}
 		target := Find(parent.(HasDataDefinitions), refine.Ident())
 		if target == nil {
-			return fmt.Errorf("%s:could not find target for refine %s", SchemaPath(u), refine.Ident())
+			return locateErr(refine, fmt.Errorf("%s:could not find target for refine %s", SchemaPath(u), refine.Ident()))
 		}
 		if err := r.refine(target, refine); err != nil {
-			return err
+			return locateErr(refine, err)
 		}
 	}
 	return nil
 }
 
 func (r *resolver) refine(target Definition, y *Refine) error {
+	// new builder so errors of other refines are not reported again
+	b := &Builder{}
 	if y.desc != "" {
-		r.builder.Description(target, y.desc)
+		b.Description(target, y.desc)
 	}
 	if y.ref != "" {
-		r.builder.Reference(target, y.ref)
+		b.Reference(target, y.ref)
 	}
 	if y.defaultVal != nil {
-		r.builder.Default(target, y.defaultVal)
+		b.Default(target, y.defaultVal)
 	}
 	if y.configPtr != nil {
-		r.builder.Config(target, *y.configPtr)
+		b.Config(target, *y.configPtr)
 	}
 	if y.mandatoryPtr != nil {
-		r.builder.Mandatory(target, *y.mandatoryPtr)
+		b.Mandatory(target, *y.mandatoryPtr)
 	}
 	if y.maxElementsPtr != nil {
-		r.builder.MaxElements(target, *y.maxElementsPtr)
+		b.MaxElements(target, *y.maxElementsPtr)
 	}
 	if y.minElementsPtr != nil {
-		r.builder.MinElements(target, *y.minElementsPtr)
+		b.MinElements(target, *y.minElementsPtr)
 	}
 	if y.unboundedPtr != nil {
-		r.builder.UnBounded(target, *y.unboundedPtr)
+		b.UnBounded(target, *y.unboundedPtr)
 	}
 	for _, m := range y.Musts() {
 		h, valid := target.(HasMusts)
 		if !valid {
-			r.builder.setErr(fmt.Errorf("%T does not support must", target))
+			b.setErr(fmt.Errorf("%T does not support must", target))
 		} else {
 			h.addMust(m.clone(target).(*Must))
 		}
 	}
-	return r.builder.LastErr
+	return b.LastErr
 }
 
 func (r *resolver) expandAugment(y *Augment, parent Meta) error {
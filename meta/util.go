@@ -45,6 +45,47 @@ func originalModule(m Definition) *Module {
 	}
 }
 
+// NamespaceModule is the module whose namespace a definition is in. This is
+// the module that augmented the definition into the schema with a top-level
+// augment, the module a top-level definition was copied from in a module set
+// or the root module otherwise. Definitions from groupings, including ones
+// added by an augment inside a uses, are in the namespace of the module with
+// the uses statement, RFC7950 Section 7.13.
+func NamespaceModule(d Definition) *Module {
+	for p := d; p != nil; p = p.getOriginalParent() {
+		if a, isAugment := p.(*Augment); isAugment {
+			if _, topLevel := a.getOriginalParent().(*Module); topLevel {
+				return originalModule(a)
+			}
+		}
+	}
+	root := RootModule(d)
+	if root.set != nil && Meta(d) != root {
+		var top Meta = d
+		for top.Parent() != root {
+			top = top.Parent()
+		}
+		if m, found := root.set.namespaces[top.(Definition)]; found {
+			return m
+		}
+	}
+	return root
+}
+
+// OriginalModule is the module a definition was defined in, not the module it
+// ended up in. Prefixes in a definition's statements like when are as this
+// module knows them.
+func OriginalModule(d Definition) *Module {
+	return originalModule(d)
+}
+
+// OriginalGrouping is the grouping a definition was copied from by a uses
+// statement or nil if definition was not defined directly in a grouping
+func OriginalGrouping(d Definition) *Grouping {
+	g, _ := d.getOriginalParent().(*Grouping)
+	return g
+}
+
 func splitIdent(ident string) (string, string) {
 	i := strings.IndexRune(ident, ':')
 	if i < 0 {
@@ -0,0 +1,815 @@
+package meta
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// YangWtr writes a module back out as YANG 1.1 source with substatements in
+// the order listed in RFC7950.
+//
+// Module can be written in it's original form, as it was written, if it was
+// loaded but not compiled.  See parser.Options. Or module can be written in
+// it's resolved form if module was compiled.  Resolved form has all groupings
+// expanded, typedefs replaced with the built-in type they derive from,
+// augments and deviations applied and definitions under disabled features
+// removed. Resolved form is useful to publish the effective schema a server
+// implements.
+type YangWtr struct {
+	Out io.Writer
+
+	// Resolved writes module in it's resolved form. Module must be compiled.
+	Resolved bool
+
+	// Indent for each level, default is 2 spaces
+	Indent string
+}
+
+// WriteYang writes original form of module to a string
+func WriteYang(m *Module) (string, error) {
+	var buf bytes.Buffer
+	w := &YangWtr{Out: &buf}
+	err := w.Write(m)
+	return buf.String(), err
+}
+
+// Write module to stream
+func (w *YangWtr) Write(m *Module) error {
+	b := &stmtBuilder{module: m, resolved: w.Resolved}
+	out := bufio.NewWriter(w.Out)
+	indent := w.Indent
+	if indent == "" {
+		indent = "  "
+	}
+	w.write(out, b.moduleStmt(m), "", indent)
+	return out.Flush()
+}
+
+func (w *YangWtr) write(out *bufio.Writer, s *stmt, prefix string, indent string) {
+	if s.keyword == "//" {
+		out.WriteString(prefix + "// " + s.arg + "\n")
+		return
+	}
+	out.WriteString(prefix)
+	out.WriteString(s.keyword)
+	if s.hasArg {
+		out.WriteRune(' ')
+		if s.quote || s.arg == "" {
+			out.WriteString(Quote(s.arg))
+		} else {
+			out.WriteString(s.arg)
+		}
+	}
+	if len(s.subs) == 0 {
+		out.WriteString(";\n")
+		return
+	}
+	out.WriteString(" {\n")
+	for i, sub := range s.subs {
+		// separate major sections at the top level for readability
+		if prefix == "" && i > 0 && (len(sub.subs) > 0 || len(s.subs[i-1].subs) > 0) {
+			out.WriteRune('\n')
+		}
+		w.write(out, sub, prefix+indent, indent)
+	}
+	out.WriteString(prefix + "}\n")
+}
+
+// Quote is s as a YANG string argument. Single quotes keep backslashes and
+// double quotes as is, otherwise they are escaped in double quotes,
+// RFC7950 Section 6.1.3.
+func Quote(s string) string {
+	if !strings.ContainsAny(s, `"\`) {
+		return `"` + s + `"`
+	}
+	if !strings.ContainsRune(s, '\'') {
+		return `'` + s + `'`
+	}
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	return `"` + s + `"`
+}
+
+// stmt is a generic YANG statement that is independent of how it is written
+type stmt struct {
+	keyword string
+	arg     string
+	hasArg  bool
+	quote   bool
+	subs    []*stmt
+
+	// set when statement is an extension so writers can find definition
+	ext *Extension
+}
+
+// id adds a substatement whose argument does not need quotes
+func (s *stmt) id(keyword string, arg string) *stmt {
+	sub := &stmt{keyword: keyword, arg: arg, hasArg: true}
+	s.subs = append(s.subs, sub)
+	return sub
+}
+
+// name adds a substatement whose argument is only quoted when it has to be
+// like an enum name with spaces
+func (s *stmt) name(keyword string, arg string) *stmt {
+	sub := s.id(keyword, arg)
+	sub.quote = strings.ContainsAny(arg, " \t\r\n;{}\"'")
+	return sub
+}
+
+// str adds a substatement whose argument is a quoted string
+func (s *stmt) str(keyword string, arg string) *stmt {
+	sub := s.id(keyword, arg)
+	sub.quote = true
+	return sub
+}
+
+// optStr adds a substatement only if there is a value
+func (s *stmt) optStr(keyword string, arg string) {
+	if arg != "" {
+		s.str(keyword, arg)
+	}
+}
+
+// noArg adds a substatement that has no argument like input or output
+func (s *stmt) noArg(keyword string) *stmt {
+	sub := &stmt{keyword: keyword}
+	s.subs = append(s.subs, sub)
+	return sub
+}
+
+type stmtBuilder struct {
+	module   *Module
+	resolved bool
+}
+
+func (b *stmtBuilder) moduleStmt(m *Module) *stmt {
+	s := &stmt{keyword: "module", arg: m.ident, hasArg: true}
+	s.id("yang-version", "1.1")
+	s.str("namespace", m.namespace)
+	s.id("prefix", m.prefix)
+	for _, i := range b.importsSorted(m) {
+		b.importStmt(s, i)
+	}
+	if !b.resolved {
+		for _, i := range m.includes {
+			x := s.id("include", i.subName)
+			if i.rev != nil {
+				x.id("revision-date", i.rev.ident)
+			}
+			b.describe(x, i)
+			b.extensions(x, i)
+		}
+	}
+	s.optStr("organization", m.org)
+	s.optStr("contact", m.contact)
+	b.describe(s, m)
+	for _, r := range m.rev {
+		x := s.id("revision", r.ident)
+		b.describe(x, r)
+		b.extensions(x, r)
+	}
+	b.extensionDefs(s, m.extensionDefs)
+	b.features(s, m.features)
+	b.identities(s, m.identities)
+	b.typedefs(s, m.typedefs)
+	b.groupings(s, m.groupings)
+	b.dataDefs(s, m)
+	if !b.resolved {
+		for _, a := range m.augments {
+			b.augment(s, a)
+		}
+	}
+	b.actions(s, "rpc", m.actions)
+	b.notifications(s, m.notifications)
+	if !b.resolved {
+		for _, d := range m.deviations {
+			b.deviation(s, d)
+		}
+	}
+	b.extensions(s, m)
+	return s
+}
+
+func (b *stmtBuilder) extensionDefs(s *stmt, defs map[string]*ExtensionDef) {
+	idents := make([]string, 0, len(defs))
+	for ident := range defs {
+		idents = append(idents, ident)
+	}
+	sort.Strings(idents)
+	for _, ident := range idents {
+		b.extensionDef(s, defs[ident])
+	}
+}
+
+func (b *stmtBuilder) features(s *stmt, features map[string]*Feature) {
+	idents := make([]string, 0, len(features))
+	for ident := range features {
+		idents = append(idents, ident)
+	}
+	sort.Strings(idents)
+	for _, ident := range idents {
+		f := features[ident]
+		x := s.id("feature", f.ident)
+		b.ifFeatures(x, f)
+		b.describe(x, f)
+		b.extensions(x, f)
+	}
+}
+
+func (b *stmtBuilder) identities(s *stmt, identities map[string]*Identity) {
+	idents := make([]string, 0, len(identities))
+	for ident := range identities {
+		idents = append(idents, ident)
+	}
+	sort.Strings(idents)
+	for _, ident := range idents {
+		i := identities[ident]
+		x := s.id("identity", i.ident)
+		b.ifFeatures(x, i)
+		for _, base := range i.baseIds {
+			x.id("base", base)
+		}
+		b.describe(x, i)
+		b.extensions(x, i)
+	}
+}
+
+func (b *stmtBuilder) importsSorted(m *Module) []*Import {
+	imports := make([]*Import, 0, len(m.imports))
+	for _, i := range m.imports {
+		imports = append(imports, i)
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		return imports[i].moduleName < imports[j].moduleName
+	})
+	return imports
+}
+
+func (b *stmtBuilder) importStmt(s *stmt, i *Import) {
+	x := s.id("import", i.moduleName)
+	x.id("prefix", i.prefix)
+	if i.rev != nil {
+		x.id("revision-date", i.rev.ident)
+	}
+	b.describe(x, i)
+	b.extensions(x, i)
+}
+
+func (b *stmtBuilder) extensionDef(s *stmt, e *ExtensionDef) {
+	x := s.id("extension", e.ident)
+	for _, arg := range e.args {
+		a := x.id("argument", arg.ident)
+		if arg.yinElement {
+			a.id("yin-element", "true")
+		}
+		b.describe(a, arg)
+		b.extensions(a, arg)
+	}
+	b.status(x, e.status)
+	b.describe(x, e)
+	b.extensions(x, e)
+}
+
+func (b *stmtBuilder) typedefs(s *stmt, typedefs map[string]*Typedef) {
+	if b.resolved {
+		// all types are expanded to built-in types
+		return
+	}
+	idents := make([]string, 0, len(typedefs))
+	for ident := range typedefs {
+		idents = append(idents, ident)
+	}
+	sort.Strings(idents)
+	for _, ident := range idents {
+		t := typedefs[ident]
+		x := s.id("typedef", t.ident)
+		b.typeStmt(x, t.dtype)
+		x.optStr("units", t.units)
+		b.defaults(x, t.defaultVal)
+		b.describe(x, t)
+		b.extensions(x, t)
+	}
+}
+
+func (b *stmtBuilder) groupings(s *stmt, groupings map[string]*Grouping) {
+	if b.resolved {
+		// all uses are expanded
+		return
+	}
+	idents := make([]string, 0, len(groupings))
+	for ident := range groupings {
+		idents = append(idents, ident)
+	}
+	sort.Strings(idents)
+	for _, ident := range idents {
+		g := groupings[ident]
+		x := s.id("grouping", g.ident)
+		b.describe(x, g)
+		b.typedefs(x, g.typedefs)
+		b.groupings(x, g.groupings)
+		b.dataDefs(x, g)
+		b.actions(x, "action", g.actions)
+		b.notifications(x, g.notifications)
+		b.extensions(x, g)
+	}
+}
+
+func (b *stmtBuilder) actions(s *stmt, keyword string, actions map[string]*Rpc) {
+	idents := make([]string, 0, len(actions))
+	for ident := range actions {
+		idents = append(idents, ident)
+	}
+	sort.Strings(idents)
+	for _, ident := range idents {
+		a := actions[ident]
+		x := s.id(keyword, a.ident)
+		b.ifFeatures(x, a)
+		b.describe(x, a)
+		b.typedefs(x, a.typedefs)
+		b.groupings(x, a.groupings)
+		if a.input != nil {
+			in := x.noArg("input")
+			b.musts(in, a.input)
+			b.typedefs(in, a.input.typedefs)
+			b.groupings(in, a.input.groupings)
+			b.dataDefs(in, a.input)
+			b.extensions(in, a.input)
+		}
+		if a.output != nil {
+			out := x.noArg("output")
+			b.musts(out, a.output)
+			b.typedefs(out, a.output.typedefs)
+			b.groupings(out, a.output.groupings)
+			b.dataDefs(out, a.output)
+			b.extensions(out, a.output)
+		}
+		b.extensions(x, a)
+	}
+}
+
+func (b *stmtBuilder) notifications(s *stmt, notifs map[string]*Notification) {
+	idents := make([]string, 0, len(notifs))
+	for ident := range notifs {
+		idents = append(idents, ident)
+	}
+	sort.Strings(idents)
+	for _, ident := range idents {
+		n := notifs[ident]
+		x := s.id("notification", n.ident)
+		b.ifFeatures(x, n)
+		b.describe(x, n)
+		b.typedefs(x, n.typedefs)
+		b.groupings(x, n.groupings)
+		b.dataDefs(x, n)
+		b.extensions(x, n)
+	}
+}
+
+func (b *stmtBuilder) dataDefs(s *stmt, parent HasDataDefinitions) {
+	if parent.IsRecursive() && b.resolved {
+		s.id("//", "recursive, see "+SchemaPath(parent))
+		return
+	}
+	for _, def := range parent.DataDefinitions() {
+		b.dataDef(s, def)
+	}
+}
+
+func (b *stmtBuilder) dataDef(s *stmt, def Definition) {
+	switch x := def.(type) {
+	case *Container:
+		c := s.id("container", x.ident)
+		b.when(c, x)
+		b.ifFeatures(c, x)
+		b.musts(c, x)
+		c.optStr("presence", x.presence)
+		b.config(c, x)
+		b.status(c, x.status)
+		b.describe(c, x)
+		b.typedefs(c, x.typedefs)
+		b.groupings(c, x.groupings)
+		b.dataDefs(c, x)
+		b.actions(c, "action", x.actions)
+		b.notifications(c, x.notifications)
+		b.extensions(c, x)
+	case *List:
+		l := s.id("list", x.ident)
+		b.when(l, x)
+		b.ifFeatures(l, x)
+		b.musts(l, x)
+		if len(x.key) > 0 {
+			l.str("key", strings.Join(x.key, " "))
+		}
+		for _, u := range x.unique {
+			l.str("unique", strings.Join(u, " "))
+		}
+		b.config(l, x)
+		b.minMax(l, x)
+		b.orderedBy(l, x.orderedBy)
+		b.describe(l, x)
+		b.typedefs(l, x.typedefs)
+		b.groupings(l, x.groupings)
+		b.dataDefs(l, x)
+		b.actions(l, "action", x.actions)
+		b.notifications(l, x.notifications)
+		b.extensions(l, x)
+	case *Leaf:
+		l := s.id("leaf", x.ident)
+		b.when(l, x)
+		b.ifFeatures(l, x)
+		b.typeStmt(l, x.dtype)
+		l.optStr("units", x.units)
+		b.musts(l, x)
+		b.defaults(l, x.defaultVal)
+		b.config(l, x)
+		b.mandatory(l, x)
+		b.describe(l, x)
+		b.extensions(l, x)
+	case *LeafList:
+		l := s.id("leaf-list", x.ident)
+		b.when(l, x)
+		b.ifFeatures(l, x)
+		b.typeStmt(l, x.dtype)
+		l.optStr("units", x.units)
+		b.musts(l, x)
+		b.defaults(l, x.defaultVal)
+		b.config(l, x)
+		b.minMax(l, x)
+		b.orderedBy(l, x.orderedBy)
+		b.describe(l, x)
+		b.extensions(l, x)
+	case *Any:
+		a := s.id("anydata", x.ident)
+		b.when(a, x)
+		b.ifFeatures(a, x)
+		b.musts(a, x)
+		b.config(a, x)
+		b.mandatory(a, x)
+		b.describe(a, x)
+		b.extensions(a, x)
+	case *Choice:
+		c := s.id("choice", x.ident)
+		b.when(c, x)
+		b.ifFeatures(c, x)
+		if x.defaultVal != nil {
+			c.id("default", fmt.Sprint(x.defaultVal))
+		}
+		b.config(c, x)
+		b.mandatory(c, x)
+		b.status(c, x.status)
+		b.describe(c, x)
+		for _, ident := range x.CaseIdents() {
+			kase := x.cases[ident]
+			k := c.id("case", kase.ident)
+			b.when(k, kase)
+			b.ifFeatures(k, kase)
+			b.describe(k, kase)
+			b.dataDefs(k, kase)
+			b.extensions(k, kase)
+		}
+		b.extensions(c, x)
+	case *Uses:
+		u := s.id("uses", x.ident)
+		b.when(u, x)
+		b.ifFeatures(u, x)
+		b.describe(u, x)
+		for _, r := range x.refines {
+			b.refine(u, r)
+		}
+		for _, a := range x.augments {
+			b.augment(u, a)
+		}
+		b.extensions(u, x)
+	}
+}
+
+func (b *stmtBuilder) refine(s *stmt, r *Refine) {
+	x := s.str("refine", r.ident)
+	b.ifFeatures(x, r)
+	b.musts(x, r)
+	x.optStr("presence", r.presence)
+	b.defaults(x, r.defaultVal)
+	if r.configPtr != nil {
+		x.id("config", strconv.FormatBool(*r.configPtr))
+	}
+	b.mandatory(x, r)
+	b.minMax(x, r)
+	b.describe(x, r)
+	b.extensions(x, r)
+}
+
+func (b *stmtBuilder) augment(s *stmt, a *Augment) {
+	x := s.str("augment", a.ident)
+	b.when(x, a)
+	b.ifFeatures(x, a)
+	b.describe(x, a)
+	b.dataDefs(x, a)
+	b.actions(x, "action", a.actions)
+	b.notifications(x, a.notifications)
+	b.extensions(x, a)
+}
+
+func (b *stmtBuilder) deviation(s *stmt, d *Deviation) {
+	x := s.str("deviation", d.ident)
+	b.describe(x, d)
+	if d.NotSupported {
+		x.id("deviate", "not-supported")
+	}
+	if add := d.Add; add != nil {
+		a := x.id("deviate", "add")
+		a.optStr("units", add.units)
+		for _, m := range add.musts {
+			b.must(a, m)
+		}
+		for _, u := range add.unique {
+			a.str("unique", strings.Join(u, " "))
+		}
+		b.defaults(a, add.defaultVal)
+		b.configPtr(a, add.configPtr)
+		b.mandatoryPtr(a, add.mandatoryPtr)
+		b.minMaxPtrs(a, add.minElementsPtr, add.maxElementsPtr, nil)
+		b.extensions(a, add)
+	}
+	if replace := d.Replace; replace != nil {
+		r := x.id("deviate", "replace")
+		if replace.dtype != nil {
+			b.typeStmt(r, replace.dtype)
+		}
+		r.optStr("units", replace.units)
+		b.defaults(r, replace.defaultVal)
+		b.configPtr(r, replace.configPtr)
+		b.mandatoryPtr(r, replace.mandatoryPtr)
+		b.minMaxPtrs(r, replace.minElementsPtr, replace.maxElementsPtr, nil)
+		b.extensions(r, replace)
+	}
+	if del := d.Delete; del != nil {
+		r := x.id("deviate", "delete")
+		r.optStr("units", del.units)
+		for _, m := range del.musts {
+			b.must(r, m)
+		}
+		for _, u := range del.unique {
+			r.str("unique", strings.Join(u, " "))
+		}
+		b.defaults(r, del.defaultVal)
+		b.extensions(r, del)
+	}
+	b.extensions(x, d)
+}
+
+func (b *stmtBuilder) typeStmt(s *stmt, t *Type) {
+	if t == nil {
+		return
+	}
+	ident := t.ident
+	if b.resolved && t.format != 0 {
+		ident = t.format.Single().String()
+	}
+	x := s.id("type", ident)
+	if t.fractionDigits != 0 {
+		x.id("fraction-digits", strconv.Itoa(t.fractionDigits))
+	}
+	// derived types accumulate the ranges of the types they derive from. The
+	// first is the most restrictive
+	if len(t.ranges) > 0 {
+		b.rangeStmt(x, "range", t.ranges[0])
+	}
+	if len(t.lengths) > 0 {
+		b.rangeStmt(x, "length", t.lengths[0])
+	}
+	for _, p := range t.patterns {
+		px := x.str("pattern", p.Pattern)
+		b.errorDetails(px, p.errorMessage, p.errorAppTag)
+		b.describe(px, p)
+		b.extensions(px, p)
+	}
+	for _, e := range t.enums {
+		ex := x.name("enum", e.ident)
+		if e.val != 0 || b.resolved {
+			ex.id("value", strconv.Itoa(e.val))
+		}
+		b.describe(ex, e)
+		b.extensions(ex, e)
+	}
+	for _, bit := range t.bits {
+		bx := x.name("bit", bit.ident)
+		if bit.Position != 0 || b.resolved {
+			bx.id("position", strconv.Itoa(bit.Position))
+		}
+		b.describe(bx, bit)
+		b.extensions(bx, bit)
+	}
+	x.optStr("path", t.path)
+	if t.requireInstance {
+		x.id("require-instance", "true")
+	}
+	if b.resolved && t.identity != nil {
+		x.id("base", b.qualify(t.identity.parent, t.identity.ident))
+	} else if t.base != "" {
+		x.id("base", t.base)
+	}
+	for _, u := range t.unionTypes {
+		b.typeStmt(x, u)
+	}
+	b.describe(x, t)
+	b.extensions(x, t)
+}
+
+// qualify is the identifier with the prefix module is known by in module being
+// written
+func (b *stmtBuilder) qualify(m *Module, ident string) string {
+	if m == nil || m == b.module {
+		return ident
+	}
+	for _, i := range b.module.imports {
+		if i.module == m {
+			return i.prefix + ":" + ident
+		}
+	}
+	return m.prefix + ":" + ident
+}
+
+func (b *stmtBuilder) rangeStmt(s *stmt, keyword string, r *Range) {
+	arg := r.Max
+	if r.Min != "" {
+		arg = r.Min + ".." + r.Max
+	}
+	x := s.str(keyword, arg)
+	b.errorDetails(x, r.errorMessage, r.errorAppTag)
+	b.describe(x, r)
+	b.extensions(x, r)
+}
+
+func (b *stmtBuilder) errorDetails(s *stmt, msg string, appTag string) {
+	s.optStr("error-message", msg)
+	s.optStr("error-app-tag", appTag)
+}
+
+func (b *stmtBuilder) defaults(s *stmt, v interface{}) {
+	switch x := v.(type) {
+	case nil:
+	case []string:
+		for _, item := range x {
+			s.str("default", item)
+		}
+	case []interface{}:
+		for _, item := range x {
+			s.str("default", fmt.Sprint(item))
+		}
+	default:
+		s.str("default", fmt.Sprint(x))
+	}
+}
+
+func (b *stmtBuilder) when(s *stmt, m HasWhen) {
+	if w := m.When(); w != nil {
+		x := s.str("when", w.expr)
+		b.describe(x, w)
+		b.extensions(x, w)
+	}
+}
+
+func (b *stmtBuilder) ifFeatures(s *stmt, m HasIfFeatures) {
+	if b.resolved {
+		// features have already been evaluated
+		return
+	}
+	for _, f := range m.IfFeatures() {
+		x := s.str("if-feature", f.expr)
+		b.extensions(x, f)
+	}
+}
+
+func (b *stmtBuilder) musts(s *stmt, m HasMusts) {
+	for _, must := range m.Musts() {
+		b.must(s, must)
+	}
+}
+
+func (b *stmtBuilder) must(s *stmt, m *Must) {
+	x := s.str("must", m.expr)
+	b.errorDetails(x, m.errorMessage, m.errorAppTag)
+	b.describe(x, m)
+	b.extensions(x, m)
+}
+
+func (b *stmtBuilder) config(s *stmt, m HasDetails) {
+	if !m.IsConfigSet() {
+		return
+	}
+	if b.resolved {
+		// compiling sets config on everything so only write where it changes
+		// from parent
+		parentConfig := true
+		for p := m.Parent(); p != nil; p = p.Parent() {
+			if x, valid := p.(HasConfig); valid && x.IsConfigSet() {
+				parentConfig = x.Config()
+				break
+			}
+		}
+		if parentConfig == m.Config() {
+			return
+		}
+	}
+	s.id("config", strconv.FormatBool(m.Config()))
+}
+
+func (b *stmtBuilder) configPtr(s *stmt, c *bool) {
+	if c != nil {
+		s.id("config", strconv.FormatBool(*c))
+	}
+}
+
+func (b *stmtBuilder) mandatory(s *stmt, m HasMandatory) {
+	if m.IsMandatorySet() {
+		s.id("mandatory", strconv.FormatBool(m.Mandatory()))
+	}
+}
+
+func (b *stmtBuilder) mandatoryPtr(s *stmt, m *bool) {
+	if m != nil {
+		s.id("mandatory", strconv.FormatBool(*m))
+	}
+}
+
+func (b *stmtBuilder) minMax(s *stmt, m interface{}) {
+	var min, max *int
+	var unbounded *bool
+	switch x := m.(type) {
+	case *List:
+		min, max, unbounded = x.minElementsPtr, x.maxElementsPtr, x.unboundedPtr
+	case *LeafList:
+		min, max, unbounded = x.minElementsPtr, x.maxElementsPtr, x.unboundedPtr
+	case *Refine:
+		min, max, unbounded = x.minElementsPtr, x.maxElementsPtr, x.unboundedPtr
+	}
+	b.minMaxPtrs(s, min, max, unbounded)
+}
+
+func (b *stmtBuilder) minMaxPtrs(s *stmt, min *int, max *int, unbounded *bool) {
+	if min != nil {
+		s.id("min-elements", strconv.Itoa(*min))
+	}
+	if unbounded != nil && *unbounded {
+		s.id("max-elements", "unbounded")
+	} else if max != nil {
+		s.id("max-elements", strconv.Itoa(*max))
+	}
+}
+
+func (b *stmtBuilder) orderedBy(s *stmt, o OrderedBy) {
+	if o == OrderedByUser {
+		s.id("ordered-by", "user")
+	}
+}
+
+func (b *stmtBuilder) status(s *stmt, status Status) {
+	switch status {
+	case Deprecated:
+		s.id("status", "deprecated")
+	case Obsolete:
+		s.id("status", "obsolete")
+	}
+}
+
+func (b *stmtBuilder) describe(s *stmt, m interface{}) {
+	if d, valid := m.(Describable); valid {
+		s.optStr("description", d.Description())
+		s.optStr("reference", d.Reference())
+	}
+}
+
+// extensions are added to the statement they were found on which is either
+// the definition itself or one of it's substatements like description.
+func (b *stmtBuilder) extensions(s *stmt, m HasExtensions) {
+	for _, e := range m.Extensions() {
+		target := s
+		if e.keyword != "" {
+			for _, sub := range s.subs {
+				if sub.keyword == e.keyword {
+					target = sub
+					break
+				}
+			}
+		}
+		b.extension(target, e)
+	}
+}
+
+func (b *stmtBuilder) extension(s *stmt, e *Extension) {
+	keyword := e.prefix + ":" + e.ident
+	var x *stmt
+	if len(e.args) > 0 {
+		x = s.str(keyword, strings.Join(e.args, " "))
+	} else {
+		x = s.noArg(keyword)
+	}
+	x.ext = e
+	b.extensions(x, e)
+}
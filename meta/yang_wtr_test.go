@@ -0,0 +1,91 @@
+package meta_test
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+var updateFlag = flag.Bool("update", false, "update golden files instead of verifying against them")
+
+func TestYangWtr(t *testing.T) {
+	ypath := source.Dir("./testdata")
+	features := meta.FeaturesOff([]string{"blacklisted"})
+	tests := []struct {
+		resolved bool
+		gold     string
+	}{
+		{resolved: false, gold: "testdata/gold/wtr.yang"},
+		{resolved: true, gold: "testdata/gold/wtr-resolved.yang"},
+	}
+	for _, test := range tests {
+		t.Log(test.gold)
+		options := parser.Options{Features: features, Uncompiled: !test.resolved}
+		m, err := parser.LoadModuleWithOptions(ypath, "wtr", options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual := writeYang(t, m, test.resolved)
+		fc.Gold(t, *updateFlag, []byte(actual), test.gold)
+
+		// what we write, we should be able to read back in and get the same
+		again, err := parser.LoadModuleFromStringWithOptions(ypath, actual, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fc.AssertEqual(t, actual, writeYang(t, again, test.resolved))
+	}
+}
+
+func writeYang(t *testing.T, m *meta.Module, resolved bool) string {
+	var actual bytes.Buffer
+	w := &meta.YangWtr{Out: &actual, Resolved: resolved}
+	if err := w.Write(m); err != nil {
+		t.Fatal(err)
+	}
+	return actual.String()
+}
+
+func TestYangWtrQuotes(t *testing.T) {
+	m, err := parser.LoadModuleFromString(nil, `module x {
+		namespace "";
+		prefix "";
+		revision 0;
+		leaf a {
+			description "it's \"x\" done";
+			type string;
+		}
+		leaf b {
+			description 'say "hi" to c:\dir';
+			type string;
+		}
+		leaf c {
+			description "it's \"c:\\dir\"";
+			type string;
+		}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"a": `it's "x" done`,
+		"b": `say "hi" to c:\dir`,
+		"c": `it's "c:\dir"`,
+	}
+	assertDescriptions := func(m *meta.Module) {
+		for ident, desc := range expected {
+			fc.AssertEqual(t, desc, meta.Find(m, ident).(meta.Describable).Description())
+		}
+	}
+	assertDescriptions(m)
+	again, err := parser.LoadModuleFromString(nil, writeYang(t, m, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertDescriptions(again)
+}
@@ -0,0 +1,219 @@
+package meta
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// YinNamespace is the XML namespace of all YANG statements in YIN format
+const YinNamespace = "urn:ietf:params:xml:ns:yang:yin:1"
+
+type yinArg struct {
+	name    string
+	element bool
+}
+
+// yinArgs is the mapping of YANG statements to YIN from RFC7950 Section 13.1
+var yinArgs = map[string]yinArg{
+	"action":           {"name", false},
+	"anydata":          {"name", false},
+	"anyxml":           {"name", false},
+	"argument":         {"name", false},
+	"augment":          {"target-node", false},
+	"base":             {"name", false},
+	"belongs-to":       {"module", false},
+	"bit":              {"name", false},
+	"case":             {"name", false},
+	"choice":           {"name", false},
+	"config":           {"value", false},
+	"contact":          {"text", true},
+	"container":        {"name", false},
+	"default":          {"value", false},
+	"description":      {"text", true},
+	"deviate":          {"value", false},
+	"deviation":        {"target-node", false},
+	"enum":             {"name", false},
+	"error-app-tag":    {"value", false},
+	"error-message":    {"value", true},
+	"extension":        {"name", false},
+	"feature":          {"name", false},
+	"fraction-digits":  {"value", false},
+	"grouping":         {"name", false},
+	"identity":         {"name", false},
+	"if-feature":       {"name", false},
+	"import":           {"module", false},
+	"include":          {"module", false},
+	"input":            {"", false},
+	"key":              {"value", false},
+	"leaf":             {"name", false},
+	"leaf-list":        {"name", false},
+	"length":           {"value", false},
+	"list":             {"name", false},
+	"mandatory":        {"value", false},
+	"max-elements":     {"value", false},
+	"min-elements":     {"value", false},
+	"modifier":         {"value", false},
+	"module":           {"name", false},
+	"must":             {"condition", false},
+	"namespace":        {"uri", false},
+	"notification":     {"name", false},
+	"ordered-by":       {"value", false},
+	"organization":     {"text", true},
+	"output":           {"", false},
+	"path":             {"value", false},
+	"pattern":          {"value", false},
+	"position":         {"value", false},
+	"prefix":           {"value", false},
+	"presence":         {"value", false},
+	"range":            {"value", false},
+	"reference":        {"text", true},
+	"refine":           {"target-node", false},
+	"require-instance": {"value", false},
+	"revision":         {"date", false},
+	"revision-date":    {"date", false},
+	"rpc":              {"name", false},
+	"status":           {"value", false},
+	"submodule":        {"name", false},
+	"type":             {"name", false},
+	"typedef":          {"name", false},
+	"unique":           {"tag", false},
+	"units":            {"name", false},
+	"uses":             {"name", false},
+	"value":            {"value", false},
+	"when":             {"condition", false},
+	"yang-version":     {"value", false},
+	"yin-element":      {"value", false},
+}
+
+// YinArgument is how the argument of a YANG statement is written in YIN.
+// Argument is either an XML attribute or, when element is true, a child
+// element with the argument as it's text.  Name is empty for statements that
+// have no argument like input and output.
+func YinArgument(keyword string) (name string, element bool) {
+	a := yinArgs[keyword]
+	return a.name, a.element
+}
+
+// YinWtr writes a module as YIN, the XML form of YANG described in RFC7950
+// Section 13. Same as YangWtr, module can be written in it's original or
+// resolved form.
+//
+// Extensions are written in the namespace of the module that defines them.
+// Namespaces of imported modules are only known once module is compiled so
+// for uncompiled modules the name of the imported module is used instead.
+type YinWtr struct {
+	Out io.Writer
+
+	// Resolved writes module in it's resolved form. Module must be compiled.
+	Resolved bool
+
+	// Indent for each level, default is 2 spaces
+	Indent string
+}
+
+// WriteYin writes original form of module to a string
+func WriteYin(m *Module) (string, error) {
+	var buf bytes.Buffer
+	w := &YinWtr{Out: &buf}
+	err := w.Write(m)
+	return buf.String(), err
+}
+
+// Write module to stream
+func (w *YinWtr) Write(m *Module) error {
+	b := &stmtBuilder{module: m, resolved: w.Resolved}
+	out := bufio.NewWriter(w.Out)
+	indent := w.Indent
+	if indent == "" {
+		indent = "  "
+	}
+	out.WriteString(xml.Header)
+	w.write(out, m, b.moduleStmt(m), "", indent, w.namespaces(m))
+	return out.Flush()
+}
+
+// namespaces declares a prefix for every module an extension might come from
+func (w *YinWtr) namespaces(m *Module) []string {
+	decls := []string{
+		w.attr("xmlns", YinNamespace),
+		w.attr("xmlns:"+m.prefix, m.namespace),
+	}
+	b := &stmtBuilder{module: m}
+	for _, i := range b.importsSorted(m) {
+		ns := i.moduleName
+		if i.module != nil {
+			ns = i.module.namespace
+		}
+		decls = append(decls, w.attr("xmlns:"+i.prefix, ns))
+	}
+	return decls
+}
+
+func (w *YinWtr) write(out *bufio.Writer, m *Module, s *stmt, prefix string, indent string, xmlns []string) {
+	if s.keyword == "//" {
+		out.WriteString(prefix + "<!-- " + strings.Replace(s.arg, "--", "- -", -1) + " -->\n")
+		return
+	}
+	out.WriteString(prefix + "<" + s.keyword)
+	var argElem string
+	if s.hasArg {
+		name, element := w.argument(m, s)
+		if element {
+			argElem = name
+		} else {
+			out.WriteString(" " + w.attr(name, s.arg))
+		}
+	}
+	for _, ns := range xmlns {
+		out.WriteString(" " + ns)
+	}
+	if len(s.subs) == 0 && argElem == "" {
+		out.WriteString("/>\n")
+		return
+	}
+	out.WriteString(">\n")
+	if argElem != "" {
+		out.WriteString(prefix + indent + "<" + argElem + ">")
+		yinText.WriteString(out, s.arg)
+		out.WriteString("</" + argElem + ">\n")
+	}
+	for _, sub := range s.subs {
+		w.write(out, m, sub, prefix+indent, indent, nil)
+	}
+	out.WriteString(prefix + "</" + s.keyword + ">\n")
+}
+
+// argument finds how the argument of statement is written.  Extensions
+// argument names come from their definition.
+func (w *YinWtr) argument(m *Module, s *stmt) (string, bool) {
+	if s.ext == nil {
+		return YinArgument(s.keyword)
+	}
+	def := s.ext.def
+	if def == nil && s.ext.prefix == m.prefix {
+		def = m.extensionDefs[s.ext.ident]
+	}
+	if def == nil || len(def.args) == 0 {
+		return "value", false
+	}
+	arg := def.args[0]
+	if arg.yinElement {
+		// element is in namespace of extension, not YIN
+		return s.ext.prefix + ":" + arg.ident, true
+	}
+	return arg.ident, false
+}
+
+// yinText keeps line breaks in text elements unlike xml.EscapeText
+var yinText = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// attr escapes line breaks too because XML parsers replace them with spaces
+// in attributes
+func (w *YinWtr) attr(name string, value string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(value))
+	return name + `="` + buf.String() + `"`
+}
@@ -0,0 +1,44 @@
+package meta_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+func TestYinWtr(t *testing.T) {
+	ypath := source.Dir("./testdata")
+	features := meta.FeaturesOff([]string{"blacklisted"})
+	tests := []struct {
+		resolved bool
+		gold     string
+	}{
+		{resolved: false, gold: "testdata/gold/wtr.yin"},
+		{resolved: true, gold: "testdata/gold/wtr-resolved.yin"},
+	}
+	for _, test := range tests {
+		t.Log(test.gold)
+		options := parser.Options{Features: features, Uncompiled: !test.resolved}
+		m, err := parser.LoadModuleWithOptions(ypath, "wtr", options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var actual bytes.Buffer
+		w := &meta.YinWtr{Out: &actual, Resolved: test.resolved}
+		if err := w.Write(m); err != nil {
+			t.Fatal(err)
+		}
+		fc.Gold(t, *updateFlag, actual.Bytes(), test.gold)
+
+		// YIN should read back in as the same module as the YANG
+		again, err := parser.LoadModuleFromStringWithOptions(ypath, actual.String(), options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fc.AssertEqual(t, writeYang(t, m, test.resolved), writeYang(t, again, test.resolved))
+	}
+}
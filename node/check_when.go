@@ -31,7 +31,12 @@ func (y CheckWhen) check(s Selection, m meta.Meta) (bool, error) {
 			if err != nil {
 				return false, err
 			}
-			proceed, err := s.XPredicate(xp)
+			// prefixes are as the module with the when statement knows them
+			var module *meta.Module
+			if d, valid := hw.When().Parent().(meta.Definition); valid {
+				module = meta.OriginalModule(d)
+			}
+			proceed, err := s.xpredicate(xp, module)
 			return proceed, err
 		}
 	}
@@ -0,0 +1,127 @@
+package node
+
+import (
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/val"
+)
+
+// ExtensionFieldHandler is a meta.ExtensionHandler that changes values of the
+// leafs the extension is on.  Called after a node read a value or before a
+// value is written to a node so nodes do not have to check for extensions
+// themselves. Use r.Write to know which.
+type ExtensionFieldHandler interface {
+	meta.ExtensionHandler
+	OnField(e *meta.Extension, r FieldRequest, hnd *ValueHandle) error
+}
+
+// ExtensionChildHandler is a meta.ExtensionHandler for containers and lists
+// the extension is on.  Called after node selected child so handler can
+// inspect child or skip it by returning false. Use r.New to know if child
+// is being created.
+type ExtensionChildHandler interface {
+	meta.ExtensionHandler
+	OnChild(e *meta.Extension, r ChildRequest, child Selection) (bool, error)
+}
+
+// ExtensionListItemHandler is a meta.ExtensionHandler for items of lists the
+// extension is on.  Called after node selected item so handler can inspect
+// item. Use item.Key() for key of item.
+type ExtensionListItemHandler interface {
+	meta.ExtensionHandler
+	OnListItem(e *meta.Extension, r ListRequest, item Selection) error
+}
+
+// ExtensionActionHandler is a meta.ExtensionHandler for rpcs and actions the
+// extension is on.  Called before node is asked to run action so handler can
+// check input or stop action by returning false.
+type ExtensionActionHandler interface {
+	meta.ExtensionHandler
+	OnAction(e *meta.Extension, r *ActionRequest) (bool, error)
+}
+
+// ExtensionNotifyHandler is a meta.ExtensionHandler for notifications the
+// extension is on.  Called for each message before subscriber gets it so
+// handler can inspect message or drop it by returning false.
+type ExtensionNotifyHandler interface {
+	meta.ExtensionHandler
+	OnNotify(e *meta.Extension, msg Selection) (bool, error)
+}
+
+// ExtensionConstraint calls extension handlers registered when module was
+// compiled.  Every browser has this constraint.
+type ExtensionConstraint struct{}
+
+func (ExtensionConstraint) CheckFieldPreConstraints(r *FieldRequest, hnd *ValueHandle) (bool, error) {
+	if !r.Write {
+		return true, nil
+	}
+	return true, fieldExtensions(*r, hnd)
+}
+
+func (ExtensionConstraint) CheckFieldPostConstraints(r FieldRequest, hnd *ValueHandle) (bool, error) {
+	if r.Write {
+		return true, nil
+	}
+	return true, fieldExtensions(r, hnd)
+}
+
+func fieldExtensions(r FieldRequest, hnd *ValueHandle) error {
+	for _, e := range r.Meta.Extensions() {
+		if h, valid := e.Handler().(ExtensionFieldHandler); valid {
+			if err := h.OnField(e, r, hnd); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (ExtensionConstraint) CheckContainerPostConstraints(r ChildRequest, child Selection) (bool, error) {
+	if child.IsNil() {
+		return true, nil
+	}
+	for _, e := range r.Meta.Extensions() {
+		if h, valid := e.Handler().(ExtensionChildHandler); valid {
+			if more, err := h.OnChild(e, r, child); !more || err != nil {
+				return more, err
+			}
+		}
+	}
+	return true, nil
+}
+
+func (ExtensionConstraint) CheckListPostConstraints(r ListRequest, child Selection, key []val.Value) (bool, error) {
+	if child.IsNil() {
+		return true, nil
+	}
+	for _, e := range r.Meta.Extensions() {
+		if h, valid := e.Handler().(ExtensionListItemHandler); valid {
+			if err := h.OnListItem(e, r, child); err != nil {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+func (ExtensionConstraint) CheckActionPreConstraints(r *ActionRequest) (bool, error) {
+	for _, e := range r.Meta.Extensions() {
+		if h, valid := e.Handler().(ExtensionActionHandler); valid {
+			if more, err := h.OnAction(e, r); !more || err != nil {
+				return more, err
+			}
+		}
+	}
+	return true, nil
+}
+
+func (ExtensionConstraint) CheckNotifyFilterConstraints(msg Selection) (bool, error) {
+	for _, e := range msg.Meta().Extensions() {
+		if h, valid := e.Handler().(ExtensionNotifyHandler); valid {
+			if more, err := h.OnNotify(e, msg); !more || err != nil {
+				return more, err
+			}
+		}
+	}
+	return true, nil
+}
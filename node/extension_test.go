@@ -0,0 +1,232 @@
+package node_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/nodeutil"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/val"
+)
+
+type secretExt struct{}
+
+func (secretExt) Compile(parent meta.Meta, e *meta.Extension) error {
+	if _, isLeaf := parent.(meta.Leafable); !isLeaf {
+		return errors.New("only allowed on leafs")
+	}
+	return nil
+}
+
+func (secretExt) OnField(e *meta.Extension, r node.FieldRequest, hnd *node.ValueHandle) error {
+	if !r.Write && hnd.Val != nil {
+		hnd.Val = val.String("****")
+	}
+	return nil
+}
+
+type unitsExt struct{}
+
+func (unitsExt) Compile(parent meta.Meta, e *meta.Extension) error {
+	if len(e.Arguments()) != 1 {
+		return errors.New("requires factor")
+	}
+	_, err := strconv.ParseFloat(e.Arguments()[0], 64)
+	return err
+}
+
+func (unitsExt) OnField(e *meta.Extension, r node.FieldRequest, hnd *node.ValueHandle) error {
+	if hnd.Val == nil {
+		return nil
+	}
+	factor, _ := strconv.ParseFloat(e.Arguments()[0], 64)
+	if r.Write {
+		factor = 1 / factor
+	}
+	var err error
+	hnd.Val, err = val.Conv(val.FmtDecimal64, hnd.Val.(val.Decimal64).Float64()*factor)
+	return err
+}
+
+func TestExtensionHandlers(t *testing.T) {
+	mstr := `module x {
+		prefix "x";
+		namespace "x";
+		revision 0;
+		extension secret;
+		extension units-conversion {
+			argument "factor";
+		}
+		leaf password {
+			type string;
+			x:secret;
+		}
+		leaf speed {
+			type decimal64;
+			x:units-conversion "2";
+		}
+	}`
+	opts := parser.Options{
+		Extensions: meta.ExtensionHandlers{
+			"x:secret":           secretExt{},
+			"x:units-conversion": unitsExt{},
+		},
+	}
+	m, err := parser.LoadModuleFromStringWithOptions(nil, mstr, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]interface{}{
+		"password": "hello",
+		"speed":    10.0,
+	}
+	b := node.NewBrowser(m, nodeutil.ReflectChild(data))
+	actual, err := nodeutil.WriteJSON(b.Root())
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, `{"password":"****","speed":20.0}`, actual)
+
+	err = b.Root().UpsertFrom(nodeutil.ReadJSON(`{"password":"bye","speed":50}`)).LastErr
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, "bye", data["password"])
+	fc.AssertEqual(t, val.Decimal64{Mantissa: 25}, data["speed"])
+
+	// compile time validation
+	bad := `module x {
+		prefix "x";
+		namespace "x";
+		revision 0;
+		extension secret;
+		container c {
+			x:secret;
+		}
+	}`
+	_, err = parser.LoadModuleFromStringWithOptions(nil, bad, opts)
+	fc.AssertEqual(t, "7:4 - x/c - x:secret - only allowed on leafs", err.Error())
+}
+
+type readOnlyExt struct{}
+
+func (readOnlyExt) Compile(parent meta.Meta, e *meta.Extension) error {
+	b := &meta.Builder{}
+	b.Config(parent, false)
+	return b.LastErr
+}
+
+type auditExt struct {
+	log []string
+}
+
+func (auditExt) Compile(parent meta.Meta, e *meta.Extension) error {
+	return nil
+}
+
+func (x *auditExt) OnChild(e *meta.Extension, r node.ChildRequest, child node.Selection) (bool, error) {
+	x.log = append(x.log, "child "+r.Meta.Ident())
+	return r.Meta.Ident() != "hidden", nil
+}
+
+func (x *auditExt) OnListItem(e *meta.Extension, r node.ListRequest, item node.Selection) error {
+	x.log = append(x.log, "item "+item.Key()[0].String())
+	return nil
+}
+
+func (x *auditExt) OnAction(e *meta.Extension, r *node.ActionRequest) (bool, error) {
+	x.log = append(x.log, "action "+r.Meta.Ident())
+	return true, nil
+}
+
+func (x *auditExt) OnNotify(e *meta.Extension, msg node.Selection) (bool, error) {
+	x.log = append(x.log, "notify "+msg.Meta().Ident())
+	return false, nil
+}
+
+func TestExtensionHandlersOnAllNodes(t *testing.T) {
+	mstr := `module x {
+		prefix "x";
+		namespace "x";
+		revision 0;
+		extension read-only;
+		extension audit;
+		container c {
+			x:read-only;
+			x:audit;
+			leaf a {
+				type string;
+			}
+		}
+		container hidden {
+			x:audit;
+			leaf b {
+				type string;
+			}
+		}
+		list l {
+			key "id";
+			x:audit;
+			leaf id {
+				type string;
+			}
+		}
+		rpc r {
+			x:audit;
+		}
+		notification n {
+			x:audit;
+		}
+	}`
+	audit := &auditExt{}
+	opts := parser.Options{
+		Extensions: meta.ExtensionHandlers{
+			"x:read-only": readOnlyExt{},
+			"x:audit":     audit,
+		},
+	}
+	m, err := parser.LoadModuleFromStringWithOptions(nil, mstr, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// changes from compile handlers are compiled
+	c := meta.Find(m, "c").(*meta.Container)
+	fc.AssertEqual(t, false, c.Config())
+	fc.AssertEqual(t, false, meta.Find(c, "a").(*meta.Leaf).Config())
+
+	data := map[string]interface{}{
+		"c":      map[string]interface{}{"a": "x"},
+		"hidden": map[string]interface{}{"b": "y"},
+		"l": []map[string]interface{}{
+			{"id": "one"},
+		},
+	}
+	b := node.NewBrowser(m, nodeutil.ReflectChild(data))
+	actual, err := nodeutil.WriteJSON(b.Root())
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, `{"c":{"a":"x"},"l":[{"id":"one"}]}`, actual)
+
+	var sent bool
+	b = node.NewBrowser(m, &nodeutil.Basic{
+		OnAction: func(r node.ActionRequest) (node.Node, error) {
+			return nil, nil
+		},
+		OnNotify: func(r node.NotifyRequest) (node.NotifyCloser, error) {
+			r.Send(&nodeutil.Basic{})
+			sent = true
+			return func() error { return nil }, nil
+		},
+	})
+	fc.AssertEqual(t, nil, b.Root().Find("r").Action(nil).LastErr)
+	var received bool
+	_, err = b.Root().Find("n").Notifications(func(node.Selection) {
+		received = true
+	})
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, true, sent)
+	fc.AssertEqual(t, false, received)
+
+	fc.AssertEqual(t, "child c,child hidden,child l,item one,action r,notify n", strings.Join(audit.log, ","))
+}
@@ -23,13 +23,25 @@ func (self Selection) Find(path string) Selection {
 		p = p[3:]
 	}
 	var u *url.URL
-	u, s.LastErr = url.Parse(p)
+	u, s.LastErr = parseRelativeUrl(p)
 	if s.LastErr != nil {
 		return s
 	}
 	return s.FindUrl(u)
 }
 
+// parseRelativeUrl is url.Parse except module qualified idents like "a:b" are
+// not mistaken for a url scheme
+func parseRelativeUrl(path string) (*url.URL, error) {
+	u, err := url.Parse("./" + path)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = strings.TrimPrefix(u.Path, "./")
+	u.RawPath = strings.TrimPrefix(u.RawPath, "./")
+	return u, nil
+}
+
 // FindUrl navigates to another selection with possible constraints as url parameters.  Constraints
 // are added to any existing contraints.  Original selector and constraints will remain unaltered
 func (self Selection) FindUrl(url *url.URL) Selection {
@@ -0,0 +1,71 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/val"
+)
+
+// FindIdentity is the identity ref is for among base and the identities
+// derived from it in every loaded module or nil if there is none
+func FindIdentity(base *meta.Identity, ref val.IdentRef) *meta.Identity {
+	for _, x := range base.AllDerived() {
+		if x.Ident() == ref.Label && (ref.Module == "" || identityModule(x).Ident() == ref.Module) {
+			return x
+		}
+	}
+	return nil
+}
+
+// DerivedFrom is the XPath derived-from function from RFC7950 Section 10.4.1,
+// true if identity of ref is derived from identity but is not identity itself
+func DerivedFrom(ref val.IdentRef, identity *meta.Identity) bool {
+	x := FindIdentity(identity, ref)
+	return x != nil && !sameIdentity(x, identity)
+}
+
+// DerivedFromOrSelf is the XPath derived-from-or-self function from RFC7950
+// Section 10.4.2, true if identity of ref is identity or derived from it
+func DerivedFromOrSelf(ref val.IdentRef, identity *meta.Identity) bool {
+	return FindIdentity(identity, ref) != nil
+}
+
+// resolveIdentity finds identity derived from base by its ident that may be
+// qualified with module name or prefix. Unqualified ident has to be unique.
+func resolveIdentity(base *meta.Identity, s string) (*meta.Identity, error) {
+	qualifier, ident := "", s
+	if colon := strings.IndexRune(s, ':'); colon >= 0 {
+		qualifier, ident = s[:colon], s[colon+1:]
+	}
+	var found []*meta.Identity
+	for _, x := range base.AllDerived() {
+		if x.Ident() != ident {
+			continue
+		}
+		m := identityModule(x)
+		if qualifier == "" || qualifier == m.Ident() || qualifier == m.Prefix() {
+			found = append(found, x)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("could not find identity ref %s in %s", s, base.Ident())
+	case 1:
+		return found[0], nil
+	}
+	return nil, fmt.Errorf("identity ref %s is in more than one module, qualify it with module name", s)
+}
+
+func identRef(base *meta.Identity, x *meta.Identity) val.IdentRef {
+	return val.IdentRef{Base: base.Ident(), Label: x.Ident(), Module: identityModule(x).Ident()}
+}
+
+func identityModule(x *meta.Identity) *meta.Module {
+	return x.Parent().(*meta.Module)
+}
+
+func sameIdentity(a *meta.Identity, b *meta.Identity) bool {
+	return a.Ident() == b.Ident() && identityModule(a).Ident() == identityModule(b).Ident()
+}
@@ -0,0 +1,60 @@
+package node_test
+
+import (
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/nodeutil"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+	"github.com/freeconf/yang/val"
+)
+
+func TestIdentityAcrossModules(t *testing.T) {
+	ypath := source.Dir("./testdata/identity")
+	m, err := parser.LoadModule(ypath, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pet := meta.Find(m, "pet").(meta.Leafable)
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{in: "dog", expected: `{"pet":"ext:dog"}`},
+		{in: "ext:dog", expected: `{"pet":"ext:dog"}`},
+		{in: "e:dog", expected: `{"pet":"ext:dog"}`},
+		{in: "zoo:cat", expected: `{"pet":"zoo:cat"}`},
+		{in: "ext:cat", expected: `{"pet":"ext:cat"}`},
+	}
+	for _, test := range tests {
+		v, err := node.ParseValue(pet.Type(), test.in)
+		if err != nil {
+			t.Error(test.in, err)
+			continue
+		}
+		sel := node.NewBrowser(m, nodeutil.ReflectChild(make(map[string]interface{}))).Root()
+		if err := sel.Set("pet", v.(val.IdentRef)); err != nil {
+			t.Error(test.in, err)
+			continue
+		}
+		actual, err := nodeutil.WriteJSON(sel)
+		fc.AssertEqual(t, nil, err)
+		fc.AssertEqual(t, test.expected, actual)
+	}
+	for _, bad := range []string{"cat", "bird", "x:dog", "animal:cat"} {
+		if _, err := node.ParseValue(pet.Type(), bad); err == nil {
+			t.Errorf("%s expected error", bad)
+		}
+	}
+
+	animal := pet.Type().Base()
+	dog, _ := node.ParseValue(pet.Type(), "dog")
+	fc.AssertEqual(t, true, node.DerivedFrom(dog.(val.IdentRef), animal))
+	fc.AssertEqual(t, true, node.DerivedFromOrSelf(dog.(val.IdentRef), animal))
+	self := val.IdentRef{Base: "animal", Label: "animal", Module: "zoo"}
+	fc.AssertEqual(t, false, node.DerivedFrom(self, animal))
+	fc.AssertEqual(t, true, node.DerivedFromOrSelf(self, animal))
+}
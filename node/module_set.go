@@ -0,0 +1,122 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/val"
+)
+
+// NewModuleSetBrowser is a browser on the root of a module set from
+// meta.NewModuleSet where the data of each module comes from it's own node.
+// Nodes are keyed by module name.
+func NewModuleSetBrowser(set *meta.Module, nodes map[string]Node) *Browser {
+	return NewBrowser(set, ModuleSetNode(nodes))
+}
+
+// ModuleSetNode routes requests on the root of a module set to the node of
+// the module that defines the top-level definition of the request. Edits on
+// the root are sent to every node.
+func ModuleSetNode(nodes map[string]Node) Node {
+	n := &moduleSetNode{nodes: nodes}
+	for name := range nodes {
+		n.names = append(n.names, name)
+	}
+	sort.Strings(n.names)
+	return n
+}
+
+type moduleSetNode struct {
+	nodes map[string]Node
+	names []string
+}
+
+func (n *moduleSetNode) route(m meta.Meta) (Node, error) {
+	ident := meta.NamespaceModule(m.(meta.Definition)).Ident()
+	if x, found := n.nodes[ident]; found {
+		return x, nil
+	}
+	return nil, fmt.Errorf("%w. no node for module %s", fc.NotFoundError, ident)
+}
+
+func (n *moduleSetNode) Child(r ChildRequest) (Node, error) {
+	x, err := n.route(r.Meta)
+	if err != nil {
+		return nil, err
+	}
+	return x.Child(r)
+}
+
+func (n *moduleSetNode) Next(r ListRequest) (Node, []val.Value, error) {
+	return nil, nil, fmt.Errorf("%w. module set is not a list", fc.BadRequestError)
+}
+
+func (n *moduleSetNode) Field(r FieldRequest, hnd *ValueHandle) error {
+	x, err := n.route(r.Meta)
+	if err != nil {
+		return err
+	}
+	return x.Field(r, hnd)
+}
+
+func (n *moduleSetNode) Choose(sel Selection, choice *meta.Choice) (*meta.ChoiceCase, error) {
+	x, err := n.route(choice)
+	if err != nil {
+		return nil, err
+	}
+	return x.Choose(sel, choice)
+}
+
+func (n *moduleSetNode) Action(r ActionRequest) (Node, error) {
+	x, err := n.route(r.Meta)
+	if err != nil {
+		return nil, err
+	}
+	return x.Action(r)
+}
+
+func (n *moduleSetNode) Notify(r NotifyRequest) (NotifyCloser, error) {
+	x, err := n.route(r.Meta)
+	if err != nil {
+		return nil, err
+	}
+	return x.Notify(r)
+}
+
+func (n *moduleSetNode) Delete(r NodeRequest) error {
+	return n.each(func(x Node) error {
+		return x.Delete(r)
+	})
+}
+
+func (n *moduleSetNode) BeginEdit(r NodeRequest) error {
+	return n.each(func(x Node) error {
+		return x.BeginEdit(r)
+	})
+}
+
+func (n *moduleSetNode) EndEdit(r NodeRequest) error {
+	return n.each(func(x Node) error {
+		return x.EndEdit(r)
+	})
+}
+
+func (n *moduleSetNode) each(f func(Node) error) error {
+	for _, name := range n.names {
+		if err := f(n.nodes[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *moduleSetNode) Peek(sel Selection, consumer interface{}) interface{} {
+	return nil
+}
+
+func (n *moduleSetNode) Context(sel Selection) context.Context {
+	return sel.Context
+}
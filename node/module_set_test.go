@@ -0,0 +1,78 @@
+package node_test
+
+import (
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/nodeutil"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+func TestModuleSet(t *testing.T) {
+	ypath := source.Dir("./testdata/set")
+	a, err := parser.LoadModule(ypath, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := parser.LoadModule(ypath, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, err := meta.NewModuleSet("set", a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, 2, len(set.ModuleSetMembers()))
+
+	power := `{"power":11}`
+	pong := nodeutil.ReadJSON(`{"pong":"hi"}`)
+	aNode := &nodeutil.Basic{
+		OnChild: nodeutil.ReadJSON(`{"c":{"v":"A"}}`).Child,
+		OnField: func(r node.FieldRequest, hnd *node.ValueHandle) error {
+			return nodeutil.ReadJSON(power).Field(r, hnd)
+		},
+		OnAction: func(r node.ActionRequest) (node.Node, error) {
+			return pong, nil
+		},
+	}
+	bNode := nodeutil.ReadJSON(`{"c":{"w":"B","level":11},"light":"red","shade":"dark"}`)
+	brwsr := node.NewModuleSetBrowser(set, map[string]node.Node{
+		"a": aNode,
+		"b": bNode,
+	})
+	root := brwsr.Root()
+
+	actual, err := nodeutil.WriteJSON(root.Find("a:c"))
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, `{"v":"A"}`, actual)
+
+	actual, err = nodeutil.WriteJSON(root.Find("b:c"))
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, `{"w":"B","level":11}`, actual)
+
+	// both modules have c so it must be qualified
+	fc.AssertEqual(t, nil, meta.Find(set, "c"))
+
+	// when in one module depends on data in another module
+	light, err := root.Get("light")
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, "red", light)
+	power = `{"power":9}`
+	lightVal, err := brwsr.Root().GetValue("light")
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, nil, lightVal)
+
+	// both modules use prefix x but in b's when it is b
+	shade, err := root.Get("shade")
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, "dark", shade)
+
+	out := root.Find("ping").Action(nil)
+	fc.AssertEqual(t, nil, out.LastErr)
+	actual, err = nodeutil.WriteJSON(out)
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, `{"pong":"hi"}`, actual)
+}
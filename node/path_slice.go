@@ -26,7 +26,7 @@ func NewPathSlice(path string, m meta.HasDefinitions) (p PathSlice) {
 }
 
 func ParsePath(path string, m meta.HasDefinitions) (PathSlice, error) {
-	u, err := url.Parse(path)
+	u, err := parseRelativeUrl(path)
 	if err != nil {
 		return PathSlice{}, err
 	}
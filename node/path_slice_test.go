@@ -7,6 +7,7 @@ import (
 	"github.com/freeconf/yang/meta"
 	"github.com/freeconf/yang/node"
 	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
 )
 
 // func TestPathSliceSplit(t *testing.T) {
@@ -180,3 +181,39 @@ func TestPathEmpty(t *testing.T) {
 		t.Errorf("expected empty path")
 	}
 }
+
+func TestPathSegmentNamespace(t *testing.T) {
+	ypath := source.Dir("../parser/testdata/namespace")
+	m, err := parser.LoadModule(ypath, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{"top/c/x", "m"},
+		{"mm:top/c/x", "m"},
+		{"m:top/c/m:x", "m"},
+		{"top/c/z", "m"},
+		{"top/c/mm:z", "m"},
+	}
+	for _, test := range tests {
+		p, err := node.ParsePath(test.in, m)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := meta.NamespaceModule(p.Tail.Meta().(meta.Definition)).Ident()
+		if actual != test.expected {
+			t.Errorf("%s: expected %s got %s", test.in, test.expected, actual)
+		}
+	}
+	if _, err := node.ParsePath("top/c/zz:x", m); err == nil {
+		t.Error("expected unknown prefix to fail")
+	}
+	// nodes from a grouping are in the namespace of the module using it
+	if _, err := node.ParsePath("top/c/aa:x", m); err == nil {
+		t.Error("expected grouping module prefix to fail")
+	}
+}
@@ -606,7 +606,8 @@ func (self Selection) SetValueHnd(r *FieldRequest, hnd *ValueHandle) error {
 	return nil
 }
 
-// Get let's you get a leaf value from a container or list item
+// Get let's you get a leaf value from a container or list item. Values of
+// typedefs registered with RegisterTypedef are their Go type.
 func (self Selection) Get(ident string) (interface{}, error) {
 	if self.LastErr != nil {
 		return nil, self.LastErr
@@ -615,7 +616,8 @@ func (self Selection) Get(ident string) (interface{}, error) {
 	if e != nil {
 		return nil, e
 	}
-	return v.Value(), nil
+	m := meta.Find(self.Path.meta.(meta.HasDefinitions), ident).(meta.Leafable)
+	return GoValue(m.Type(), v)
 }
 
 // GetValue let's you get the leaf value as a Value instance.  Returns null if value is null
@@ -631,11 +633,15 @@ func (self Selection) GetValue(ident string) (val.Value, error) {
 	if !meta.IsLeaf(pos) {
 		return nil, fmt.Errorf("%w. property is not a leaf %s", fc.NotFoundError, ident)
 	}
+	return self.getValue(pos.(meta.Leafable))
+}
+
+func (self Selection) getValue(m meta.Leafable) (val.Value, error) {
 	r := FieldRequest{
 		Request: Request{
 			Selection: self,
 		},
-		Meta: pos.(meta.Leafable),
+		Meta: m,
 	}
 
 	r.Write = false
@@ -0,0 +1,230 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/val"
+)
+
+// TypedefConv converts values of a typedef to and from a Go type like
+// date-and-time to time.Time. Values are still the typedef's built-in type
+// as they go thru nodes, Go type is only what Selection.Get returns and what
+// nodeutil.Reflect puts in fields.
+type TypedefConv struct {
+	// ToGo checks value that is already the typedef's built-in type and
+	// converts it to Go type
+	ToGo func(v val.Value) (interface{}, error)
+
+	// FromGo converts Go type to anything the built-in type can be converted
+	// from. Any other value should be returned as is.
+	FromGo func(v interface{}) interface{}
+}
+
+var typedefConvs = make(map[string]TypedefConv)
+
+// RegisterTypedef converts values of a typedef everywhere values are created
+// with NewValue. Name is module and typedef, e.g.
+// "ietf-yang-types:date-and-time". Typedefs that are derived from it are
+// converted too unless they are registered themselves. Register before
+// browsing, typically in an init function, as registry is not safe to change
+// concurrently.
+func RegisterTypedef(name string, conv TypedefConv) {
+	typedefConvs[name] = conv
+}
+
+func typedefConv(typ *meta.Type) (TypedefConv, bool) {
+	for t := typ.Typedef(); t != nil; t = t.Type().Typedef() {
+		name := meta.RootModule(t).Ident() + ":" + t.Ident()
+		if conv, found := typedefConvs[name]; found {
+			return conv, true
+		}
+	}
+	return TypedefConv{}, false
+}
+
+// GoValue is v as the Go type registered for the typedef of typ or just
+// v.Value() if there is none. Lists are always their built-in type.
+func GoValue(typ *meta.Type, v val.Value) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if conv, found := typedefConv(typ); found && !v.Format().IsList() {
+		return conv.ToGo(v)
+	}
+	return v.Value(), nil
+}
+
+// toTypedef checks value is valid for typedef and lets value be given as the
+// Go type
+func toTypedef(typ *meta.Type, conv TypedefConv, v interface{}) (val.Value, error) {
+	if x, valid := v.(val.Value); valid {
+		v = x.Value()
+	}
+	if conv.FromGo != nil {
+		if l := reflect.ValueOf(v); typ.Format().IsList() && l.Kind() == reflect.Slice {
+			items := make([]interface{}, l.Len())
+			for i := range items {
+				items[i] = conv.FromGo(l.Index(i).Interface())
+			}
+			v = items
+		} else {
+			v = conv.FromGo(v)
+		}
+	}
+	x, err := newValue(typ, v)
+	if err != nil {
+		return nil, err
+	}
+	if l, isList := x.(val.Listable); isList {
+		for i := 0; i < l.Len(); i++ {
+			if _, err := conv.ToGo(l.Item(i)); err != nil {
+				return nil, err
+			}
+		}
+	} else if _, err := conv.ToGo(x); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// From RFC6991 - Common YANG Data Types. Counters and gauges are not here as
+// they are already Go's unsigned integers.
+func init() {
+	RegisterTypedef("ietf-yang-types:date-and-time", TypedefConv{
+		ToGo: func(v val.Value) (interface{}, error) {
+			t, err := time.Parse(time.RFC3339Nano, v.String())
+			if err != nil {
+				return nil, fmt.Errorf("invalid date-and-time '%s'", v.String())
+			}
+			return t, nil
+		},
+		FromGo: func(v interface{}) interface{} {
+			if t, valid := v.(time.Time); valid {
+				return t.Format(time.RFC3339Nano)
+			}
+			return v
+		},
+	})
+	// hundredths of a second
+	RegisterTypedef("ietf-yang-types:timeticks", TypedefConv{
+		ToGo: func(v val.Value) (interface{}, error) {
+			ticks, err := strconv.ParseUint(v.String(), 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timeticks '%s'", v.String())
+			}
+			return time.Duration(ticks) * 10 * time.Millisecond, nil
+		},
+		FromGo: func(v interface{}) interface{} {
+			if d, valid := v.(time.Duration); valid {
+				return uint32(d / (10 * time.Millisecond))
+			}
+			return v
+		},
+	})
+	RegisterTypedef("ietf-yang-types:mac-address", TypedefConv{
+		ToGo: func(v val.Value) (interface{}, error) {
+			mac, err := net.ParseMAC(v.String())
+			if err != nil || len(mac) != 6 || strings.Count(v.String(), ":") != 5 {
+				return nil, fmt.Errorf("invalid mac-address '%s'", v.String())
+			}
+			return mac, nil
+		},
+		FromGo: func(v interface{}) interface{} {
+			if mac, valid := v.(net.HardwareAddr); valid {
+				return mac.String()
+			}
+			return v
+		},
+	})
+	RegisterTypedef("ietf-yang-types:uuid", TypedefConv{
+		ToGo: func(v val.Value) (interface{}, error) {
+			s := v.String()
+			if !isUUID(s) {
+				return nil, fmt.Errorf("invalid uuid '%s'", s)
+			}
+			return strings.ToLower(s), nil
+		},
+	})
+
+	ip := func(ident string, version int, zone bool) {
+		RegisterTypedef("ietf-inet-types:"+ident, TypedefConv{
+			ToGo: func(v val.Value) (interface{}, error) {
+				s := v.String()
+				if i := strings.IndexRune(s, '%'); i >= 0 && zone {
+					// net.IP has no zone
+					s = s[:i]
+				}
+				addr := net.ParseIP(s)
+				isV6 := strings.ContainsRune(s, ':')
+				if addr == nil || (version == 4 && isV6) || (version == 6 && !isV6) {
+					return nil, fmt.Errorf("invalid %s '%s'", ident, v.String())
+				}
+				return addr, nil
+			},
+			FromGo: func(v interface{}) interface{} {
+				if addr, valid := v.(net.IP); valid {
+					return addr.String()
+				}
+				return v
+			},
+		})
+	}
+	ip("ip-address", 0, true)
+	ip("ipv4-address", 4, true)
+	ip("ipv6-address", 6, true)
+	ip("ip-address-no-zone", 0, false)
+	ip("ipv4-address-no-zone", 4, false)
+	ip("ipv6-address-no-zone", 6, false)
+
+	prefix := func(ident string, version int) {
+		RegisterTypedef("ietf-inet-types:"+ident, TypedefConv{
+			ToGo: func(v val.Value) (interface{}, error) {
+				s := v.String()
+				_, n, err := net.ParseCIDR(s)
+				isV6 := strings.ContainsRune(s, ':')
+				if err != nil || (version == 4 && isV6) || (version == 6 && !isV6) {
+					return nil, fmt.Errorf("invalid %s '%s'", ident, s)
+				}
+				return n, nil
+			},
+			FromGo: func(v interface{}) interface{} {
+				switch x := v.(type) {
+				case *net.IPNet:
+					return x.String()
+				case net.IPNet:
+					return x.String()
+				}
+				return v
+			},
+		})
+	}
+	prefix("ip-prefix", 0)
+	prefix("ipv4-prefix", 4)
+	prefix("ipv6-prefix", 6)
+}
+
+// isUUID is in the form 8-4-4-4-12 hex digits
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, c := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+				return false
+			}
+		}
+	}
+	return true
+}
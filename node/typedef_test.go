@@ -0,0 +1,166 @@
+package node_test
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/nodeutil"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+	"github.com/freeconf/yang/val"
+)
+
+const typedefYangTypes = `module ietf-yang-types {
+	namespace "urn:ietf:params:xml:ns:yang:ietf-yang-types";
+	prefix yang;
+	revision 2013-07-15;
+	typedef date-and-time {
+		type string;
+	}
+	typedef timeticks {
+		type uint32;
+	}
+	typedef timestamp {
+		type yang:timeticks;
+	}
+	typedef mac-address {
+		type string;
+	}
+}`
+
+const typedefInetTypes = `module ietf-inet-types {
+	namespace "urn:ietf:params:xml:ns:yang:ietf-inet-types";
+	prefix inet;
+	revision 2013-07-15;
+	typedef ip-address {
+		type string;
+	}
+	typedef ipv4-prefix {
+		type string;
+	}
+}`
+
+const typedefModule = `module x {
+	namespace "";
+	prefix x;
+	revision 0;
+	import ietf-yang-types {
+		prefix yang;
+	}
+	import ietf-inet-types {
+		prefix inet;
+	}
+	typedef color {
+		type string;
+	}
+	leaf when {
+		type yang:date-and-time;
+	}
+	leaf uptime {
+		type yang:timestamp;
+	}
+	leaf mac {
+		type yang:mac-address;
+	}
+	leaf addr {
+		type inet:ip-address;
+	}
+	leaf-list addrs {
+		type inet:ip-address;
+	}
+	leaf net {
+		type inet:ipv4-prefix;
+	}
+	leaf color {
+		type color;
+	}
+}`
+
+type rgb struct {
+	r, g, b uint8
+}
+
+func init() {
+	node.RegisterTypedef("x:color", node.TypedefConv{
+		ToGo: func(v val.Value) (interface{}, error) {
+			var c rgb
+			if _, err := fmt.Sscanf(v.String(), "#%02x%02x%02x", &c.r, &c.g, &c.b); err != nil {
+				return nil, fmt.Errorf("invalid color '%s'", v.String())
+			}
+			return c, nil
+		},
+		FromGo: func(v interface{}) interface{} {
+			if c, valid := v.(rgb); valid {
+				return fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b)
+			}
+			return v
+		},
+	})
+}
+
+func TestTypedefs(t *testing.T) {
+	ypath := source.Any(
+		source.Named("ietf-yang-types", strings.NewReader(typedefYangTypes)),
+		source.Named("ietf-inet-types", strings.NewReader(typedefInetTypes)),
+	)
+	m, err := parser.LoadModuleFromString(ypath, typedefModule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var app struct {
+		When   time.Time
+		Uptime time.Duration
+		Mac    net.HardwareAddr
+		Addr   net.IP
+		Addrs  []string
+		Net    *net.IPNet
+		Color  rgb
+	}
+	b := node.NewBrowser(m, nodeutil.ReflectChild(&app))
+	in := `{"when":"2023-03-01T10:00:00.5Z","uptime":150,"mac":"00:1a:2b:3c:4d:5e","addr":"fe80::1%eth0","addrs":["10.0.0.1"],"net":"10.0.0.0/8","color":"#ff0080"}`
+	if err := b.Root().UpsertFrom(nodeutil.ReadJSON(in)).LastErr; err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, time.Date(2023, 3, 1, 10, 0, 0, 500000000, time.UTC), app.When)
+	fc.AssertEqual(t, 1500*time.Millisecond, app.Uptime)
+	fc.AssertEqual(t, "00:1a:2b:3c:4d:5e", app.Mac.String())
+	fc.AssertEqual(t, "fe80::1", app.Addr.String())
+	fc.AssertEqual(t, []string{"10.0.0.1"}, app.Addrs)
+	fc.AssertEqual(t, "10.0.0.0/8", app.Net.String())
+	fc.AssertEqual(t, rgb{0xff, 0, 0x80}, app.Color)
+
+	when, err := b.Root().Get("when")
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, app.When, when)
+	color, err := b.Root().Get("color")
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, rgb{0xff, 0, 0x80}, color)
+
+	fc.AssertEqual(t, nil, b.Root().Set("addr", net.ParseIP("10.0.0.2")))
+	fc.AssertEqual(t, "10.0.0.2", app.Addr.String())
+	fc.AssertEqual(t, nil, b.Root().Set("color", rgb{1, 2, 3}))
+	fc.AssertEqual(t, rgb{1, 2, 3}, app.Color)
+
+	actual, err := nodeutil.WriteJSON(b.Root())
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, `{"when":"2023-03-01T10:00:00.5Z","uptime":150,"mac":"00:1a:2b:3c:4d:5e","addr":"10.0.0.2","addrs":["10.0.0.1"],"net":"10.0.0.0/8","color":"#010203"}`, actual)
+
+	invalid := []string{
+		`{"when":"yesterday"}`,
+		`{"mac":"00:1a:2b"}`,
+		`{"addr":"10.0.0.256"}`,
+		`{"addrs":["10.0.0.1","nope"]}`,
+		`{"net":"::/0"}`,
+		`{"color":"red"}`,
+	}
+	for _, in := range invalid {
+		if err := b.Root().UpsertFrom(nodeutil.ReadJSON(in)).LastErr; err == nil {
+			t.Errorf("%s expected error", in)
+		}
+	}
+}
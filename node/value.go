@@ -2,6 +2,9 @@ package node
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
 
 	"github.com/freeconf/yang/meta"
 	"github.com/freeconf/yang/val"
@@ -26,12 +29,14 @@ func minInt(a, b int) int {
 	return b
 }
 
+// NewValuesByString parses values like keys in a URL so they are in canonical
+// form and compare correctly with keys from anywhere else
 func NewValuesByString(m []meta.Leafable, objs ...string) ([]val.Value, error) {
 	var err error
 	l := minInt(len(m), len(objs))
 	vals := make([]val.Value, len(m))
 	for i := 0; i < l; i++ {
-		vals[i], err = NewValue(m[i].Type(), objs[i])
+		vals[i], err = ParseValue(m[i].Type(), objs[i])
 		if err != nil {
 			return nil, err
 		}
@@ -61,6 +66,98 @@ func NewValue(typ *meta.Type, v interface{}) (val.Value, error) {
 	if v == nil {
 		return nil, nil
 	}
+	if conv, found := typedefConv(typ); found {
+		return toTypedef(typ, conv, v)
+	}
+	return newValue(typ, v)
+}
+
+// ParseValue is like NewValue but only accepts the lexical representation of
+// the type from RFC7950 Section 9 and value is in canonical form. Use this for
+// values that are always text like JSON strings or keys in a URL. List types
+// are a list of one item, see ParseValueList.
+func ParseValue(typ *meta.Type, s string) (val.Value, error) {
+	if typ.Format().IsList() {
+		return ParseValueList(typ, []string{s})
+	}
+	return parseValue(typ, s)
+}
+
+// ParseValueList is ParseValue for each item of a leaf-list
+func ParseValueList(typ *meta.Type, items []string) (val.Value, error) {
+	canonical := make([]string, len(items))
+	for i, s := range items {
+		item, err := parseValue(typ, s)
+		if err != nil {
+			return nil, err
+		}
+		if ref, isRef := item.(val.IdentRef); isRef {
+			// label alone may be in more than one module
+			canonical[i] = ref.Qualified()
+		} else {
+			canonical[i] = item.String()
+		}
+	}
+	return NewValue(typ, canonical)
+}
+
+// parseValue parses a single item even when type is a list
+func parseValue(typ *meta.Type, s string) (val.Value, error) {
+	var v val.Value
+	var err error
+	switch typ.Format().Single() {
+	case val.FmtEnum:
+		e, found := typ.Enum().ByLabel(s)
+		if !found {
+			return nil, fmt.Errorf("'%s' is not one of enum %s", s, typ.Enum().String())
+		}
+		v = e
+	case val.FmtIdentityRef:
+		v, err = toIdentRef(typ.Base(), s)
+	case val.FmtBits:
+		var labels val.Value
+		if labels, err = val.Parse(val.FmtBits, s); err == nil {
+			v, err = toBits(typ.Bits(), labels.(val.Bits).Labels())
+		}
+	case val.FmtDecimal64:
+		var d val.Value
+		if d, err = val.Parse(val.FmtDecimal64, s); err == nil {
+			v, err = toDecimal64(typ, d)
+		}
+	case val.FmtBinary:
+		var b val.Value
+		if b, err = val.Parse(val.FmtBinary, s); err == nil {
+			v, err = toBinary(typ, b)
+		}
+	case val.FmtUnion:
+		// From RFC7950 Section 9.12 - the first member type that accepts
+		// the value is used
+		for _, u := range typ.Union() {
+			if v, err = parseValue(u, s); err == nil {
+				return v, nil
+			}
+		}
+		return nil, fmt.Errorf("'%s' is not valid for any of the union types", s)
+	case val.FmtLeafRef:
+		if resolved := typ.Resolve(); resolved != typ {
+			return parseValue(resolved, s)
+		}
+		v = val.String(s)
+	default:
+		v, err = val.Parse(typ.Format().Single(), s)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if conv, found := typedefConv(typ); found {
+		if _, err := conv.ToGo(v); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func newValue(typ *meta.Type, v interface{}) (val.Value, error) {
 	switch typ.Format() {
 	case val.FmtIdentityRef:
 		return toIdentRef(typ.Base(), v)
@@ -70,6 +167,18 @@ func NewValue(typ *meta.Type, v interface{}) (val.Value, error) {
 		return toEnum(typ.Enum(), v)
 	case val.FmtEnumList:
 		return toEnumList(typ.Enum(), v)
+	case val.FmtBits:
+		return toBits(typ.Bits(), v)
+	case val.FmtBitsList:
+		return toBitsList(typ.Bits(), v)
+	case val.FmtBinary:
+		return toBinary(typ, v)
+	case val.FmtBinaryList:
+		return toBinaryList(typ, v)
+	case val.FmtDecimal64:
+		return toDecimal64(typ, v)
+	case val.FmtDecimal64List:
+		return toDecimal64List(typ, v)
 	case val.FmtUnion:
 		cvt, _, err := val.ConvOneOf(typ.UnionFormats(), v)
 		return cvt, err
@@ -77,19 +186,222 @@ func NewValue(typ *meta.Type, v interface{}) (val.Value, error) {
 	return val.Conv(typ.Format(), v)
 }
 
+// toBits accepts bit labels or a number where each bit set in the number is
+// the bit with that position
+func toBits(defs []*meta.Bit, v interface{}) (val.Bits, error) {
+	var bits val.Bits
+	var mask uint64
+	isMask := true
+	switch x := reflect.ValueOf(v); x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		mask = uint64(x.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		mask = x.Uint()
+	default:
+		isMask = false
+	}
+	if isMask {
+		for _, def := range defs {
+			if def.Position < 64 && mask&(1<<uint(def.Position)) != 0 {
+				bits = append(bits, val.Bit{Position: def.Position, Label: def.Ident()})
+				mask &^= 1 << uint(def.Position)
+			}
+		}
+		if mask != 0 {
+			return nil, fmt.Errorf("no bits for positions in mask %b", mask)
+		}
+	} else {
+		labels, err := val.Conv(val.FmtBits, v)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range labels.(val.Bits) {
+			if bits.IsSet(b.Label) {
+				return nil, fmt.Errorf("bit %s is set more than once", b.Label)
+			}
+			var def *meta.Bit
+			for _, candidate := range defs {
+				if candidate.Ident() == b.Label {
+					def = candidate
+					break
+				}
+			}
+			if def == nil {
+				return nil, fmt.Errorf("could not find bit %s", b.Label)
+			}
+			bits = append(bits, val.Bit{Position: def.Position, Label: def.Ident()})
+		}
+	}
+	sort.Slice(bits, func(i, j int) bool {
+		return bits[i].Position < bits[j].Position
+	})
+	return bits, nil
+}
+
+func toBitsList(defs []*meta.Bit, v interface{}) (val.BitsList, error) {
+	if x := reflect.ValueOf(v); x.Kind() == reflect.Slice {
+		switch x.Type().Elem().Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			// list of bitmasks
+			l := make(val.BitsList, x.Len())
+			var err error
+			for i := range l {
+				if l[i], err = toBits(defs, x.Index(i).Interface()); err != nil {
+					return nil, err
+				}
+			}
+			return l, nil
+		}
+	}
+	x, err := val.Conv(val.FmtBitsList, v)
+	if err != nil {
+		return nil, err
+	}
+	l := x.(val.BitsList)
+	for i := range l {
+		if l[i], err = toBits(defs, l[i].Labels()); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// toBinary checks length of binary which is in bytes
+func toBinary(typ *meta.Type, v interface{}) (val.Binary, error) {
+	x, err := val.Conv(val.FmtBinary, v)
+	if err != nil {
+		return nil, err
+	}
+	b := x.(val.Binary)
+	for _, r := range typ.Length() {
+		if err := checkLength(r, len(b)); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func toBinaryList(typ *meta.Type, v interface{}) (val.BinaryList, error) {
+	x, err := val.Conv(val.FmtBinaryList, v)
+	if err != nil {
+		return nil, err
+	}
+	l := x.(val.BinaryList)
+	for _, b := range l {
+		if _, err := toBinary(typ, b); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+func checkLength(r *meta.Range, n int) error {
+	min, max := r.Min, r.Max
+	if min == "" {
+		// length of a single value
+		min = max
+	}
+	if min != "min" {
+		if bound, err := strconv.Atoi(min); err != nil {
+			return err
+		} else if n < bound {
+			return fmt.Errorf("length %d is not in range %s..%s", n, min, max)
+		}
+	}
+	if max != "max" {
+		if bound, err := strconv.Atoi(max); err != nil {
+			return err
+		} else if n > bound {
+			return fmt.Errorf("length %d is not in range %s..%s", n, min, max)
+		}
+	}
+	return nil
+}
+
+// toDecimal64 has fraction digits of type and is within type's ranges
+func toDecimal64(typ *meta.Type, v interface{}) (val.Decimal64, error) {
+	var empty val.Decimal64
+	x, err := val.Conv(val.FmtDecimal64, v)
+	if err != nil {
+		return empty, err
+	}
+	d := x.(val.Decimal64)
+	if typ.FractionDigits() > 0 {
+		if d, err = d.Scale(typ.FractionDigits()); err != nil {
+			return empty, err
+		}
+	}
+	for _, r := range typ.Range() {
+		if err := checkDecimal64Range(r, d); err != nil {
+			return empty, err
+		}
+	}
+	return d, nil
+}
+
+func checkDecimal64Range(r *meta.Range, d val.Decimal64) error {
+	min, max := r.Min, r.Max
+	if min == "" {
+		// range of a single value
+		min = max
+	}
+	if min != "min" {
+		bound, err := val.Conv(val.FmtDecimal64, min)
+		if err != nil {
+			return err
+		}
+		if d.Compare(bound.(val.Decimal64)) < 0 {
+			return fmt.Errorf("%s is not in range %s", d, r)
+		}
+	}
+	if max != "max" {
+		bound, err := val.Conv(val.FmtDecimal64, max)
+		if err != nil {
+			return err
+		}
+		if d.Compare(bound.(val.Decimal64)) > 0 {
+			return fmt.Errorf("%s is not in range %s", d, r)
+		}
+	}
+	return nil
+}
+
+func toDecimal64List(typ *meta.Type, v interface{}) (val.Decimal64List, error) {
+	x, err := val.Conv(val.FmtDecimal64List, v)
+	if err != nil {
+		return nil, err
+	}
+	l := x.(val.Decimal64List)
+	for i := range l {
+		if l[i], err = toDecimal64(typ, l[i]); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// toIdentRef accepts identity with or without the module name or prefix it is
+// in
 func toIdentRef(base *meta.Identity, v interface{}) (val.IdentRef, error) {
 	var empty val.IdentRef
-	x := fmt.Sprintf("%v", v)
-	ref, found := base.Derived()[x]
-	if !found {
-		return empty, fmt.Errorf("could not find identity ref for %T:%s in %s", v, x, base.Ident())
+	var s string
+	switch x := v.(type) {
+	case val.IdentRef:
+		s = x.Qualified()
+	default:
+		s = fmt.Sprintf("%v", v)
+	}
+	ref, err := resolveIdentity(base, s)
+	if err != nil {
+		return empty, err
 	}
-	return val.IdentRef{Base: base.Ident(), Label: ref.Ident()}, nil
+	return identRef(base, ref), nil
 }
 
 func toIdentRefList(base *meta.Identity, v interface{}) (val.IdentRefList, error) {
 	switch x := v.(type) {
-	case string:
+	case string, val.IdentRef:
 		ref, err := toIdentRef(base, x)
 		if err != nil {
 			return nil, err
@@ -105,6 +417,26 @@ func toIdentRefList(base *meta.Identity, v interface{}) (val.IdentRefList, error
 			refs = append(refs, ref)
 		}
 		return refs, nil
+	case []interface{}:
+		var refs []val.IdentRef
+		for _, item := range x {
+			ref, err := toIdentRef(base, item)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, ref)
+		}
+		return refs, nil
+	case val.IdentRefList:
+		var refs []val.IdentRef
+		for _, item := range x {
+			ref, err := toIdentRef(base, item)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, ref)
+		}
+		return refs, nil
 	}
 	return nil, fmt.Errorf("could not coerse %v into identref list", v)
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/freeconf/yang/fc"
 	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/val"
 )
 
 func TestCoerseValue(t *testing.T) {
@@ -40,3 +42,193 @@ func TestToIdentRef(t *testing.T) {
 	fc.AssertEqual(t, "i00", ref.Label)
 	fc.AssertEqual(t, "i0", ref.Base)
 }
+
+func TestDecimal64Value(t *testing.T) {
+	b := &meta.Builder{}
+	m := b.Module("x", nil)
+	l := b.Leaf(m, "l")
+	dt := b.Type(l, "decimal64")
+	b.FractionDigits(dt, 2)
+	b.ValueRange(dt, "-0.5..1000.25")
+	if err := meta.Compile(m); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in       interface{}
+		expected string
+	}{
+		{in: "1000.25", expected: "1000.25"},
+		{in: 0.1, expected: "0.1"},
+		{in: 3, expected: "3.0"},
+		{in: "-0.50", expected: "-0.5"},
+		{in: "1000.26"},
+		{in: "-0.51"},
+		{in: "1.234"},
+	}
+	for _, test := range tests {
+		v, err := NewValue(dt, test.in)
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("%v expected error, got %v", test.in, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		fc.AssertEqual(t, 2, v.(val.Decimal64).FractionDigits)
+		fc.AssertEqual(t, test.expected, v.String())
+	}
+}
+
+func TestBitsValue(t *testing.T) {
+	b := &meta.Builder{}
+	m := b.Module("x", nil)
+	l := b.Leaf(m, "l")
+	dt := b.Type(l, "bits")
+	b.Bit(dt, "a")
+	c := b.Bit(dt, "c")
+	b.Position(c, 3)
+	b.Bit(dt, "d")
+	if err := meta.Compile(m); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in       interface{}
+		expected string
+	}{
+		{in: "d a", expected: "a d"},
+		{in: []string{"c"}, expected: "c"},
+		{in: "", expected: ""},
+		{in: 9, expected: "a c"},
+		{in: uint8(16), expected: "d"},
+		{in: "a b"},
+		{in: "a a"},
+		{in: 2},
+	}
+	for _, test := range tests {
+		v, err := NewValue(dt, test.in)
+		if test.expected == "" && test.in != "" {
+			if err == nil {
+				t.Errorf("%v expected error, got %v", test.in, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		fc.AssertEqual(t, test.expected, v.String())
+	}
+	v, _ := NewValue(dt, "c a")
+	fc.AssertEqual(t, val.Bits{{Position: 0, Label: "a"}, {Position: 3, Label: "c"}}, v)
+}
+
+func TestBinaryValue(t *testing.T) {
+	b := &meta.Builder{}
+	m := b.Module("x", nil)
+	l := b.Leaf(m, "l")
+	dt := b.Type(l, "binary")
+	b.LengthRange(dt, "1..2")
+	if err := meta.Compile(m); err != nil {
+		t.Fatal(err)
+	}
+	v, err := NewValue(dt, "aGk=")
+	fc.AssertEqual(t, nil, err)
+	fc.AssertEqual(t, []byte("hi"), v.Value())
+	_, err = NewValue(dt, []byte("bye"))
+	fc.AssertEqual(t, "length 3 is not in range 1..2", err.Error())
+	_, err = NewValue(dt, []byte{})
+	fc.AssertEqual(t, "length 0 is not in range 1..2", err.Error())
+}
+
+func TestParseValue(t *testing.T) {
+	m, err := parser.LoadModuleFromString(nil, `module x {
+		prefix x;
+		namespace "";
+		revision 0;
+		identity animal;
+		identity dog {
+			base animal;
+		}
+		leaf e {
+			type enumeration {
+				enum one;
+				enum two;
+			}
+		}
+		leaf i {
+			type identityref {
+				base animal;
+			}
+		}
+		leaf b {
+			type bits {
+				bit a;
+				bit b;
+			}
+		}
+		leaf d {
+			type decimal64 {
+				fraction-digits 2;
+			}
+		}
+		leaf u {
+			type union {
+				type int32;
+				type boolean;
+			}
+		}
+		leaf r {
+			type leafref {
+				path "../d";
+			}
+		}
+		leaf-list l {
+			type uint8;
+		}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		leaf      string
+		in        string
+		canonical string
+	}{
+		{leaf: "e", in: "two", canonical: "two"},
+		{leaf: "e", in: "1"},
+		{leaf: "i", in: "dog", canonical: "dog"},
+		{leaf: "i", in: "x:dog", canonical: "dog"},
+		{leaf: "i", in: "y:dog"},
+		{leaf: "b", in: "b  a", canonical: "a b"},
+		{leaf: "b", in: "c"},
+		{leaf: "d", in: "+1.5", canonical: "1.5"},
+		{leaf: "d", in: "1.555"},
+		{leaf: "u", in: "+5", canonical: "5"},
+		{leaf: "u", in: "true", canonical: "true"},
+		{leaf: "u", in: "TRUE"},
+		{leaf: "r", in: "02.10", canonical: "2.1"},
+		{leaf: "l", in: "+8", canonical: "8"},
+		{leaf: "l", in: "0x8"},
+	}
+	for _, test := range tests {
+		l := meta.Find(m, test.leaf).(meta.Leafable)
+		v, err := ParseValue(l.Type(), test.in)
+		if test.canonical == "" {
+			if err == nil {
+				t.Errorf("%s '%s' expected error, got %v", test.leaf, test.in, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s '%s' %s", test.leaf, test.in, err)
+			continue
+		}
+		if l.Type().Format().IsList() {
+			v = v.(val.Listable).Item(0)
+		}
+		fc.AssertEqual(t, test.canonical, v.String())
+	}
+}
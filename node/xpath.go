@@ -1,11 +1,20 @@
 package node
 
-import "github.com/freeconf/yang/xpath"
+import (
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/xpath"
+)
 
 func (self Selection) XFind(path xpath.Path) Selection {
+	return self.xfind(path, nil)
+}
+
+// xfind resolves prefixes in path as module that wrote the path knows them
+// or as the module of the selection does if module is nil
+func (self Selection) xfind(path xpath.Path, module *meta.Module) Selection {
 	sel := self
 	p := path
-	r := xpathResolver{impl: xpathImpl{}}
+	r := xpathResolver{impl: xpathImpl{}, module: module}
 	for p != nil {
 		found := r.resolvePath(p, sel)
 		if found.IsNil() || found.LastErr != nil {
@@ -18,7 +27,11 @@ func (self Selection) XFind(path xpath.Path) Selection {
 }
 
 func (self Selection) XPredicate(p xpath.Path) (bool, error) {
-	found := self.XFind(p)
+	return self.xpredicate(p, nil)
+}
+
+func (self Selection) xpredicate(p xpath.Path, module *meta.Module) (bool, error) {
+	found := self.xfind(p, module)
 	if found.LastErr != nil {
 		return false, found.LastErr
 	}
@@ -26,7 +39,8 @@ func (self Selection) XPredicate(p xpath.Path) (bool, error) {
 }
 
 type xpathResolver struct {
-	impl xpathInterpretter
+	impl   xpathInterpretter
+	module *meta.Module
 }
 
 func (self xpathResolver) resolvePath(p xpath.Path, sel Selection) Selection {
@@ -12,15 +12,15 @@ type xpathImpl struct {
 }
 
 func (self xpathImpl) resolveSegment(r xpathResolver, seg *xpath.Segment, s Selection) Selection {
-	m := meta.Find(s.Meta().(meta.HasDefinitions), seg.Ident)
+	m := meta.FindFrom(r.module, s.Meta().(meta.HasDefinitions), seg.Ident)
 	if m == nil {
 		return Selection{LastErr: fmt.Errorf("'%s' not found in xpath", seg.Ident)}
 	}
 	if meta.IsContainer(m) {
-		return r.resolvePath(seg.Next(), s.Find(seg.Ident))
+		return r.resolvePath(seg.Next(), self.child(s, m))
 	}
 	if meta.IsList(m) {
-		s := s.Find(seg.Ident)
+		s := self.child(s, m)
 		li := s.First()
 		nextSeg := seg.Next()
 		for !li.Selection.IsNil() {
@@ -45,7 +45,7 @@ func (self xpathImpl) resolveSegment(r xpathResolver, seg *xpath.Segment, s Sele
 }
 
 func (self xpathImpl) resolveOperator(r xpathResolver, oper *xpath.Operator, ident string, s Selection) (bool, error) {
-	m := meta.Find(s.Meta().(meta.HasDefinitions), ident)
+	m := meta.FindFrom(r.module, s.Meta().(meta.HasDefinitions), ident)
 	if m == nil {
 		return false, fmt.Errorf("'%s' not found in xpath", ident)
 	}
@@ -53,7 +53,7 @@ func (self xpathImpl) resolveOperator(r xpathResolver, oper *xpath.Operator, ide
 	if err != nil {
 		return false, err
 	}
-	a, err := s.GetValue(ident)
+	a, err := s.getValue(m.(meta.Leafable))
 	if err != nil {
 		return false, err
 	}
@@ -78,9 +78,16 @@ func (self xpathImpl) resolveOperator(r xpathResolver, oper *xpath.Operator, ide
 	panic("unrecognized operator: " + oper.Oper)
 }
 
+// child selects by definition already found so prefixes are not resolved
+// again without the module that wrote them
+func (self xpathImpl) child(s Selection, m meta.Definition) Selection {
+	head := NewRootPath(s.Meta())
+	return s.FindSlice(PathSlice{Head: head, Tail: &Path{parent: head, meta: m}})
+}
+
 func (self xpathImpl) resolveAbsolutePath(r xpathResolver, s Selection) Selection {
 	found := s
-	if found.Parent != nil {
+	for found.Parent != nil {
 		found = *found.Parent
 	}
 	return found
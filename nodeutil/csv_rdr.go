@@ -0,0 +1,161 @@
+package nodeutil
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/val"
+)
+
+// CSVRdr reads rows of comma separated values as items in a list. First row
+// must be the header in the same format CSVWtr writes. Columns not found in
+// the list definition are ignored and empty cells are treated as no value.
+// Rows are read from stream as items are requested so very large files can be
+// imported.
+//
+// Example:
+//    sel.Find("sessions").InsertFrom(nodeutil.ReadCSV(data))
+type CSVRdr struct {
+	In io.Reader
+
+	// field delimiter, default is ','.  Use '\t' for TSV
+	Delim rune
+
+	// values of a leaf-list are split from a single cell using this
+	// delimiter, default is ';'
+	LeafListDelim string
+
+	in     *csv.Reader
+	header []string
+	row    int
+	cells  map[string]string
+}
+
+func ReadCSV(data string) node.Node {
+	rdr := &CSVRdr{In: strings.NewReader(data)}
+	return rdr.Node()
+}
+
+func ReadCSVIO(rdr io.Reader) node.Node {
+	csvRdr := &CSVRdr{In: rdr}
+	return csvRdr.Node()
+}
+
+func (self *CSVRdr) Node() node.Node {
+	self.in = csv.NewReader(self.In)
+	self.in.Comma = csvDelim(self.Delim)
+	self.row = -1
+	var err error
+	if self.header, err = self.in.Read(); err != nil {
+		return node.ErrorNode{Err: err}
+	}
+	// rows are allowed to be shorter than header
+	self.in.FieldsPerRecord = -1
+	return &Basic{
+		OnNext: func(r node.ListRequest) (node.Node, []val.Value, error) {
+			if r.New {
+				panic("Cannot write to CSV reader")
+			}
+			if len(r.Key) > 0 {
+				return nil, nil, fmt.Errorf("%w. finding items by key in csv", fc.NotImplementedError)
+			}
+			cells, err := self.read(r.Row)
+			if err != nil || cells == nil {
+				return nil, nil, err
+			}
+			var key []val.Value
+			if len(r.Meta.KeyMeta()) > 0 {
+				keyStrs := make([]string, len(r.Meta.KeyMeta()))
+				for i, kmeta := range r.Meta.KeyMeta() {
+					keyStrs[i] = cells[kmeta.Ident()]
+				}
+				if key, err = node.NewValuesByString(r.Meta.KeyMeta(), keyStrs...); err != nil {
+					return nil, nil, err
+				}
+			}
+			return self.container(cells, ""), key, nil
+		},
+	}
+}
+
+// read advances stream to the given row, rows can only be read in order
+func (self *CSVRdr) read(row int) (map[string]string, error) {
+	for self.row < row {
+		record, err := self.in.Read()
+		if err == io.EOF {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		self.row++
+		self.cells = make(map[string]string, len(self.header))
+		for i, cell := range record {
+			if i < len(self.header) && cell != "" {
+				self.cells[self.header[i]] = cell
+			}
+		}
+	}
+	if self.row != row {
+		return nil, fmt.Errorf("%w. reading csv rows out of order", fc.NotImplementedError)
+	}
+	return self.cells, nil
+}
+
+func (self *CSVRdr) container(cells map[string]string, prefix string) node.Node {
+	s := &Basic{}
+	s.OnChoose = func(sel node.Selection, choice *meta.Choice) (*meta.ChoiceCase, error) {
+		for _, kaseIdent := range choice.CaseIdents() {
+			kase := choice.Cases()[kaseIdent]
+			if self.hasAny(cells, prefix, kase) {
+				return kase, nil
+			}
+		}
+		return nil, nil
+	}
+	s.OnChild = func(r node.ChildRequest) (node.Node, error) {
+		if r.New {
+			panic("Cannot write to CSV reader")
+		}
+		if meta.IsList(r.Meta) {
+			return nil, nil
+		}
+		childPrefix := prefix + r.Meta.Ident() + "."
+		if !self.hasAny(cells, childPrefix, r.Meta) {
+			return nil, nil
+		}
+		return self.container(cells, childPrefix), nil
+	}
+	s.OnField = func(r node.FieldRequest, hnd *node.ValueHandle) (err error) {
+		if r.Write {
+			panic("Cannot write to CSV reader")
+		}
+		cell, found := cells[prefix+r.Meta.Ident()]
+		if !found {
+			return nil
+		}
+		if r.Meta.Type().Format().IsList() {
+			items := strings.Split(cell, csvLeafListDelim(self.LeafListDelim))
+			hnd.Val, err = node.ParseValueList(r.Meta.Type(), items)
+			return
+		}
+		hnd.Val, err = node.ParseValue(r.Meta.Type(), cell)
+		return
+	}
+	return s
+}
+
+// hasAny is true if there is at least one value for any leaf under the given
+// definition
+func (self *CSVRdr) hasAny(cells map[string]string, prefix string, m meta.HasDataDefinitions) bool {
+	for _, col := range csvColumns(m, prefix, "", nil) {
+		if _, found := cells[col.ident]; found {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package nodeutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/parser"
+)
+
+func TestCSVRdr(t *testing.T) {
+	m, err := parser.LoadModuleFromString(nil, csvTestModule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := "id\tpeer.port.num\ttags\tignored\n" +
+		"1\t80\tx;y\tjunk\n" +
+		"2\n"
+	b := node.NewBrowser(m, ReflectChild(make(map[string]interface{})))
+	sel := b.Root()
+	if err = sel.InsertFrom(ReadJSON(`{"session":[{"id":"0"}]}`)).LastErr; err != nil {
+		t.Fatal(err)
+	}
+	rdr := &CSVRdr{In: strings.NewReader(data), Delim: '\t'}
+	if err = sel.Find("session").InsertFrom(rdr.Node()).LastErr; err != nil {
+		t.Fatal(err)
+	}
+	actual, err := WriteJSON(sel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"session":[{"id":"0"},{"id":"1","peer":{"port":{"num":80}},"tags":["x","y"]},{"id":"2"}]}`
+	fc.AssertEqual(t, expected, actual)
+}
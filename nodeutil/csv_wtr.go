@@ -0,0 +1,220 @@
+package nodeutil
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/val"
+)
+
+// CSVWtr writes each item of a list as a row of comma separated values. Header
+// row is derived from the leaf identifiers of list.  Leafs in nested containers
+// are flattened into columns like "a.b.c" and nested lists are not written.
+// Columns can be selected with "fields" and "fc.xfields" constraints on the
+// selection.
+//
+// Example:
+//    sel.Find("sessions?fields=id;peer")
+type CSVWtr struct {
+
+	// stream to write contents.
+	Out io.Writer
+
+	// field delimiter, default is ','.  Use '\t' for TSV
+	Delim rune
+
+	// values of a leaf-list are joined into a single cell using this
+	// delimiter, default is ';'
+	LeafListDelim string
+}
+
+// WriteCSV writes list selection as CSV to a string
+func WriteCSV(s node.Selection) (string, error) {
+	buff := new(bytes.Buffer)
+	wtr := &CSVWtr{Out: buff}
+	err := wtr.Write(s)
+	return buff.String(), err
+}
+
+// Write list selection into stream
+func (self *CSVWtr) Write(s node.Selection) error {
+	if s.LastErr != nil {
+		return s.LastErr
+	}
+	if !meta.IsList(s.Meta()) || s.InsideList {
+		return fmt.Errorf("%w. %s is not a list", fc.BadRequestError, s.Path)
+	}
+	cols, err := self.selectedColumns(s)
+	if err != nil {
+		return err
+	}
+	out := csv.NewWriter(self.Out)
+	out.Comma = csvDelim(self.Delim)
+	header := make([]string, len(cols))
+	for i, col := range cols {
+		header[i] = col.ident
+	}
+	if err := out.Write(header); err != nil {
+		return err
+	}
+	n := &Basic{
+		OnNext: func(r node.ListRequest) (node.Node, []val.Value, error) {
+			if !r.New {
+				return nil, nil, nil
+			}
+			return self.item(out, cols), r.Key, nil
+		},
+	}
+	if err := s.InsertInto(n).LastErr; err != nil {
+		return err
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func (self *CSVWtr) item(out *csv.Writer, cols []csvColumn) node.Node {
+	row := make(map[string]string)
+	n := self.container(row, "")
+	n.OnEndEdit = func(r node.NodeRequest) error {
+		record := make([]string, len(cols))
+		for i, col := range cols {
+			record[i] = row[col.ident]
+		}
+		return out.Write(record)
+	}
+	return n
+}
+
+func (self *CSVWtr) container(row map[string]string, prefix string) *Basic {
+	n := &Basic{}
+	n.OnChild = func(r node.ChildRequest) (node.Node, error) {
+		if !r.New {
+			return nil, nil
+		}
+		if meta.IsList(r.Meta) {
+			// cannot flatten nested lists into a single row
+			return Null(), nil
+		}
+		return self.container(row, prefix+r.Meta.Ident()+"."), nil
+	}
+	n.OnField = func(r node.FieldRequest, hnd *node.ValueHandle) error {
+		if hnd.Val != nil {
+			row[prefix+r.Meta.Ident()] = csvCell(hnd.Val, csvLeafListDelim(self.LeafListDelim))
+		}
+		return nil
+	}
+	return n
+}
+
+// selectedColumns has to consult field constraints before any data is written
+// because header is written first
+func (self *CSVWtr) selectedColumns(s node.Selection) ([]csvColumn, error) {
+	all := csvColumns(s.Meta().(meta.HasDataDefinitions), "", "", nil)
+	if s.Constraints == nil {
+		return all, nil
+	}
+	var selected []csvColumn
+	for _, col := range all {
+		slice, err := node.ParsePath(col.path, s.Meta().(meta.HasDefinitions))
+		if err != nil {
+			return nil, err
+		}
+		include, err := csvColumnSelected(s.Constraints, slice)
+		if err != nil {
+			return nil, err
+		}
+		if include {
+			selected = append(selected, col)
+		}
+	}
+	return selected, nil
+}
+
+func csvColumnSelected(c *node.Constraints, slice node.PathSlice) (bool, error) {
+	segs := slice.Segments()
+	for _, id := range []string{"fields", "fc.xfields", "content"} {
+		constraint := c.Constraint(id)
+		if constraint == nil {
+			continue
+		}
+		for i, seg := range segs {
+			if i < len(segs)-1 {
+				if check, valid := constraint.(node.ContainerPreConstraint); valid {
+					r := &node.ChildRequest{
+						Request: node.Request{Path: seg, Base: slice.Head},
+						Meta:    seg.Meta().(meta.HasDataDefinitions),
+					}
+					if proceed, err := check.CheckContainerPreConstraints(r); !proceed || err != nil {
+						return false, err
+					}
+				}
+			} else if check, valid := constraint.(node.FieldPreConstraint); valid {
+				r := &node.FieldRequest{
+					Request: node.Request{Path: seg, Base: slice.Head},
+					Meta:    seg.Meta().(meta.Leafable),
+				}
+				if proceed, err := check.CheckFieldPreConstraints(r, &node.ValueHandle{}); !proceed || err != nil {
+					return false, err
+				}
+			}
+		}
+	}
+	return true, nil
+}
+
+type csvColumn struct {
+	// a.b.c
+	ident string
+
+	// a/b/c
+	path string
+}
+
+func csvColumns(parent meta.HasDataDefinitions, prefix string, pathPrefix string, cols []csvColumn) []csvColumn {
+	for _, def := range parent.DataDefinitions() {
+		switch x := def.(type) {
+		case *meta.Choice:
+			for _, kaseIdent := range x.CaseIdents() {
+				cols = csvColumns(x.Cases()[kaseIdent], prefix, pathPrefix, cols)
+			}
+		case *meta.Container:
+			if !x.IsRecursive() {
+				cols = csvColumns(x, prefix+x.Ident()+".", pathPrefix+x.Ident()+"/", cols)
+			}
+		case *meta.Leaf, *meta.LeafList:
+			cols = append(cols, csvColumn{
+				ident: prefix + def.Ident(),
+				path:  pathPrefix + def.Ident(),
+			})
+		}
+	}
+	return cols
+}
+
+func csvCell(v val.Value, leafListDelim string) string {
+	var items []string
+	val.ForEach(v, func(i int, item val.Value) {
+		items = append(items, item.String())
+	})
+	return strings.Join(items, leafListDelim)
+}
+
+func csvDelim(delim rune) rune {
+	if delim == 0 {
+		return ','
+	}
+	return delim
+}
+
+func csvLeafListDelim(delim string) string {
+	if delim == "" {
+		return ";"
+	}
+	return delim
+}
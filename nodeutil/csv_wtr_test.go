@@ -0,0 +1,86 @@
+package nodeutil
+
+import (
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/parser"
+)
+
+const csvTestModule = `module m {
+	list session {
+		key "id";
+		leaf id {
+			type string;
+		}
+		container peer {
+			leaf addr {
+				type string;
+			}
+			container port {
+				leaf num {
+					type int32;
+				}
+			}
+		}
+		leaf-list tags {
+			type string;
+		}
+		leaf rate {
+			type decimal64;
+		}
+		list history {
+			leaf x {
+				type string;
+			}
+		}
+	}
+}`
+
+func TestCSVWtr(t *testing.T) {
+	m, err := parser.LoadModuleFromString(nil, csvTestModule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := `{"session":[
+		{"id":"1","peer":{"addr":"a, b","port":{"num":80}},"tags":["x","y"],"rate":0.5,"history":[{"x":"z"}]},
+		{"id":"2","rate":1}
+	]}`
+	sel := node.NewBrowser(m, ReadJSON(data)).Root()
+	tests := []struct {
+		find     string
+		expected string
+	}{
+		{
+			find: "session",
+			expected: "id,peer.addr,peer.port.num,tags,rate\n" +
+				"1,\"a, b\",80,x;y,0.5\n" +
+				"2,,,,1.0\n",
+		},
+		{
+			find: "session?fields=id%3Bpeer",
+			expected: "id,peer.addr,peer.port.num\n" +
+				"1,\"a, b\",80\n" +
+				"2,,\n",
+		},
+		{
+			find: "session?fc.xfields=peer%3Btags",
+			expected: "id,rate\n" +
+				"1,0.5\n" +
+				"2,1.0\n",
+		},
+	}
+	for _, test := range tests {
+		t.Log(test.find)
+		actual, err := WriteCSV(sel.Find(test.find))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fc.AssertEqual(t, test.expected, actual)
+	}
+
+	if _, err := WriteCSV(sel.Find("session=1")); err == nil {
+		t.Error("expected error writing list item")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/freeconf/yang/node"
@@ -42,6 +43,8 @@ func (self *JSONRdr) Node() node.Node {
 func (self *JSONRdr) decode() (map[string]interface{}, error) {
 	if self.values == nil {
 		d := json.NewDecoder(self.In)
+		// numbers as json.Number so large 64-bit integers are not rounded
+		d.UseNumber()
 		if err := d.Decode(&self.values); err != nil {
 			return nil, err
 		}
@@ -50,7 +53,66 @@ func (self *JSONRdr) decode() (map[string]interface{}, error) {
 }
 
 func leafOrLeafListJsonReader(m meta.Leafable, data interface{}) (v val.Value, err error) {
-	return node.NewValue(m.Type(), data)
+	return jsonValue(m.Type(), data)
+}
+
+// jsonValue parses strings, numbers and booleans strictly so values are in
+// canonical form. Numbers are allowed for any type that is a number, not just
+// the ones RFC7951 has as JSON numbers.
+func jsonValue(typ *meta.Type, data interface{}) (val.Value, error) {
+	if typ.Format().Single() == val.FmtEnum {
+		// numbers are ids from JSONWtr.EnumAsIds
+		if s, isStr := data.(string); isStr && !typ.Format().IsList() {
+			return node.ParseValue(typ, s)
+		}
+		return node.NewValue(typ, jsonEnumIds(data))
+	}
+	if l, isList := data.([]interface{}); isList && typ.Format().IsList() {
+		items := make([]string, len(l))
+		for i, item := range l {
+			s, valid := jsonScalar(item)
+			if !valid {
+				return node.NewValue(typ, data)
+			}
+			items[i] = s
+		}
+		return node.ParseValueList(typ, items)
+	}
+	if s, valid := jsonScalar(data); valid {
+		return node.ParseValue(typ, s)
+	}
+	return node.NewValue(typ, data)
+}
+
+func jsonScalar(data interface{}) (string, bool) {
+	switch x := data.(type) {
+	case string:
+		return x, true
+	case json.Number:
+		return x.String(), true
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(x), true
+	}
+	return "", false
+}
+
+// jsonEnumIds converts numbers to ids of enums
+func jsonEnumIds(data interface{}) interface{} {
+	switch x := data.(type) {
+	case json.Number:
+		if id, err := x.Int64(); err == nil {
+			return int(id)
+		}
+	case []interface{}:
+		ids := make([]interface{}, len(x))
+		for i, item := range x {
+			ids[i] = jsonEnumIds(item)
+		}
+		return ids
+	}
+	return data
 }
 
 func JsonListReader(list []interface{}) node.Node {
@@ -74,13 +136,12 @@ func JsonListReader(list []interface{}) node.Node {
 			if r.Row < len(list) {
 				container := list[r.Row].(map[string]interface{})
 				if len(r.Meta.KeyMeta()) > 0 {
-					keyData := make([]interface{}, len(r.Meta.KeyMeta()))
+					key = make([]val.Value, len(r.Meta.KeyMeta()))
 					for i, kmeta := range r.Meta.KeyMeta() {
 						// Key may legitimately not exist when inserting new data
-						keyData[i] = container[kmeta.Ident()]
-					}
-					if key, err = node.NewValues(r.Meta.KeyMeta(), keyData...); err != nil {
-						return nil, nil, err
+						if key[i], err = jsonValue(kmeta.Type(), container[kmeta.Ident()]); err != nil {
+							return nil, nil, err
+						}
 					}
 				}
 				return JsonContainerReader(container), key, nil
@@ -154,7 +215,8 @@ func JsonContainerReader(container map[string]interface{}) node.Node {
 
 func jsonKeyMatches(keyFields []meta.Leafable, candidate map[string]interface{}, key []val.Value) bool {
 	for i, field := range keyFields {
-		if candidate[field.Ident()] != key[i].String() {
+		v, err := jsonValue(field.Type(), candidate[field.Ident()])
+		if err != nil || !val.Equal(v, key[i]) {
 			return false
 		}
 	}
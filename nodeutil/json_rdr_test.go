@@ -6,6 +6,7 @@ import (
 	"github.com/freeconf/yang/fc"
 	"github.com/freeconf/yang/node"
 	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/val"
 )
 
 func TestJsonWalk(t *testing.T) {
@@ -89,6 +90,118 @@ func TestJsonRdrUnion(t *testing.T) {
 	}
 }
 
+func TestJsonRdrBitsBinary(t *testing.T) {
+	mstr := `
+	module x {
+		revision 0;
+		leaf b {
+			type bits {
+				bit one;
+				bit two;
+			}
+		}
+		leaf-list bl {
+			type bits {
+				bit one;
+				bit two;
+			}
+		}
+		leaf d {
+			type binary;
+		}
+	}
+		`
+	m, err := parser.LoadModuleFromString(nil, mstr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := WriteJSON(node.NewBrowser(m, ReadJSON(`{"b":"two one","bl":["one",""],"d":"aGk="}`)).Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, `{"b":"one two","bl":["one",""],"d":"aGk="}`, actual)
+}
+
+func TestJsonRdrCanonical(t *testing.T) {
+	mstr := `
+	module x {
+		revision 0;
+		list l {
+			key "id";
+			leaf id {
+				type uint32;
+			}
+			leaf on {
+				type boolean;
+			}
+			leaf-list d {
+				type decimal64 {
+					fraction-digits 1;
+				}
+			}
+		}
+	}
+		`
+	m, err := parser.LoadModuleFromString(nil, mstr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := `{"l":[{"id":"+07","on":true,"d":["+1.50",2]},{"id":10}]}`
+	b := node.NewBrowser(m, ReadJSON(in))
+	actual, err := WriteJSON(b.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, `{"l":[{"id":7,"on":true,"d":[1.5,2.0]},{"id":10}]}`, actual)
+
+	sel := b.Root().Find("l=7")
+	fc.AssertEqual(t, nil, sel.LastErr)
+	fc.AssertEqual(t, false, sel.IsNil())
+
+	invalid := []string{
+		`{"l":[{"id":"0x7"}]}`,
+		`{"l":[{"id":7.5}]}`,
+		`{"l":[{"id":7,"on":"True"}]}`,
+		`{"l":[{"id":7,"d":["1.55"]}]}`,
+	}
+	for _, in := range invalid {
+		if _, err := WriteJSON(node.NewBrowser(m, ReadJSON(in)).Root()); err == nil {
+			t.Errorf("%s expected error", in)
+		}
+	}
+}
+
+func TestJsonRdrLargeNumbers(t *testing.T) {
+	mstr := `
+	module x {
+		revision 0;
+		leaf u {
+			type uint64;
+		}
+		leaf i {
+			type int64;
+		}
+		leaf-list e {
+			type enumeration {
+				enum a;
+				enum b;
+			}
+		}
+	}
+		`
+	m, err := parser.LoadModuleFromString(nil, mstr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := `{"u":18446744073709551615,"i":-9223372036854775808,"e":[1,0]}`
+	b := node.NewBrowser(m, ReadJSON(in))
+	actual, err := WriteJSON(b.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, `{"u":18446744073709551615,"i":-9223372036854775808,"e":["b","a"]}`, actual)
+}
+
 func TestNumberParse(t *testing.T) {
 	moduleStr := `
 module json-test {
@@ -167,11 +280,6 @@ module json-test {
 	} else if found == nil {
 		t.Error("data/readings - Target not found, state nil")
 	} else {
-		expected := []float64{3.555454, 45.04545, 324545.04}
-		readings := found.([]float64)
-
-		if expected[0] != readings[0] || expected[1] != readings[1] || expected[2] != readings[2] {
-			t.Error(found.([]int), "!=", expected)
-		}
+		fc.AssertEqual(t, "[3.555454 45.04545 324545.04]", val.Decimal64List(found.([]val.Decimal64)).String())
 	}
 }
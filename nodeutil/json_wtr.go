@@ -28,6 +28,18 @@ type JSONWtr struct {
 	// useful to know that json reader can accept labels or values
 	EnumAsIds bool
 
+	// flush contents to stream after each list item is written instead of
+	// only at the end of operation. Useful for very large lists sent over
+	// chunked responses so client can start consuming data immediately
+	FlushListItems bool
+
+	// write each list item as it's own JSON object followed by a line feed,
+	// otherwise known as JSON Lines or NDJSON.  When writing a container
+	// or notification, entire object is written on a single line so writing
+	// each event to the same stream produces a valid JSON Lines stream.
+	// Pretty is ignored in this mode.
+	JSONLines bool
+
 	_out *bufio.Writer
 }
 
@@ -52,10 +64,15 @@ func (self *JSONWtr) Node() node.Node {
 	return &Extend{
 		Base: self.container(0),
 		OnBeginEdit: func(p node.Node, r node.NodeRequest) error {
+			isList := meta.IsList(r.Selection.Meta()) && !r.Selection.InsideList
+			if self.JSONLines && isList {
+				// each list item is written as it's own line
+				return nil
+			}
 			if err := self.beginObject(); err != nil {
 				return err
 			}
-			if meta.IsList(r.Selection.Meta()) && !r.Selection.InsideList {
+			if isList {
 				if err := self.beginList(r.Selection.Meta().Ident()); err != nil {
 					return err
 				}
@@ -63,13 +80,21 @@ func (self *JSONWtr) Node() node.Node {
 			return nil
 		},
 		OnEndEdit: func(p node.Node, r node.NodeRequest) error {
-			if meta.IsList(r.Selection.Meta()) && !r.Selection.InsideList {
-				if err := self.endList(); err != nil {
+			isList := meta.IsList(r.Selection.Meta()) && !r.Selection.InsideList
+			if !(self.JSONLines && isList) {
+				if isList {
+					if err := self.endList(); err != nil {
+						return err
+					}
+				}
+				if err := self.endContainer(); err != nil {
 					return err
 				}
-			}
-			if err := self.endContainer(); err != nil {
-				return err
+				if self.JSONLines {
+					if _, err := self._out.WriteRune('\n'); err != nil {
+						return err
+					}
+				}
 			}
 			if err := self._out.Flush(); err != nil {
 				return err
@@ -89,7 +114,7 @@ func (self *JSONWtr) container(lvl int) node.Node {
 		} else {
 			first = false
 		}
-		if self.Pretty {
+		if self.Pretty && !self.JSONLines {
 			self._out.WriteString("\n")
 			self._out.WriteString(padding[0:(2 * lvl)])
 		}
@@ -100,6 +125,9 @@ func (self *JSONWtr) container(lvl int) node.Node {
 		if !r.New {
 			return nil, nil
 		}
+		if err = self.checkCancel(r.Selection); err != nil {
+			return nil, err
+		}
 		if err = delim(); err != nil {
 			return nil, err
 		}
@@ -124,6 +152,9 @@ func (self *JSONWtr) container(lvl int) node.Node {
 			if err := self.endContainer(); err != nil {
 				return err
 			}
+			if r.Selection.InsideList {
+				return self.endListItem(lvl)
+			}
 		}
 		return nil
 	}
@@ -141,9 +172,16 @@ func (self *JSONWtr) container(lvl int) node.Node {
 		if !r.New {
 			return
 		}
-		if err = delim(); err != nil {
+		if err = self.checkCancel(r.Selection); err != nil {
 			return
 		}
+		// in JSON Lines mode, top-level list items are separated by line feeds
+		// that are written when item is complete
+		if !(self.JSONLines && lvl == 0) {
+			if err = delim(); err != nil {
+				return
+			}
+		}
 		if err = self.beginObject(); err != nil {
 			return
 		}
@@ -152,6 +190,28 @@ func (self *JSONWtr) container(lvl int) node.Node {
 	return s
 }
 
+// checkCancel stops walk when operation is no longer wanted, like when a
+// client disconnects in the middle of a large response
+func (self *JSONWtr) checkCancel(s node.Selection) error {
+	if s.Context == nil {
+		return nil
+	}
+	return s.Context.Err()
+}
+
+func (self *JSONWtr) endListItem(lvl int) (err error) {
+	// lvl 1 is item of a list that was the starting point of the write
+	if self.JSONLines && lvl == 1 {
+		if _, err = self._out.WriteRune('\n'); err != nil {
+			return
+		}
+	}
+	if self.FlushListItems {
+		err = self._out.Flush()
+	}
+	return
+}
+
 func (self *JSONWtr) beginList(ident string) (err error) {
 	if err = self.writeIdent(ident); err == nil {
 		_, err = self._out.WriteRune('[')
@@ -217,10 +277,14 @@ func (self *JSONWtr) writeValue(m meta.Definition, v val.Value) error {
 			}
 		}
 		switch item.Format() {
-		case val.FmtString, val.FmtIdentityRef:
+		case val.FmtString, val.FmtBits, val.FmtBinary:
 			if err := self.writeString(item.String()); err != nil {
 				return err
 			}
+		case val.FmtIdentityRef:
+			if err := self.writeString(jsonIdentRef(m, item.(val.IdentRef))); err != nil {
+				return err
+			}
 		case val.FmtEnum:
 			if self.EnumAsIds {
 				id := strconv.Itoa(item.(val.Enum).Id)
@@ -232,11 +296,6 @@ func (self *JSONWtr) writeValue(m meta.Definition, v val.Value) error {
 					return err
 				}
 			}
-		case val.FmtDecimal64:
-			f := item.Value().(float64)
-			if _, err := self._out.WriteString(strconv.FormatFloat(f, 'f', -1, 64)); err != nil {
-				return err
-			}
 		case val.FmtAny:
 			var data []byte
 			var err error
@@ -271,6 +330,18 @@ func (self *JSONWtr) writeValue(m meta.Definition, v val.Value) error {
 	return nil
 }
 
+// jsonIdentRef is identity with the module it is in from RFC7951 Section 6.8
+func jsonIdentRef(m meta.Definition, ref val.IdentRef) string {
+	if ref.Module == "" {
+		if l, valid := m.(meta.Leafable); valid && l.Type().Base() != nil {
+			if x := node.FindIdentity(l.Type().Base(), ref); x != nil {
+				ref.Module = x.Parent().(*meta.Module).Ident()
+			}
+		}
+	}
+	return ref.Qualified()
+}
+
 func (self *JSONWtr) writeString(s string) error {
 	clean := bytes.NewBuffer(make([]byte, len(s)+2))
 	clean.Reset()
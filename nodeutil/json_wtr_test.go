@@ -3,6 +3,7 @@ package nodeutil
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"testing"
 
@@ -153,3 +154,82 @@ func TestJsonAnyData(t *testing.T) {
 		fc.AssertEqual(t, test.expected, actual.String())
 	}
 }
+
+func TestJsonWtrLinesAndFlush(t *testing.T) {
+	mstr := `module m {
+	list l {
+		key "a";
+		leaf a {
+			type string;
+		}
+		list l2 {
+			leaf b {
+				type string;
+			}
+		}
+	}
+	container c {
+		leaf d {
+			type string;
+		}
+	}
+}`
+	m, err := parser.LoadModuleFromString(nil, mstr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := `{"l":[{"a":"x","l2":[{"b":"y"},{"b":"z"}]},{"a":"w"}],"c":{"d":"q"}}`
+	sel := node.NewBrowser(m, ReadJSON(data)).Root()
+
+	var actual bytes.Buffer
+	w := &JSONWtr{Out: &actual, JSONLines: true}
+	if err = sel.Find("l").InsertInto(w.Node()).LastErr; err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, "{\"a\":\"x\",\"l2\":[{\"b\":\"y\"},{\"b\":\"z\"}]}\n{\"a\":\"w\"}\n", actual.String())
+
+	actual.Reset()
+	if err = sel.Find("c").InsertInto(w.Node()).LastErr; err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, "{\"d\":\"q\"}\n", actual.String())
+
+	var flushes countingWriter
+	w = &JSONWtr{Out: &flushes, FlushListItems: true}
+	if err = sel.Find("l").InsertInto(w.Node()).LastErr; err != nil {
+		t.Fatal(err)
+	}
+	// one for each of the 4 list items and one at the end
+	fc.AssertEqual(t, 5, flushes.writes)
+}
+
+func TestJsonWtrCancel(t *testing.T) {
+	mstr := `module m {
+	list l {
+		leaf a {
+			type string;
+		}
+	}
+}`
+	m, err := parser.LoadModuleFromString(nil, mstr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := `{"l":[{"a":"x"},{"a":"y"}]}`
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sel := node.NewBrowser(m, ReadJSON(data)).RootWithContext(ctx)
+	var actual bytes.Buffer
+	w := &JSONWtr{Out: &actual}
+	err = sel.InsertInto(w.Node()).LastErr
+	fc.AssertEqual(t, context.Canceled, err)
+}
+
+type countingWriter struct {
+	writes int
+}
+
+func (self *countingWriter) Write(p []byte) (int, error) {
+	self.writes++
+	return len(p), nil
+}
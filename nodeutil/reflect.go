@@ -426,6 +426,17 @@ func WriteFieldWithFieldName(fieldName string, m meta.Leafable, ptrVal reflect.V
 	if v == nil {
 		panic(fmt.Sprintf("No value given to set %s", m.Ident()))
 	}
+	if !reflect.TypeOf(v.Value()).AssignableTo(fieldVal.Type()) {
+		// field may be Go type of a typedef like time.Time
+		goVal, err := node.GoValue(m.Type(), v)
+		if err != nil {
+			return err
+		}
+		if reflect.TypeOf(goVal).AssignableTo(fieldVal.Type()) {
+			fieldVal.Set(reflect.ValueOf(goVal))
+			return nil
+		}
+	}
 	switch v.Format() {
 	case val.FmtIdentityRef:
 		e := v.(val.IdentRef)
@@ -455,12 +466,71 @@ func WriteFieldWithFieldName(fieldName string, m meta.Leafable, ptrVal reflect.V
 		case reflect.String:
 			fieldVal.Set(reflect.ValueOf(el.Labels()))
 		}
+	case val.FmtBits:
+		b := v.(val.Bits)
+		switch fieldVal.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			fieldVal.SetInt(int64(bitmask(b)))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			fieldVal.SetUint(bitmask(b))
+		case reflect.Slice:
+			fieldVal.Set(reflect.ValueOf(b.Labels()))
+		default:
+			fieldVal.Set(reflect.ValueOf(b))
+		}
+	case val.FmtBitsList:
+		bl := v.(val.BitsList)
+		switch fieldVal.Type().Elem().Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			ml := reflect.MakeSlice(fieldVal.Type(), len(bl), len(bl))
+			for i, b := range bl {
+				ml.Index(i).SetInt(int64(bitmask(b)))
+			}
+			fieldVal.Set(ml)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			ml := reflect.MakeSlice(fieldVal.Type(), len(bl), len(bl))
+			for i, b := range bl {
+				ml.Index(i).SetUint(bitmask(b))
+			}
+			fieldVal.Set(ml)
+		default:
+			fieldVal.Set(reflect.ValueOf(bl))
+		}
+	case val.FmtDecimal64:
+		d := v.(val.Decimal64)
+		switch fieldVal.Kind() {
+		case reflect.Float32, reflect.Float64:
+			fieldVal.SetFloat(d.Float64())
+		default:
+			fieldVal.Set(reflect.ValueOf(d))
+		}
+	case val.FmtDecimal64List:
+		dl := v.(val.Decimal64List)
+		switch fieldVal.Type().Elem().Kind() {
+		case reflect.Float32, reflect.Float64:
+			fl := reflect.MakeSlice(fieldVal.Type(), len(dl), len(dl))
+			for i, d := range dl {
+				fl.Index(i).SetFloat(d.Float64())
+			}
+			fieldVal.Set(fl)
+		default:
+			fieldVal.Set(reflect.ValueOf(v.Value()))
+		}
 	default:
 		fieldVal.Set(reflect.ValueOf(v.Value()))
 	}
 	return nil
 }
 
+// bitmask has each bit set at the position of the bit
+func bitmask(b val.Bits) uint64 {
+	var mask uint64
+	for _, bit := range b {
+		mask |= 1 << uint(bit.Position)
+	}
+	return mask
+}
+
 func ReadField(m meta.Leafable, ptrVal reflect.Value) (val.Value, error) {
 	return ReadFieldWithFieldName(MetaNameToFieldName(m.Ident()), m, ptrVal)
 }
@@ -486,12 +556,25 @@ func ReadFieldWithFieldName(fieldName string, m meta.Leafable, ptrVal reflect.Va
 
 	switch dt.Format() {
 	case val.FmtString:
-		s := fieldVal.String()
-		if len(s) == 0 {
-			return nil, nil
+		switch fieldVal.Kind() {
+		case reflect.String:
+			s := fieldVal.String()
+			if len(s) == 0 {
+				return nil, nil
+			}
+			return val.String(s), nil
+		case reflect.Slice, reflect.Ptr:
+			// Go type of a typedef like net.IP
+			if fieldVal.IsNil() {
+				return nil, nil
+			}
+		case reflect.Struct:
+			// Go type of a typedef like time.Time
+			if reflect.DeepEqual(fieldVal.Interface(), reflect.Zero(fieldVal.Type()).Interface()) {
+				return nil, nil
+			}
 		}
-		return val.String(s), nil
-	case val.FmtAny:
+	case val.FmtAny, val.FmtBinary:
 		if fieldVal.IsNil() {
 			return nil, nil
 		}
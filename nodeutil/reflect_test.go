@@ -242,6 +242,44 @@ func Test_Reflect2Read(t *testing.T) {
 	}
 }
 
+func TestReflectBitsBinary(t *testing.T) {
+	mstr := `module x {
+		revision 0;
+		leaf flags {
+			type bits {
+				bit one;
+				bit two;
+				bit four {
+					position 2;
+				}
+			}
+		}
+		leaf photo {
+			type binary;
+		}
+	}`
+	m, err := parser.LoadModuleFromString(nil, mstr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := struct {
+		Flags int
+		Photo []byte
+	}{}
+	b := node.NewBrowser(m, nodeutil.ReflectChild(&obj))
+	if err := b.Root().UpsertFrom(nodeutil.ReadJSON(`{"flags":"four one","photo":"aGk="}`)).LastErr; err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, 5, obj.Flags)
+	fc.AssertEqual(t, "hi", string(obj.Photo))
+	obj.Flags = 2
+	actual, err := nodeutil.WriteJSON(b.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, `{"flags":"two","photo":"aGk="}`, actual)
+}
+
 type TestMessage struct {
 	Message struct {
 		Hello string
@@ -0,0 +1,255 @@
+package nodeutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/val"
+)
+
+// SetRdr applies lines in the format SetWtr writes to a selection.  Each "set"
+// line is merged into selection creating containers and list items as needed
+// and each "delete" line removes the leaf, container, list item or list at the
+// given path.  Lines are applied in order, blank lines and lines starting with
+// '#' are ignored.  Paths are validated against the schema before any edit
+// is made for that line.
+//
+// Example:
+//    set interface=eth0/mtu 9000
+//    delete interface=eth1
+type SetRdr struct {
+	In io.Reader
+}
+
+// ReadSet applies set lines in data to selection
+func ReadSet(s node.Selection, data string) error {
+	rdr := &SetRdr{In: strings.NewReader(data)}
+	return rdr.Apply(s)
+}
+
+// ReadSetIO applies set lines read from stream to selection
+func ReadSetIO(s node.Selection, in io.Reader) error {
+	rdr := &SetRdr{In: in}
+	return rdr.Apply(s)
+}
+
+// Apply each line in stream to selection stopping at first error
+func (self *SetRdr) Apply(s node.Selection) error {
+	if s.LastErr != nil {
+		return s.LastErr
+	}
+	scanner := bufio.NewScanner(self.In)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := self.applyLine(s, line); err != nil {
+			return fmt.Errorf("line %d: %w", lineNum, err)
+		}
+	}
+	return scanner.Err()
+}
+
+func (self *SetRdr) applyLine(s node.Selection, line string) error {
+	tokens, err := setTokens(line)
+	if err != nil {
+		return err
+	}
+	if len(tokens) < 2 {
+		return fmt.Errorf("%w. expected '<set|delete> <path> [value]'", fc.BadRequestError)
+	}
+	switch tokens[0].text {
+	case "set":
+		return self.set(s, tokens[1].text, tokens[2:])
+	case "delete":
+		if len(tokens) > 2 {
+			return fmt.Errorf("%w. unexpected value after delete path", fc.BadRequestError)
+		}
+		return self.delete(s, tokens[1].text)
+	}
+	return fmt.Errorf("%w. unknown operation '%s'", fc.BadRequestError, tokens[0].text)
+}
+
+func (self *SetRdr) set(s node.Selection, path string, tokens []setToken) error {
+	slice, err := node.ParsePath(path, s.Meta().(meta.HasDefinitions))
+	if err != nil {
+		return err
+	}
+	m, valid := slice.Tail.Meta().(meta.Leafable)
+	if !valid {
+		return fmt.Errorf("%w. %s is not a leaf", fc.BadRequestError, path)
+	}
+	v, err := setParseValue(m, tokens)
+	if err != nil {
+		return fmt.Errorf("%s : %w", path, err)
+	}
+	return s.UpsertFrom(setFrom(slice.Segments(), v)).LastErr
+}
+
+func (self *SetRdr) delete(s node.Selection, path string) error {
+	slice, err := node.ParsePath(path, s.Meta().(meta.HasDefinitions))
+	if err != nil {
+		return err
+	}
+	if slice.Empty() {
+		return fmt.Errorf("%w. cannot delete selection itself", fc.BadRequestError)
+	}
+	if m, isLeaf := slice.Tail.Meta().(meta.Leafable); isLeaf {
+		parent := s.FindSlice(node.PathSlice{Head: slice.Head, Tail: slice.Tail.Parent()})
+		if parent.LastErr != nil || parent.IsNil() {
+			return parent.LastErr
+		}
+		return parent.ClearField(m)
+	}
+	target := s.FindSlice(slice)
+	if target.LastErr != nil || target.IsNil() {
+		return target.LastErr
+	}
+	return target.Delete()
+}
+
+// setFrom builds a node that has nothing but the path down to a single leaf
+// value so it can be merged into existing data.
+func setFrom(segs []*node.Path, v val.Value) node.Node {
+	seg := segs[0]
+	n := &Basic{}
+	n.OnChoose = func(sel node.Selection, choice *meta.Choice) (*meta.ChoiceCase, error) {
+		return setChosen(choice, seg.Meta()), nil
+	}
+	n.OnChild = func(r node.ChildRequest) (node.Node, error) {
+		if len(segs) == 1 || r.Meta != seg.Meta() {
+			return nil, nil
+		}
+		if meta.IsList(r.Meta) {
+			return setFromList(segs, v), nil
+		}
+		return setFrom(segs[1:], v), nil
+	}
+	n.OnField = func(r node.FieldRequest, hnd *node.ValueHandle) error {
+		if len(segs) == 1 && r.Meta == seg.Meta() {
+			hnd.Val = v
+		}
+		return nil
+	}
+	return n
+}
+
+func setFromList(segs []*node.Path, v val.Value) node.Node {
+	seg := segs[0]
+	return &Basic{
+		OnNext: func(r node.ListRequest) (node.Node, []val.Value, error) {
+			if r.Key != nil {
+				if !val.EqualVals(r.Key, seg.Key()) {
+					return nil, nil, nil
+				}
+			} else if r.Row > 0 {
+				return nil, nil, nil
+			}
+			// list is never last segment because path ends in a leaf
+			return &Extend{
+				Base: setFrom(segs[1:], v),
+				OnField: func(p node.Node, r node.FieldRequest, hnd *node.ValueHandle) error {
+					for i, k := range seg.Meta().(*meta.List).KeyMeta() {
+						if k == r.Meta && i < len(seg.Key()) {
+							hnd.Val = seg.Key()[i]
+							return nil
+						}
+					}
+					return p.Field(r, hnd)
+				},
+			}, seg.Key(), nil
+		},
+	}
+}
+
+// setChosen finds the case in choice that contains m, if any
+func setChosen(choice *meta.Choice, m meta.Meta) *meta.ChoiceCase {
+	var p meta.Meta = m
+	for p != nil {
+		if kase, valid := p.(*meta.ChoiceCase); valid && kase.Parent() == choice {
+			return kase
+		}
+		p = p.Parent()
+	}
+	return nil
+}
+
+func setParseValue(m meta.Leafable, tokens []setToken) (val.Value, error) {
+	if m.Type().Format().IsList() {
+		var items []string
+		if len(tokens) > 1 && tokens[0].isOpen() {
+			if !tokens[len(tokens)-1].isClose() {
+				return nil, fmt.Errorf("%w. missing ']'", fc.BadRequestError)
+			}
+			tokens = tokens[1 : len(tokens)-1]
+		}
+		for _, t := range tokens {
+			items = append(items, t.text)
+		}
+		return node.ParseValueList(m.Type(), items)
+	}
+	if len(tokens) != 1 {
+		return nil, fmt.Errorf("%w. expected a single value", fc.BadRequestError)
+	}
+	return node.ParseValue(m.Type(), tokens[0].text)
+}
+
+type setToken struct {
+	text   string
+	quoted bool
+}
+
+func (t setToken) isOpen() bool {
+	return !t.quoted && t.text == "["
+}
+
+func (t setToken) isClose() bool {
+	return !t.quoted && t.text == "]"
+}
+
+// setTokens splits line on spaces, text in double quotes is one token
+func setTokens(line string) ([]setToken, error) {
+	var tokens []setToken
+	i := 0
+	for i < len(line) {
+		if unicode.IsSpace(rune(line[i])) {
+			i++
+			continue
+		}
+		if line[i] != '"' {
+			end := strings.IndexFunc(line[i:], unicode.IsSpace)
+			if end < 0 {
+				end = len(line) - i
+			}
+			tokens = append(tokens, setToken{text: line[i : i+end]})
+			i += end
+			continue
+		}
+		end := i + 1
+		for ; end < len(line) && line[end] != '"'; end++ {
+			if line[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(line) {
+			return nil, fmt.Errorf("%w. missing closing quote", fc.BadRequestError)
+		}
+		text, err := strconv.Unquote(line[i : end+1])
+		if err != nil {
+			return nil, fmt.Errorf("%w. %s", fc.BadRequestError, err)
+		}
+		tokens = append(tokens, setToken{text: text, quoted: true})
+		i = end + 1
+	}
+	return tokens, nil
+}
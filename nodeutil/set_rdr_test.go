@@ -0,0 +1,54 @@
+package nodeutil
+
+import (
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/parser"
+)
+
+func TestSetRdr(t *testing.T) {
+	m, err := parser.LoadModuleFromString(nil, setTestModule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := node.NewBrowser(m, ReflectChild(make(map[string]interface{})))
+	sel := b.Root()
+	data := `
+# comment
+set interface=eth0/mtu 9000
+set interface=eth0/description "uplink to core"
+set interface=eth1/mtu 1500
+set interface=a%2Fb/mtu 1
+set dns/server [ 10.0.0.1 "10.0.0.2" ]
+set dns/udp true
+set dns/tcp true
+delete interface=eth1
+delete interface=eth0/description
+`
+	if err := ReadSet(sel, data); err != nil {
+		t.Fatal(err)
+	}
+	actual, err := WriteJSON(sel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"interface":[{"name":"a/b","mtu":1},{"name":"eth0","mtu":9000}],"dns":{"server":["10.0.0.1","10.0.0.2"],"tcp":true}}`
+	fc.AssertEqual(t, expected, actual)
+
+	errs := []string{
+		"set bogus 1",
+		"set interface=eth0/mtu x",
+		"set interface=eth0 1",
+		"set dns/server [ a",
+		"set interface=eth0/description \"unterminated",
+		"rename interface=eth0/mtu",
+		"set",
+	}
+	for _, bad := range errs {
+		if err := ReadSet(sel, bad); err == nil {
+			t.Error("expected error for ", bad)
+		}
+	}
+}
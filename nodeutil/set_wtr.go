@@ -0,0 +1,132 @@
+package nodeutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/val"
+)
+
+// SetWtr writes data as one line per leaf in the style of network equipment
+// configuration files.  Paths are relative to the selection and are in the same
+// format node.ParsePath accepts. Values are quoted when they contain spaces and
+// leaf-lists are written in brackets.  To only write configuration use the
+// "content=config" constraint on the selection.
+//
+// Example:
+//    set interface=eth0/name eth0
+//    set interface=eth0/mtu 9000
+//    set interface=eth0/description "uplink to core"
+//    set dns/server [ 10.0.0.1 10.0.0.2 ]
+type SetWtr struct {
+
+	// stream to write contents.
+	Out io.Writer
+}
+
+// WriteSet writes selection as set lines to a string
+func WriteSet(s node.Selection) (string, error) {
+	buff := new(bytes.Buffer)
+	wtr := &SetWtr{Out: buff}
+	err := wtr.Write(s)
+	return buff.String(), err
+}
+
+// Write selection into stream
+func (self *SetWtr) Write(s node.Selection) error {
+	if s.LastErr != nil {
+		return s.LastErr
+	}
+	if meta.IsList(s.Meta()) && !s.InsideList {
+		return fmt.Errorf("%w. select a list item or the parent of list %s", fc.BadRequestError, s.Path)
+	}
+	return s.InsertInto(self.container()).LastErr
+}
+
+func (self *SetWtr) container() node.Node {
+	n := &Basic{}
+	n.OnChild = func(r node.ChildRequest) (node.Node, error) {
+		if !r.New {
+			return nil, nil
+		}
+		if meta.IsList(r.Meta) {
+			return self.list(), nil
+		}
+		return self.container(), nil
+	}
+	n.OnField = func(r node.FieldRequest, hnd *node.ValueHandle) error {
+		if hnd.Val == nil {
+			return nil
+		}
+		_, err := fmt.Fprintf(self.Out, "set %s %s\n", setPath(r.Base, r.Path), setValue(hnd.Val))
+		return err
+	}
+	return n
+}
+
+func (self *SetWtr) list() node.Node {
+	return &Basic{
+		OnNext: func(r node.ListRequest) (node.Node, []val.Value, error) {
+			if !r.New {
+				return nil, nil, nil
+			}
+			return self.container(), r.Key, nil
+		},
+	}
+}
+
+// setPath is the path from base to p with keys escaped so path has no spaces
+// and keys with '/' or ',' survive being parsed again.
+func setPath(base *node.Path, p *node.Path) string {
+	segs := p.Segments()[base.Len():]
+	strs := make([]string, len(segs))
+	for i, seg := range segs {
+		var b strings.Builder
+		b.WriteString(seg.Meta().Ident())
+		for j, k := range seg.Key() {
+			if j == 0 {
+				b.WriteRune('=')
+			} else {
+				b.WriteRune(',')
+			}
+			b.WriteString(url.QueryEscape(k.String()))
+		}
+		strs[i] = b.String()
+	}
+	return strings.Join(strs, "/")
+}
+
+func setValue(v val.Value) string {
+	if !v.Format().IsList() {
+		return setQuote(v.String())
+	}
+	var b strings.Builder
+	b.WriteRune('[')
+	val.ForEach(v, func(i int, item val.Value) {
+		b.WriteRune(' ')
+		b.WriteString(setQuote(item.String()))
+	})
+	b.WriteString(" ]")
+	return b.String()
+}
+
+// setQuote quotes values that would otherwise be split or mistaken for list
+// brackets when read back in
+func setQuote(s string) string {
+	if s == "" || s == "[" || s == "]" || strings.IndexFunc(s, setNeedsQuote) >= 0 {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func setNeedsQuote(r rune) bool {
+	return r == '"' || r == '\\' || unicode.IsSpace(r) || !unicode.IsPrint(r)
+}
@@ -0,0 +1,74 @@
+package nodeutil
+
+import (
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/parser"
+)
+
+const setTestModule = `module m {
+	list interface {
+		key "name";
+		leaf name {
+			type string;
+		}
+		leaf mtu {
+			type int32;
+		}
+		leaf description {
+			type string;
+		}
+	}
+	container dns {
+		leaf-list server {
+			type string;
+		}
+		choice transport {
+			leaf udp {
+				type boolean;
+			}
+			leaf tcp {
+				type boolean;
+			}
+		}
+	}
+}`
+
+func TestSetWtr(t *testing.T) {
+	m, err := parser.LoadModuleFromString(nil, setTestModule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := `{
+		"interface":[
+			{"name":"eth0","mtu":9000,"description":"uplink to core"},
+			{"name":"a/b"}
+		],
+		"dns":{"server":["10.0.0.1","10.0.0.2"],"tcp":true}
+	}`
+	sel := node.NewBrowser(m, ReadJSON(data)).Root()
+	actual, err := WriteSet(sel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `set interface=eth0/name eth0
+set interface=eth0/mtu 9000
+set interface=eth0/description "uplink to core"
+set interface=a%2Fb/name a/b
+set dns/server [ 10.0.0.1 10.0.0.2 ]
+set dns/tcp true
+`
+	fc.AssertEqual(t, expected, actual)
+
+	actual, err = WriteSet(sel.Find("interface=eth0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, "set name eth0\nset mtu 9000\nset description \"uplink to core\"\n", actual)
+
+	if _, err := WriteSet(sel.Find("interface")); err == nil {
+		t.Error("expected error writing list")
+	}
+}
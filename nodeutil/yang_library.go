@@ -0,0 +1,367 @@
+package nodeutil
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/source"
+)
+
+// YangLibraryModuleSet is the name of the module set, schema all datastores use
+const YangLibraryModuleSet = "complete"
+
+// YangLibrary implements ietf-yang-library (RFC 8525) describing the modules
+// a server implements.  Use with the ietf-yang-library.yang file in this
+// repository.
+//
+// All modules are in a single module set and schema that is used by the
+// running and operational datastores.  Features are reported as enabled
+// according to the feature set each module was loaded with, modules with
+// deviations to implemented modules are reported as implemented and modules
+// only imported by the implemented modules are reported as import-only.
+type YangLibrary struct {
+
+	// Modules implemented by server
+	Modules []*meta.Module
+
+	// Location of YANG file of a module or submodule that clients can use to
+	// retrieve the schema. Typically served by a handler that calls Schema.
+	// Optional.
+	Location func(name string, rev string) string
+
+	// Source of original YANG files returned by Schema. Optional.
+	Source source.Opener
+
+	mu        sync.Mutex
+	listeners map[int]node.NotifyRequest
+	nextId    int
+}
+
+// Node of ietf-yang-library data and yang-library-update notification
+func (lib *YangLibrary) Node() node.Node {
+	return &Basic{
+		OnChild: func(r node.ChildRequest) (node.Node, error) {
+			switch r.Meta.Ident() {
+			case "yang-library":
+				return JsonContainerReader(lib.library()), nil
+			}
+			return nil, nil
+		},
+		OnNotify: func(r node.NotifyRequest) (node.NotifyCloser, error) {
+			switch r.Meta.Ident() {
+			case "yang-library-update":
+				return lib.subscribe(r), nil
+			}
+			return nil, fmt.Errorf("%w. %s", fc.NotImplementedError, r.Meta.Ident())
+		},
+	}
+}
+
+// Update replaces the implemented modules and sends yang-library-update
+// notification if that changes the content-id
+func (lib *YangLibrary) Update(modules []*meta.Module) {
+	lib.mu.Lock()
+	before := lib.contentId()
+	lib.Modules = modules
+	after := lib.contentId()
+	var listeners []node.NotifyRequest
+	for _, l := range lib.listeners {
+		listeners = append(listeners, l)
+	}
+	lib.mu.Unlock()
+	if before == after {
+		return
+	}
+	msg := map[string]interface{}{
+		"content-id": after,
+	}
+	for _, l := range listeners {
+		l.Send(JsonContainerReader(msg))
+	}
+}
+
+func (lib *YangLibrary) subscribe(r node.NotifyRequest) node.NotifyCloser {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+	if lib.listeners == nil {
+		lib.listeners = make(map[int]node.NotifyRequest)
+	}
+	id := lib.nextId
+	lib.nextId++
+	lib.listeners[id] = r
+	return func() error {
+		lib.mu.Lock()
+		defer lib.mu.Unlock()
+		delete(lib.listeners, id)
+		return nil
+	}
+}
+
+// ContentId changes when any information about the modules reported in the
+// library changes
+func (lib *YangLibrary) ContentId() string {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+	return lib.contentId()
+}
+
+func (lib *YangLibrary) contentId() string {
+	h := sha1.New()
+	for _, m := range lib.implemented() {
+		fmt.Fprintf(h, "module %s %s %s\n", m.Ident(), moduleRevision(m), m.Namespace())
+		for _, f := range enabledFeatures(m) {
+			fmt.Fprintf(h, "feature %s\n", f)
+		}
+		for _, d := range deviatedBy(m) {
+			fmt.Fprintf(h, "deviation %s\n", d)
+		}
+		for _, i := range m.Includes() {
+			fmt.Fprintf(h, "submodule %s %s\n", i.Name(), includeRevision(i))
+		}
+	}
+	for _, m := range lib.importOnly() {
+		fmt.Fprintf(h, "import %s %s\n", m.Ident(), moduleRevision(m))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// Schema is the original YANG source of an implemented or imported module or
+// a submodule of one of those modules. Useful to serve the location of a
+// module.
+func (lib *YangLibrary) Schema(name string, rev string) (io.Reader, error) {
+	if lib.Source == nil {
+		return nil, fmt.Errorf("%w. no yang source", fc.NotImplementedError)
+	}
+	if !lib.reported(name, rev) {
+		return nil, fmt.Errorf("%w. %s is not in yang library", fc.NotFoundError, name)
+	}
+	if rev != "" {
+		rdr, err := lib.Source(name+"@"+rev, ".yang")
+		if rdr != nil || err != nil {
+			return rdr, err
+		}
+	}
+	rdr, err := lib.Source(name, ".yang")
+	if rdr == nil && err == nil {
+		err = fmt.Errorf("%w. yang source for %s", fc.NotFoundError, name)
+	}
+	return rdr, err
+}
+
+// reported only allows retrieving the source of modules in the library so
+// clients cannot read any other files
+func (lib *YangLibrary) reported(name string, rev string) bool {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+	for _, m := range append(lib.implemented(), lib.importOnly()...) {
+		if m.Ident() == name && (rev == "" || rev == moduleRevision(m)) {
+			return true
+		}
+		for _, i := range m.Includes() {
+			if i.Name() == name && (rev == "" || rev == includeRevision(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// implemented are the modules given and the modules with deviations to them
+// as RFC 8525 requires deviation modules to be implemented
+func (lib *YangLibrary) implemented() []*meta.Module {
+	found := make(map[string]*meta.Module)
+	for _, m := range lib.Modules {
+		for _, d := range m.DeviatedBy() {
+			found[d.Ident()] = d
+		}
+	}
+	for _, m := range lib.Modules {
+		found[m.Ident()] = m
+	}
+	var modules []*meta.Module
+	for _, m := range found {
+		modules = append(modules, m)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Ident() < modules[j].Ident()
+	})
+	return modules
+}
+
+// importOnly are modules imported directly or indirectly that are not
+// implemented
+func (lib *YangLibrary) importOnly() []*meta.Module {
+	implemented := make(map[string]bool)
+	for _, m := range lib.implemented() {
+		implemented[m.Ident()] = true
+	}
+	found := make(map[string]*meta.Module)
+	var walk func(m *meta.Module)
+	walk = func(m *meta.Module) {
+		for _, i := range m.Imports() {
+			imported := i.Module()
+			if imported == nil || implemented[imported.Ident()] {
+				continue
+			}
+			key := imported.Ident() + "@" + moduleRevision(imported)
+			if _, seen := found[key]; !seen {
+				found[key] = imported
+				walk(imported)
+			}
+		}
+	}
+	for _, m := range lib.implemented() {
+		walk(m)
+	}
+	var keys []string
+	for key := range found {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	modules := make([]*meta.Module, len(keys))
+	for i, key := range keys {
+		modules[i] = found[key]
+	}
+	return modules
+}
+
+func (lib *YangLibrary) library() map[string]interface{} {
+	lib.mu.Lock()
+	defer lib.mu.Unlock()
+	var modules []interface{}
+	for _, m := range lib.implemented() {
+		data := lib.module(m)
+		data["namespace"] = m.Namespace()
+		if features := enabledFeatures(m); len(features) > 0 {
+			data["feature"] = strList(features)
+		}
+		if deviations := deviatedBy(m); len(deviations) > 0 {
+			data["deviation"] = strList(deviations)
+		}
+		modules = append(modules, data)
+	}
+	var importOnly []interface{}
+	for _, m := range lib.importOnly() {
+		data := lib.module(m)
+		// revision is part of key so it is required
+		data["revision"] = moduleRevision(m)
+		data["namespace"] = m.Namespace()
+		importOnly = append(importOnly, data)
+	}
+	set := map[string]interface{}{
+		"name": YangLibraryModuleSet,
+	}
+	if len(modules) > 0 {
+		set["module"] = modules
+	}
+	if len(importOnly) > 0 {
+		set["import-only-module"] = importOnly
+	}
+	return map[string]interface{}{
+		"module-set": []interface{}{set},
+		"schema": []interface{}{
+			map[string]interface{}{
+				"name":       YangLibraryModuleSet,
+				"module-set": []interface{}{YangLibraryModuleSet},
+			},
+		},
+		"datastore": []interface{}{
+			map[string]interface{}{
+				"name":   "ietf-datastores:running",
+				"schema": YangLibraryModuleSet,
+			},
+			map[string]interface{}{
+				"name":   "ietf-datastores:operational",
+				"schema": YangLibraryModuleSet,
+			},
+		},
+		"content-id": lib.contentId(),
+	}
+}
+
+// module has the leafs common to implemented and import-only modules
+func (lib *YangLibrary) module(m *meta.Module) map[string]interface{} {
+	data := lib.identification(m.Ident(), moduleRevision(m))
+	var submodules []interface{}
+	for _, i := range m.Includes() {
+		submodules = append(submodules, lib.identification(i.Name(), includeRevision(i)))
+	}
+	if len(submodules) > 0 {
+		data["submodule"] = submodules
+	}
+	return data
+}
+
+func (lib *YangLibrary) identification(name string, rev string) map[string]interface{} {
+	data := map[string]interface{}{
+		"name": name,
+	}
+	if rev != "" {
+		data["revision"] = rev
+	}
+	if lib.Location != nil {
+		if loc := lib.Location(name, rev); loc != "" {
+			data["location"] = []interface{}{loc}
+		}
+	}
+	return data
+}
+
+func moduleRevision(m *meta.Module) string {
+	if r := m.Revision(); r != nil {
+		return r.Ident()
+	}
+	return ""
+}
+
+func includeRevision(i *meta.Include) string {
+	if r := i.Revision(); r != nil {
+		return r.Ident()
+	}
+	return ""
+}
+
+func enabledFeatures(m *meta.Module) []string {
+	var features []string
+	for ident := range m.Features() {
+		if on, err := m.FeatureEnabled(ident); err == nil && on {
+			features = append(features, ident)
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+func deviatedBy(m *meta.Module) []string {
+	var deviations []string
+	for _, d := range m.DeviatedBy() {
+		deviations = append(deviations, d.Ident())
+	}
+	sort.Strings(deviations)
+	return deviations
+}
+
+func strList(l []string) []interface{} {
+	items := make([]interface{}, len(l))
+	for i, s := range l {
+		items[i] = s
+	}
+	return items
+}
+
+// YangLibraryLocation is a Location for YangLibrary that serves YANG files
+// from a base URL such as "https://server/schema/" as base + name@rev.yang
+func YangLibraryLocation(base string) func(name string, rev string) string {
+	return func(name string, rev string) string {
+		if rev == "" {
+			return base + name + ".yang"
+		}
+		return base + name + "@" + rev + ".yang"
+	}
+}
@@ -0,0 +1,65 @@
+package nodeutil_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/node"
+	"github.com/freeconf/yang/nodeutil"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+func TestYangLibrary(t *testing.T) {
+	ylib := parser.RequireModule(source.Dir("../yang"), "ietf-yang-library")
+	ypath := source.Dir("./testdata/library")
+	car, err := parser.LoadModuleWithOptions(ypath, "car", parser.Options{
+		Features:   meta.FeaturesOff([]string{"sunroof"}),
+		Deviations: []string{"car-deviations"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	radio := parser.RequireModule(ypath, "radio")
+	lib := &nodeutil.YangLibrary{
+		Modules:  []*meta.Module{car},
+		Location: nodeutil.YangLibraryLocation("https://example.com/schema/"),
+		Source:   ypath,
+	}
+	b := node.NewBrowser(ylib, lib.Node())
+	actual, err := nodeutil.WritePrettyJSON(b.Root())
+	fc.AssertEqual(t, nil, err)
+	fc.Gold(t, *updateFlag, []byte(actual), "gold/yang-library.json")
+
+	// schema
+	rdr, err := lib.Schema("car-types", "2020-01-01")
+	fc.AssertEqual(t, nil, err)
+	src, _ := ioutil.ReadAll(rdr)
+	fc.AssertEqual(t, true, strings.HasPrefix(string(src), "module car-types {"))
+	_, err = lib.Schema("car-types", "1999-01-01")
+	fc.AssertEqual(t, true, err != nil)
+	_, err = lib.Schema("../car", "")
+	fc.AssertEqual(t, true, err != nil)
+
+	// update
+	var updates []string
+	sel := b.Root().Find("yang-library-update")
+	closer, err := sel.Notifications(func(msg node.Selection) {
+		id, _ := msg.Get("content-id")
+		updates = append(updates, id.(string))
+	})
+	fc.AssertEqual(t, nil, err)
+	before := lib.ContentId()
+	lib.Update([]*meta.Module{car})
+	fc.AssertEqual(t, 0, len(updates))
+	lib.Update([]*meta.Module{car, radio})
+	fc.AssertEqual(t, 1, len(updates))
+	fc.AssertEqual(t, lib.ContentId(), updates[0])
+	fc.AssertEqual(t, true, before != updates[0])
+	closer()
+	lib.Update([]*meta.Module{car})
+	fc.AssertEqual(t, 1, len(updates))
+}
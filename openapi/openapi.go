@@ -0,0 +1,386 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/freeconf/yang/meta"
+)
+
+// Wtr writes an OpenAPI 3 document describing the RESTCONF API of a module
+// as served by freeconf.  Every container gets GET, PUT, PATCH and DELETE
+// operations, every list gets GET and POST to create an item and every list
+// item, addressed by it's keys, gets GET, PUT, PATCH and DELETE. Lists
+// without keys are only described as a whole as their items and anything in
+// them cannot be addressed. Read-only definitions only get GET.  Each rpc and action is a POST with the input as
+// the request body and output as the response.
+//
+// Request and response bodies are the JSON freeconf reads and writes, that is
+// the contents of the container or list item without a wrapping object.
+// Notifications are not described because they are delivered as event
+// streams that OpenAPI has no way of describing.
+//
+// Module must be compiled.
+type Wtr struct {
+	Out io.Writer
+
+	// Title of API, default is module name
+	Title string
+
+	// Root of RESTCONF API, default is "/restconf"
+	Root string
+
+	// adds extra indenting and line feeds
+	Pretty bool
+}
+
+// WriteOpenAPI writes OpenAPI document for module to a string
+func WriteOpenAPI(m *meta.Module) (string, error) {
+	var buf bytes.Buffer
+	w := &Wtr{Out: &buf, Pretty: true}
+	err := w.Write(m)
+	return buf.String(), err
+}
+
+// Write OpenAPI document for module to stream
+func (w *Wtr) Write(m *meta.Module) error {
+	b := &builder{
+		module:  m,
+		root:    w.Root,
+		schemas: make(map[string]*schema),
+		names:   make(map[meta.Definition]string),
+		doc: &document{
+			OpenAPI: "3.0.3",
+			Info: info{
+				Title:       w.Title,
+				Description: m.Description(),
+				Version:     "1",
+			},
+			Paths: make(map[string]*pathItem),
+		},
+	}
+	if b.root == "" {
+		b.root = "/restconf"
+	}
+	if b.doc.Info.Title == "" {
+		b.doc.Info.Title = m.Ident()
+	}
+	if rev := m.Revision(); rev != nil {
+		b.doc.Info.Version = rev.Ident()
+	}
+	b.build()
+	b.doc.Components.Schemas = b.schemas
+	enc := json.NewEncoder(w.Out)
+	enc.SetEscapeHTML(false)
+	if w.Pretty {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(b.doc)
+}
+
+const mimeType = "application/yang-data+json"
+
+type document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       info                 `json:"info"`
+	Paths      map[string]*pathItem `json:"paths"`
+	Components components           `json:"components"`
+}
+
+type info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type components struct {
+	Schemas map[string]*schema `json:"schemas"`
+}
+
+type pathItem struct {
+	Parameters []*parameter `json:"parameters,omitempty"`
+	Get        *operation   `json:"get,omitempty"`
+	Put        *operation   `json:"put,omitempty"`
+	Post       *operation   `json:"post,omitempty"`
+	Patch      *operation   `json:"patch,omitempty"`
+	Delete     *operation   `json:"delete,omitempty"`
+}
+
+type operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	RequestBody *body                `json:"requestBody,omitempty"`
+	Responses   map[string]*response `json:"responses"`
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *schema `json:"schema"`
+}
+
+type body struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*mediaType `json:"content"`
+}
+
+type response struct {
+	Description string                `json:"description"`
+	Content     map[string]*mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type builder struct {
+	module  *meta.Module
+	root    string
+	doc     *document
+	schemas map[string]*schema
+
+	// component schema name of each container, list, input and output so
+	// recursive schemas refer back to schema already created
+	names map[meta.Definition]string
+}
+
+// endpoint is where we are in the API while walking schema
+type endpoint struct {
+	path   string
+	params []*parameter
+	id     []string
+	tag    string
+	config bool
+}
+
+func (e endpoint) child(ident string) endpoint {
+	if strings.HasSuffix(e.path, ":") {
+		// top level definitions are qualified by module name
+		e.path = e.path + ident
+	} else {
+		e.path = e.path + "/" + ident
+	}
+	e.id = append(append([]string{}, e.id...), ident)
+	if e.tag == "" {
+		e.tag = ident
+	}
+	return e
+}
+
+func (e endpoint) operationID(method string) string {
+	id := strings.Join(append([]string{method}, e.id...), "_")
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, id)
+}
+
+func (b *builder) build() {
+	id := []string{b.module.Ident()}
+	e := endpoint{path: b.root + "/data/" + b.module.Ident() + ":", id: id, config: true}
+	ref := b.objectSchema(b.module)
+	b.resource(e, b.module.Description(), ref, false)
+	for _, def := range b.module.DataDefinitions() {
+		b.dataDef(e, def)
+	}
+	ops := endpoint{path: b.root + "/operations/" + b.module.Ident() + ":", id: id, config: true}
+	for _, ident := range sortedActions(b.module.Actions()) {
+		rpc := b.module.Actions()[ident]
+		b.action(ops.child(rpc.Ident()), rpc)
+	}
+}
+
+func (b *builder) dataDef(parent endpoint, def meta.Definition) {
+	switch x := def.(type) {
+	case *meta.Choice:
+		for _, kaseIdent := range x.CaseIdents() {
+			kase := x.Cases()[kaseIdent]
+			for _, kaseDef := range kase.DataDefinitions() {
+				b.dataDef(parent, kaseDef)
+			}
+		}
+	case *meta.Container:
+		e := parent.child(x.Ident())
+		e.config = parent.config && x.Config()
+		b.resource(e, x.Description(), b.objectSchema(x), true)
+		b.children(e, x)
+	case *meta.List:
+		e := parent.child(x.Ident())
+		e.config = parent.config && x.Config()
+		item := b.objectSchema(x)
+		b.list(e, x, item)
+		if len(x.KeyMeta()) == 0 {
+			// items cannot be addressed without keys
+			return
+		}
+		b.children(b.listItem(e, x), x)
+	}
+}
+
+func (b *builder) children(e endpoint, x meta.HasDataDefinitions) {
+	if x.IsRecursive() {
+		return
+	}
+	for _, def := range x.DataDefinitions() {
+		b.dataDef(e, def)
+	}
+	if hasActions, valid := x.(meta.HasActions); valid {
+		for _, ident := range sortedActions(hasActions.Actions()) {
+			a := hasActions.Actions()[ident]
+			b.action(e.child(a.Ident()), a)
+		}
+	}
+}
+
+// resource adds operations for a container or list item
+func (b *builder) resource(e endpoint, desc string, s *schema, canDelete bool) {
+	item := b.pathItem(e)
+	item.Get = &operation{
+		OperationID: e.operationID("get"),
+		Description: desc,
+		Tags:        b.tags(e),
+		Responses:   map[string]*response{"200": b.content("OK", s)},
+	}
+	if !e.config {
+		return
+	}
+	item.Put = &operation{
+		OperationID: e.operationID("put"),
+		Summary:     "replace",
+		Tags:        b.tags(e),
+		RequestBody: b.body(s),
+		Responses:   noContent(),
+	}
+	item.Patch = &operation{
+		OperationID: e.operationID("patch"),
+		Summary:     "merge",
+		Tags:        b.tags(e),
+		RequestBody: b.body(s),
+		Responses:   noContent(),
+	}
+	if canDelete {
+		item.Delete = &operation{
+			OperationID: e.operationID("delete"),
+			Tags:        b.tags(e),
+			Responses:   noContent(),
+		}
+	}
+}
+
+func (b *builder) list(e endpoint, x *meta.List, itemRef *schema) {
+	item := b.pathItem(e)
+	item.Get = &operation{
+		OperationID: e.operationID("get"),
+		Description: x.Description(),
+		Tags:        b.tags(e),
+		Responses:   map[string]*response{"200": b.content("OK", b.listSchema(x, itemRef))},
+	}
+	if e.config {
+		item.Post = &operation{
+			OperationID: e.operationID("post"),
+			Summary:     "create item",
+			Tags:        b.tags(e),
+			RequestBody: b.body(itemRef),
+			Responses: map[string]*response{
+				"201": {Description: "Created"},
+			},
+		}
+	}
+}
+
+// listItem is endpoint of a single list item addressed by it's keys
+func (b *builder) listItem(e endpoint, x *meta.List) endpoint {
+	keys := x.KeyMeta()
+	params := append([]*parameter{}, e.params...)
+	vars := make([]string, len(keys))
+	for i, k := range keys {
+		name := k.Ident()
+		for _, p := range e.params {
+			if p.Name == name {
+				name = x.Ident() + "-" + k.Ident()
+				break
+			}
+		}
+		vars[i] = "{" + name + "}"
+		params = append(params, &parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   b.leafSchema(k),
+		})
+	}
+	e.path = e.path + "=" + strings.Join(vars, ",")
+	e.params = params
+	e.id = append(append([]string{}, e.id...), "item")
+	b.resource(e, x.Description(), b.objectSchema(x), true)
+	return e
+}
+
+func (b *builder) action(e endpoint, rpc *meta.Rpc) {
+	op := &operation{
+		OperationID: e.operationID("invoke"),
+		Description: rpc.Description(),
+		Tags:        b.tags(e),
+		Responses:   noContent(),
+	}
+	if rpc.Input() != nil {
+		op.RequestBody = b.body(b.objectSchema(rpc.Input()))
+	}
+	if rpc.Output() != nil {
+		op.Responses = map[string]*response{"200": b.content("OK", b.objectSchema(rpc.Output()))}
+	}
+	b.pathItem(e).Post = op
+}
+
+func (b *builder) pathItem(e endpoint) *pathItem {
+	item, found := b.doc.Paths[e.path]
+	if !found {
+		item = &pathItem{Parameters: e.params}
+		b.doc.Paths[e.path] = item
+	}
+	return item
+}
+
+func (b *builder) tags(e endpoint) []string {
+	if e.tag == "" {
+		return []string{b.module.Ident()}
+	}
+	return []string{e.tag}
+}
+
+func (b *builder) content(desc string, s *schema) *response {
+	return &response{
+		Description: desc,
+		Content:     map[string]*mediaType{mimeType: {Schema: s}},
+	}
+}
+
+func (b *builder) body(s *schema) *body {
+	return &body{
+		Required: true,
+		Content:  map[string]*mediaType{mimeType: {Schema: s}},
+	}
+}
+
+func noContent() map[string]*response {
+	return map[string]*response{
+		"204": {Description: "No Content"},
+	}
+}
+
+func sortedActions(actions map[string]*meta.Rpc) []string {
+	idents := make([]string, 0, len(actions))
+	for ident := range actions {
+		idents = append(idents, ident)
+	}
+	sort.Strings(idents)
+	return idents
+}
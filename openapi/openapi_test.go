@@ -0,0 +1,21 @@
+package openapi
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+var updateFlag = flag.Bool("update", false, "update golden files instead of verifying against them")
+
+func TestOpenAPI(t *testing.T) {
+	m := parser.RequireModule(source.Dir("./testdata"), "car")
+	actual, err := WriteOpenAPI(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.Gold(t, *updateFlag, []byte(actual), "testdata/gold/car.json")
+}
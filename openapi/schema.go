@@ -0,0 +1,265 @@
+package openapi
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/freeconf/yang/jsonschema"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/val"
+)
+
+// schema is the subset of OpenAPI schema object that YANG maps to
+type schema struct {
+	Ref         string             `json:"$ref,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *schema            `json:"items,omitempty"`
+	MinItems    *int               `json:"minItems,omitempty"`
+	MaxItems    *int               `json:"maxItems,omitempty"`
+	Enum        []interface{}      `json:"enum,omitempty"`
+	Default     interface{}        `json:"default,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+	MinLength   *int               `json:"minLength,omitempty"`
+	MaxLength   *int               `json:"maxLength,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	OneOf       []*schema          `json:"oneOf,omitempty"`
+	AllOf       []*schema          `json:"allOf,omitempty"`
+	Nullable    bool               `json:"nullable,omitempty"`
+	ReadOnly    bool               `json:"readOnly,omitempty"`
+}
+
+// objectSchema adds a component schema for container, list item, input or
+// output and returns a reference to it
+func (b *builder) objectSchema(x meta.HasDataDefinitions) *schema {
+	name, found := b.names[x]
+	if !found {
+		name = strings.Replace(meta.SchemaPath(x), "/", ".", -1)
+		b.names[x] = name
+		s := &schema{
+			Type:       "object",
+			Properties: make(map[string]*schema),
+		}
+		if d, valid := x.(meta.Describable); valid {
+			s.Description = d.Description()
+		}
+		// register before adding properties for recursive schemas
+		b.schemas[name] = s
+		config := true
+		if d, valid := x.(meta.HasDetails); valid {
+			config = d.Config()
+		}
+		b.properties(s, x.DataDefinitions(), config)
+		if l, isList := x.(*meta.List); isList {
+			for _, k := range l.KeyMeta() {
+				s.Required = appendUnique(s.Required, k.Ident())
+			}
+		}
+		sort.Strings(s.Required)
+	}
+	return &schema{Ref: "#/components/schemas/" + name}
+}
+
+// properties of object. Only definitions that are read-only in an object that
+// is config are marked read-only as everything under them is read-only too.
+func (b *builder) properties(s *schema, defs []meta.Definition, config bool) {
+	for _, def := range defs {
+		var p *schema
+		switch x := def.(type) {
+		case *meta.Choice:
+			// all cases are listed, server enforces only one case is used
+			for _, kaseIdent := range x.CaseIdents() {
+				b.properties(s, x.Cases()[kaseIdent].DataDefinitions(), config)
+			}
+			continue
+		case *meta.Container:
+			p = b.objectSchema(x)
+		case *meta.List:
+			p = b.listSchema(x, b.objectSchema(x))
+		case meta.Leafable:
+			p = b.leafSchema(x)
+		case *meta.Any:
+			p = &schema{Description: x.Description()}
+		default:
+			continue
+		}
+		if d, valid := def.(meta.HasDetails); valid {
+			if d.Mandatory() {
+				s.Required = appendUnique(s.Required, def.Ident())
+			}
+			if config && !d.Config() {
+				if p.Ref != "" {
+					// siblings of $ref are ignored
+					p = &schema{AllOf: []*schema{p}}
+				}
+				p.ReadOnly = true
+			}
+		}
+		s.Properties[def.Ident()] = p
+	}
+}
+
+func (b *builder) listSchema(x *meta.List, item *schema) *schema {
+	s := &schema{
+		Type:  "array",
+		Items: item,
+	}
+	if x.IsMinElementsSet() {
+		min := x.MinElements()
+		s.MinItems = &min
+	}
+	if !x.Unbounded() {
+		max := x.MaxElements()
+		s.MaxItems = &max
+	}
+	return s
+}
+
+func (b *builder) leafSchema(l meta.Leafable) *schema {
+	s := b.typeSchema(l.Type())
+	if l.Type().Format().IsList() {
+		s = &schema{
+			Type:  "array",
+			Items: s,
+		}
+	}
+	if d, valid := l.(meta.Describable); valid {
+		s.Description = d.Description()
+	}
+	if l.HasDefault() {
+		s.Default = jsonschema.DefaultValue(l)
+	}
+	return s
+}
+
+func (b *builder) typeSchema(t *meta.Type) *schema {
+	s := &schema{}
+	switch t.Format().Single() {
+	case val.FmtString:
+		s.Type = "string"
+		b.lengthRange(s, t)
+		for i, pattern := range jsonschema.Patterns(t) {
+			if i == 0 {
+				s.Pattern = pattern
+			} else {
+				s.AllOf = append(s.AllOf, &schema{Pattern: pattern})
+			}
+		}
+	case val.FmtInt8, val.FmtInt16, val.FmtInt32, val.FmtUInt8, val.FmtUInt16:
+		s.Type = "integer"
+		s.Format = "int32"
+		b.numberRange(s, t)
+	case val.FmtUInt32, val.FmtInt64, val.FmtUInt64:
+		s.Type = "integer"
+		s.Format = "int64"
+		b.numberRange(s, t)
+	case val.FmtDecimal64:
+		s.Type = "number"
+		s.Format = "double"
+		b.numberRange(s, t)
+	case val.FmtBool:
+		s.Type = "boolean"
+	case val.FmtEnum:
+		s.Type = "string"
+		for _, e := range t.Enum() {
+			s.Enum = append(s.Enum, e.Label)
+		}
+	case val.FmtIdentityRef:
+		s.Type = "string"
+		if t.Base() != nil {
+			idents := make([]string, 0, len(t.Base().Derived()))
+			for ident := range t.Base().Derived() {
+				idents = append(idents, ident)
+			}
+			sort.Strings(idents)
+			for _, ident := range idents {
+				s.Enum = append(s.Enum, ident)
+			}
+		}
+	case val.FmtBinary:
+		s.Type = "string"
+		s.Format = "byte"
+	case val.FmtBits, val.FmtInstanceRef:
+		s.Type = "string"
+	case val.FmtEmpty:
+		// [null] from RFC7951 Section 6.9
+		one := 1
+		s.Type = "array"
+		s.Items = &schema{Nullable: true, Enum: []interface{}{nil}}
+		s.MinItems = &one
+		s.MaxItems = &one
+	case val.FmtLeafRef:
+		return b.typeSchema(t.Resolve())
+	case val.FmtUnion:
+		for _, u := range t.Union() {
+			s.OneOf = append(s.OneOf, b.typeSchema(u))
+		}
+	}
+	return s
+}
+
+// numberRange limits number to ranges of type and the types it derives from
+// which all have to be satisfied
+func (b *builder) numberRange(s *schema, t *meta.Type) {
+	s.Minimum, s.Maximum = intersectRanges(t.Range())
+}
+
+func (b *builder) lengthRange(s *schema, t *meta.Type) {
+	min, max := intersectRanges(t.Length())
+	s.MinLength, s.MaxLength = intPtr(min), intPtr(max)
+}
+
+// intersectRanges is the highest lower bound and lowest upper bound of ranges
+func intersectRanges(ranges []*meta.Range) (min *float64, max *float64) {
+	for _, r := range ranges {
+		rmin, rmax := rangeBounds(r)
+		if rmin != nil && (min == nil || *rmin > *min) {
+			min = rmin
+		}
+		if rmax != nil && (max == nil || *rmax < *max) {
+			max = rmax
+		}
+	}
+	return
+}
+
+// rangeBounds of a single range, bounds are nil when they are "min" or "max"
+// which are the limits of type that the format already implies.
+func rangeBounds(r *meta.Range) (min *float64, max *float64) {
+	max = rangeBound(r.Max)
+	if r.Min == "" {
+		// single value
+		return max, max
+	}
+	return rangeBound(r.Min), max
+}
+
+func rangeBound(s string) *float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+func intPtr(f *float64) *int {
+	if f == nil {
+		return nil
+	}
+	i := int(*f)
+	return &i
+}
+
+func appendUnique(l []string, s string) []string {
+	for _, candidate := range l {
+		if candidate == s {
+			return l
+		}
+	}
+	return append(l, s)
+}
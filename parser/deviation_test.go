@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/source"
+	"github.com/freeconf/yang/val"
+)
+
+func TestDeviationModule(t *testing.T) {
+	ypath := source.Dir("./testdata/deviate")
+	m, err := LoadModuleWithOptions(ypath, "base", Options{
+		Deviations: []string{"base-deviations"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, 1, len(m.DeviatedBy()))
+	fc.AssertEqual(t, "base-deviations", m.DeviatedBy()[0].Ident())
+
+	a := meta.Find(m, "c/a").(*meta.Leaf)
+	fc.AssertEqual(t, val.FmtInt64, a.Type().Format())
+	fc.AssertEqual(t, "distance", a.Type().Typedef().Ident())
+	fc.AssertEqual(t, "centimeters", a.Units())
+	fc.AssertEqual(t, false, a.HasDefault())
+
+	b := meta.Find(m, "c/b").(*meta.Leaf)
+	fc.AssertEqual(t, true, b.Mandatory())
+	fc.AssertEqual(t, false, b.Config())
+
+	l := meta.Find(m, "c/l").(*meta.List)
+	fc.AssertEqual(t, 1, l.MinElements())
+	fc.AssertEqual(t, 20, l.MaxElements())
+	fc.AssertEqual(t, 1, len(l.Unique()))
+	fc.AssertEqual(t, "k x", strings.Join(l.Unique()[0], " "))
+	fc.AssertEqual(t, 1, len(l.Musts()))
+	fc.AssertEqual(t, "count(../l) < 5", l.Musts()[0].Expression())
+
+	// without deviation module
+	m, err = LoadModule(ypath, "base")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, 0, len(m.DeviatedBy()))
+	fc.AssertEqual(t, "inches", meta.Find(m, "c/a").(*meta.Leaf).Units())
+}
+
+func TestDeviationModuleErrors(t *testing.T) {
+	tests := []struct {
+		deviate string
+		err     string
+	}{
+		{
+			deviate: `deviate replace { type x:nosuch; }`,
+			err:     "bad-deviations.yang:7:4 - bad-deviations - base/c/a - typedef x:nosuch not found",
+		},
+		{
+			deviate: `deviate replace { type identityref { base nosuch; } }`,
+			err:     "bad-deviations.yang:7:4 - bad-deviations - base/c/a - nosuch identity not found",
+		},
+		{
+			deviate: `deviate add { must "../y:b"; }`,
+			err:     "bad-deviations.yang:7:4 - bad-deviations - ../y:b - prefix y not imported",
+		},
+	}
+	for _, test := range tests {
+		t.Log(test.deviate)
+		yang := `module bad-deviations {
+			namespace "bad-deviations";
+			prefix "bd";
+			import base {
+				prefix "x";
+			}
+			deviation "/x:c/x:a" {
+				` + test.deviate + `
+			}
+		}`
+		ypath := source.Any(
+			source.Dir("./testdata/deviate"),
+			source.Named("bad-deviations", strings.NewReader(yang)),
+		)
+		_, err := LoadModuleWithOptions(ypath, "base", Options{
+			Deviations: []string{"bad-deviations"},
+		})
+		if err == nil {
+			t.Error("expected error")
+			continue
+		}
+		fc.AssertEqual(t, test.err, err.Error())
+	}
+}
+
+func TestDeviationErrors(t *testing.T) {
+	tests := []struct {
+		deviate string
+		err     string
+	}{
+		{
+			deviate: `deviate add { units "cm"; }`,
+			err:     "12:4 - units already set on x/a",
+		},
+		{
+			deviate: `deviate add { default 1; }`,
+			err:     "12:4 - default already set on x/a",
+		},
+		{
+			deviate: `deviate add { max-elements 1; }`,
+			err:     "12:4 - max-elements not supported on leaf x/a",
+		},
+		{
+			deviate: `deviate add { unique "a"; }`,
+			err:     "12:4 - unique not supported on leaf x/a",
+		},
+		{
+			deviate: `deviate replace { mandatory true; }`,
+			err:     "12:4 - mandatory not set on x/a",
+		},
+		{
+			deviate: `deviate replace { config false; }`,
+			err:     "12:4 - config not set on x/a",
+		},
+		{
+			deviate: `deviate delete { units "cm"; }`,
+			err:     "12:4 - cannot delete units 'cm' != 'inches' on x/a",
+		},
+		{
+			deviate: `deviate delete { default 2; }`,
+			err:     "12:4 - cannot delete default '2' != '1' on x/a",
+		},
+		{
+			deviate: `deviate delete { must "1"; }`,
+			err:     "12:4 - must entry 1 not found on x/a",
+		},
+	}
+	for _, test := range tests {
+		t.Log(test.deviate)
+		yang := `module m {
+			namespace "m";
+			prefix "m";
+			revision 0;
+			container x {
+				leaf a {
+					type int32;
+					units inches;
+					default 1;
+				}
+			}
+			deviation x/a {
+				` + test.deviate + `
+			}
+		}`
+		_, err := LoadModuleFromString(nil, yang)
+		if err == nil {
+			t.Error("expected error")
+			continue
+		}
+		fc.AssertEqual(t, test.err, err.Error())
+	}
+}
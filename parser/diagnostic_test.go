@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/source"
+)
+
+func TestDiagnostics(t *testing.T) {
+	m, err := LoadModule(source.Dir("./testdata/diagnostic"), "broken")
+	diags := meta.DiagnosticsOf(err)
+	expected := []string{
+		"broken.yang:7:3 - no type set on broken/a/b",
+		"broken.yang:8:8 - expecting string",
+		"broken.yang:10:3 - broken/a/c - typedef no-such-type not found",
+		"broken.yang:16:2 - expecting semicolon or '{'",
+		"broken.yang:17:2 - broken/no-such-grouping - no-such-grouping group not found",
+		"broken.yang:19:3 - unknown statement",
+		"broken.yang:28:6 - unknown statement",
+	}
+	fc.AssertEqual(t, len(expected), len(diags))
+	for i, d := range diags {
+		fc.AssertEqual(t, meta.SeverityError, d.Severity)
+		fc.AssertEqual(t, expected[i], d.Error())
+	}
+
+	// everything that could be parsed is still available
+	fc.AssertEqual(t, true, meta.Find(m, "e/f") != nil)
+	fc.AssertEqual(t, true, meta.Find(m, "g/i") != nil)
+	h := meta.Find(m, "g/h").(*meta.Leaf)
+	fc.AssertEqual(t, "x,y", h.Type().Enum().String())
+}
+
+func TestDiagnosticsAllBuilderErrors(t *testing.T) {
+	yang := `module x {
+		namespace "x";
+		prefix "x";
+		must "a";
+		leaf c {
+			type string;
+		}
+		must "b";
+	}`
+	_, err := LoadModuleFromString(nil, yang)
+	diags := meta.DiagnosticsOf(err)
+	fc.AssertEqual(t, 2, len(diags))
+	fc.AssertEqual(t, "4:11 - *meta.Module does not support must", diags[0].Error())
+	fc.AssertEqual(t, "8:11 - *meta.Module does not support must", diags[1].Error())
+}
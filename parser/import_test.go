@@ -1,9 +1,11 @@
 package parser
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
+	"github.com/freeconf/yang/fc"
 	"github.com/freeconf/yang/meta"
 	"github.com/freeconf/yang/source"
 )
@@ -60,3 +62,115 @@ module main {
 		t.Error("non should not have been imported")
 	}
 }
+
+func TestImportRevision(t *testing.T) {
+	ypath := source.Dir("./testdata/revision")
+	old, err := LoadModule(ypath, "old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, "2021-03-01", old.Imports()["c"].Module().Revision().Ident())
+
+	latest, err := LoadModule(ypath, "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, "2022-01-01", latest.Imports()["c"].Module().Revision().Ident())
+
+	_, err = LoadModule(ypath, "missing")
+	fc.AssertEqual(t, true, errors.Is(err, fc.NotFoundError))
+
+	car, err := LoadModuleWithOptions(ypath, "car", Options{Revision: "2021-03-01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, true, meta.Find(car, "r2021") != nil)
+
+	car, err = LoadModule(ypath, "car")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, true, meta.Find(car, "r2022") != nil)
+
+	// file without revision in name is accepted if revision matches
+	_, err = LoadModuleWithOptions(ypath, "latest", Options{Revision: "2022-01-01"})
+	fc.AssertEqual(t, nil, err)
+	_, err = LoadModuleWithOptions(ypath, "latest", Options{Revision: "2021-03-01"})
+	fc.AssertEqual(t, true, errors.Is(err, fc.NotFoundError))
+
+	// includes are checked against latest revision of submodule
+	inc, err := LoadModule(ypath, "inc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, true, meta.Find(inc, "s") != nil)
+	_, err = LoadModule(ypath, "inc-mismatch")
+	fc.AssertEqual(t, true, errors.Is(err, fc.NotFoundError))
+	fc.AssertEqual(t, true, strings.Contains(err.Error(), "revision 2021-03-01 of sub requested but found 2022-01-01"))
+}
+
+func TestImportNamespace(t *testing.T) {
+	ypath := source.Dir("./testdata/namespace")
+	m, err := LoadModule(ypath, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "top/c/x", expected: "m"},
+		{path: "top/c/mm:x", expected: "m"},
+		{path: "top/c/m:x", expected: "m"},
+		{path: "/m:top/mm:c/m:x", expected: "m"},
+		{path: "top/c/aa:x", expected: ""},
+		{path: "top/c/a:x", expected: ""},
+		{path: "top/c/y", expected: "m"},
+		{path: "top/c/aa:y", expected: ""},
+		{path: "top/c/z", expected: "m"},
+		{path: "top/c/mm:z", expected: "m"},
+		{path: "top/c/zz:x", expected: ""},
+	}
+	for _, test := range tests {
+		def := meta.Find(m, test.path)
+		actual := ""
+		if def != nil {
+			actual = meta.NamespaceModule(def).Ident()
+		}
+		if actual != test.expected {
+			t.Errorf("%s: expected %q got %q", test.path, test.expected, actual)
+		}
+	}
+}
+
+func TestAugmentImport(t *testing.T) {
+	ypath := source.Dir("./testdata/namespace")
+	m, err := LoadModuleFromString(ypath, `module b {
+		namespace "b";
+		prefix "bb";
+		revision 0;
+
+		import m {
+			prefix "mm";
+		}
+
+		augment "/mm:top/mm:c" {
+			leaf x {
+				type int32;
+			}
+		}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	def := meta.Find(m, "/mm:top/mm:c/bb:x")
+	if def == nil {
+		t.Fatal("augmented leaf not found")
+	}
+	fc.AssertEqual(t, "b", meta.NamespaceModule(def).Ident())
+	def = meta.Find(m, "/mm:top/mm:c/mm:x")
+	if def == nil {
+		t.Fatal("leaf from grouping not found")
+	}
+	fc.AssertEqual(t, "m", meta.NamespaceModule(def).Ident())
+}
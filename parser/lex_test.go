@@ -3,11 +3,12 @@ package parser
 import (
 	"testing"
 
+	"github.com/freeconf/yang/fc"
 	"github.com/freeconf/yang/meta"
 )
 
 func TestLexEmpty(t *testing.T) {
-	actual := token{parseEof, "EOF"}.String()
+	actual := token{parseEof, "EOF", 0}.String()
 	if actual != "\"EOF\"" {
 		t.Error(actual)
 	}
@@ -242,7 +243,7 @@ func TestStack(t *testing.T) {
 	b := &meta.Builder{}
 	stack := newDefStack(10)
 	expected := b.Module("x", nil)
-	stack.push(expected)
+	stack.push(expected, 0)
 	actual, ok := stack.pop().(*meta.Module)
 	if !ok {
 		t.Fail()
@@ -251,3 +252,66 @@ func TestStack(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestPositionOf(t *testing.T) {
+	l := lex("ab\ncd\n\nef", nil)
+	tests := []struct {
+		pos  int
+		line int
+		col  int
+	}{
+		{pos: 4, line: 1, col: 1},
+		{pos: 0, line: 0, col: 0},
+		{pos: 8, line: 3, col: 1},
+		{pos: 3, line: 1, col: 0},
+		{pos: 6, line: 2, col: 0},
+		{pos: 2, line: 0, col: 2},
+	}
+	for _, test := range tests {
+		line, col := l.positionOf(test.pos)
+		fc.AssertEqual(t, test.line, line)
+		fc.AssertEqual(t, test.col, col)
+	}
+}
+
+func TestTokens(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected []Token
+	}{
+		{
+			in: `leaf a { type "x\"y"; } // c`,
+			expected: []Token{
+				{Text: "leaf", Start: 0, End: 4},
+				{Text: "a", Start: 5, End: 6},
+				{Text: "{", Start: 7, End: 8},
+				{Text: "type", Start: 9, End: 13},
+				{Text: `x"y`, Quoted: true, Start: 14, End: 20},
+				{Text: ";", Start: 20, End: 21},
+				{Text: "}", Start: 22, End: 23},
+			},
+		},
+		{
+			in: "must 'a' + /* c */ 'b'",
+			expected: []Token{
+				{Text: "must", Start: 0, End: 4},
+				{Text: "a", Quoted: true, Start: 5, End: 8},
+				{Text: "+", Start: 9, End: 10},
+				{Text: "b", Quoted: true, Start: 19, End: 22},
+			},
+		},
+		{
+			in: `leaf a}description "unclosed`,
+			expected: []Token{
+				{Text: "leaf", Start: 0, End: 4},
+				{Text: "a", Start: 5, End: 6},
+				{Text: "}", Start: 6, End: 7},
+				{Text: "description", Start: 7, End: 18},
+				{Text: "unclosed", Quoted: true, Start: 19, End: 28},
+			},
+		},
+	}
+	for _, test := range tests {
+		fc.AssertEqual(t, test.expected, Tokens(test.in))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -30,6 +31,7 @@ import (
 type token struct {
 	typ int
 	val string
+	pos int
 }
 
 type stateFunc func(*lexer) stateFunc
@@ -138,6 +140,7 @@ var keywords = [...]string{
 	"error-message",
 	"bit",
 	"position",
+	"revision-date",
 }
 
 const eof rune = 0
@@ -159,26 +162,77 @@ func (t token) String() string {
 	return fmt.Sprintf("%q", t.val)
 }
 
+// error stops lexing and gives parser a token with the problem so parser
+// can report it and recover
 func (l *lexer) error(msg string) stateFunc {
-	l.tokens = append(l.tokens, token{
+	l.pushToken(token{
 		parseErr,
 		msg,
+		l.start,
 	})
-	l.Error(msg)
 	return nil
 }
 
+// errorAt records a problem and where it happened. Parsing continues to find
+// more problems.
+func (l *lexer) errorAt(pos int, msg string) {
+	l.diags = append(l.diags, meta.NewDiagnostic(&meta.LocationError{
+		Location: l.location(pos),
+		Err:      errors.New(msg),
+	}))
+}
+
+// recover from a problem by skipping the rest of the statement with the
+// problem at the nesting level the parser recovered at and the definitions
+// started by that statement. False if recovering would not make any progress
+// or there are too many problems to bother.
+func (l *lexer) recover(level int) bool {
+	resume := statementEnd(l.input, l.tokPos, l.tokDepth, level)
+	if resume == l.resumePos || len(l.diags) >= maxParseErrors {
+		return false
+	}
+	l.resumePos = resume
+	for l.stack.count > 0 && l.stack.levels[l.stack.count-1] >= level {
+		l.stack.pop()
+	}
+	l.depth = level
+	l.head, l.tail = 0, 0
+	l.pos, l.start = resume, resume
+	l.acceptWS()
+	l.state = lexBegin
+	return true
+}
+
 func (l *lexer) importModule(into *meta.Module, moduleName string) error {
 	return nil
 }
 
 type yangMetaStack struct {
-	defs  []interface{}
-	count int
+	defs   []interface{}
+	levels []int
+	count  int
 }
 
-func (s *yangMetaStack) push(def interface{}) interface{} {
+// push definition on stack and record it's location as the start of the
+// statement being parsed
+func (l *lexer) push(def interface{}) interface{} {
+	l.builder.Location(def, l.location(l.stmtPos))
+	return l.stack.push(def, l.stmtLevel)
+}
+
+func (l *lexer) location(pos int) *meta.Location {
+	line, col := l.positionOf(pos)
+	return &meta.Location{
+		File: l.file,
+		Line: line + 1,
+		Col:  col + 1,
+	}
+}
+
+// push definition of statement at nesting level
+func (s *yangMetaStack) push(def interface{}, level int) interface{} {
 	s.defs[s.count] = def
+	s.levels[s.count] = level
 	s.count++
 	return def
 }
@@ -203,8 +257,9 @@ func (s *yangMetaStack) peekModule() *meta.Module {
 
 func newDefStack(size int) *yangMetaStack {
 	return &yangMetaStack{
-		defs:  make([]interface{}, size),
-		count: 0,
+		defs:   make([]interface{}, size),
+		levels: make([]int, size),
+		count:  0,
 	}
 }
 
@@ -221,8 +276,26 @@ type lexer struct {
 	loader     meta.Loader
 	featureSet meta.FeatureSet
 	parent     *meta.Module
-	lastError  error
 	builder    *meta.Builder
+	diags      meta.Diagnostics
+
+	// file and start of most recent statement are for locations of
+	// definitions
+	file      string
+	stmtPos   int
+	stmtLevel int
+
+	// start, type and nesting of last token given to parser, nesting of
+	// next token and where parsing last resumed after a problem
+	tokPos    int
+	tokTyp    int
+	tokDepth  int
+	depth     int
+	resumePos int
+
+	// offsets of where lines start in input up to scanned
+	lineStarts []int
+	scanned    int
 }
 
 func (l *lexer) next() (r rune) {
@@ -235,16 +308,19 @@ func (l *lexer) next() (r rune) {
 	return r
 }
 
-func (l *lexer) Position() (line, col int) {
-	for p := 0; p < l.pos; p++ {
-		if l.input[p] == '\n' {
-			line += 1
-			col = 0
-		} else {
-			col += 1
+// positionOf is the zero based line and column of pos. Where lines start is
+// recorded as input is scanned so each part of input is only scanned once.
+func (l *lexer) positionOf(pos int) (line, col int) {
+	for ; l.scanned < pos && l.scanned < len(l.input); l.scanned++ {
+		if l.input[l.scanned] == '\n' {
+			l.lineStarts = append(l.lineStarts, l.scanned+1)
 		}
 	}
-	return
+	line = sort.SearchInts(l.lineStarts, pos+1)
+	if line > 0 {
+		return line, pos - l.lineStarts[line-1]
+	}
+	return 0, pos
 }
 
 func (l *lexer) isEof() bool {
@@ -381,6 +457,35 @@ func (l *lexer) acceptString() bool {
 	}
 }
 
+// unescape double quoted string, RFC7950 Section 6.1.3. Other escapes were
+// allowed in YANG 1.0 and are kept as is, e.g. patterns like "\d+".
+func unescape(s string) string {
+	if !strings.ContainsRune(s, char_backslash) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == char_backslash && i+1 < len(s) {
+			switch s[i+1] {
+			case 'n':
+				b.WriteByte('\n')
+				i++
+				continue
+			case 't':
+				b.WriteByte('\t')
+				i++
+				continue
+			case '"', char_backslash:
+				b.WriteByte(s[i+1])
+				i++
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
 // strings that are not surrounded by quotes (single or double) are allowed
 func isStringDelim(r rune) bool {
 	return unicode.IsSpace(r) || r == ';' || r == '{'
@@ -571,8 +676,6 @@ func lexBegin(l *lexer) stateFunc {
 		kywd_anyxml,
 		kywd_import,
 		kywd_type,
-		kywd_enum,
-		kywd_bit,
 		kywd_uses,
 		kywd_base,
 	}
@@ -585,6 +688,23 @@ func lexBegin(l *lexer) stateFunc {
 		}
 	}
 
+	// FORMAT: Either
+	//  xxx zzz;
+	// or
+	//  xxx "z z" { ...
+	types = []int{
+		kywd_enum,
+		kywd_bit,
+	}
+	for _, ttype := range types {
+		if l.acceptToken(ttype) {
+			if !l.acceptToken(token_ident) && !l.acceptString() {
+				return l.error("expecting string")
+			}
+			return l.acceptEndOfStatement()
+		}
+	}
+
 	if l.acceptToken(kywd_status) {
 		allowed := []int{
 			kywd_current,
@@ -644,6 +764,7 @@ func lexBegin(l *lexer) stateFunc {
 		kywd_if_feature,
 		kywd_namespace,
 		kywd_reference,
+		kywd_revision_date,
 		kywd_revision,
 		kywd_argument,
 		kywd_presence,
@@ -731,7 +852,7 @@ func (l *lexer) acceptEndOfStatement() stateFunc {
 }
 
 func (l *lexer) emit(t int) {
-	l.pushToken(token{t, l.input[l.start:l.pos]})
+	l.pushToken(token{t, l.input[l.start:l.pos], l.start})
 	l.start = l.pos
 	l.acceptWS()
 }
@@ -757,7 +878,7 @@ func (l *lexer) nextToken() (token, error) {
 			return token, nil
 		}
 		if l.state == nil {
-			return token{parseEof, "EOF"}, nil
+			return token{parseEof, "EOF", l.pos}, nil
 		}
 		l.state = l.state(l)
 	}
@@ -770,13 +891,14 @@ const (
 
 func lex(input string, loader meta.Loader) *lexer {
 	l := &lexer{
-		input:  input,
-		tokens: make([]token, lexRingBufferSize),
-		head:   0,
-		tail:   0,
-		state:  lexBegin,
-		stack:  newDefStack(256),
-		loader: loader,
+		input:     input,
+		tokens:    make([]token, lexRingBufferSize),
+		head:      0,
+		tail:      0,
+		state:     lexBegin,
+		stack:     newDefStack(256),
+		loader:    loader,
+		resumePos: -1,
 	}
 	l.acceptWS()
 	return l
@@ -789,8 +911,8 @@ func lexDump(y string, w io.Writer) error {
 		token, err := l.nextToken()
 		if err != nil {
 			return err
-		} else if l.lastError != nil {
-			return l.lastError
+		} else if token.typ == parseErr {
+			return errors.New(token.val)
 		} else if token.typ == parseEof {
 			return nil
 		}
@@ -1,9 +1,13 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
 
 	"github.com/freeconf/yang/fc"
 	"github.com/freeconf/yang/source"
@@ -32,11 +36,27 @@ type Options struct {
 	// Features when you know want to control what features are on of off
 	Features meta.FeatureSet
 
-	// Revision to lock into a specific revision
+	// Revision to lock into a specific revision. File name@revision is
+	// preferred but name is also accepted as long as it's latest revision
+	// matches.
 	Revision string
+
+	// Uncompiled leaves module as it was written. Groupings are not expanded,
+	// imports are not loaded and types are not resolved.  Useful for tools
+	// that work with YANG source like meta.YangWtr.
+	Uncompiled bool
+
+	// Extensions are handlers called for extensions used in module when it is
+	// compiled
+	Extensions meta.ExtensionHandlers
+
+	// Deviations are names of modules with deviations to apply to the module
+	// being loaded.  Useful when a vendor keeps their changes to a standard
+	// module in a separate module.
+	Deviations []string
 }
 
-// LoadModuleFromString parses YANG from a string, not a file.
+// LoadModuleFromString parses YANG, or YIN, from a string, not a file.
 func LoadModuleFromString(source source.Opener, yang string) (*meta.Module, error) {
 	return LoadModuleFromStringWithOptions(source, yang, Options{})
 }
@@ -47,11 +67,11 @@ func LoadModuleFromStringWithOptions(source source.Opener, yang string, options
 	p := &parser{
 		source: source,
 	}
-	m, err := p.parseModule(yang, nil, options.Features, p.loadAndParseModule)
-	if err != nil {
-		return nil, err
+	m, err := p.parseModule(yang, "", nil, options.Features, p.loadAndParseModule)
+	if m == nil || options.Uncompiled {
+		return m, err
 	}
-	return m, meta.Compile(m)
+	return m, p.compile(m, options, err)
 }
 
 type parser struct {
@@ -66,40 +86,91 @@ func LoadModuleWithOptions(source source.Opener, yangfile string, options Option
 		source: source,
 	}
 	m, err := p.loadAndParseModule(nil, yangfile, options.Revision, options.Features, p.loadAndParseModule)
-	if err != nil {
-		return nil, err
+	if m == nil || options.Uncompiled {
+		return m, err
+	}
+	return m, p.compile(m, options, err)
+}
+
+// compile module even when there were parse errors so all the problems can be
+// reported at once
+func (p *parser) compile(m *meta.Module, options Options, parseErr error) error {
+	diags := meta.DiagnosticsOf(parseErr)
+	if err := p.compileModule(m, options); err != nil {
+		diags = append(diags, meta.DiagnosticsOf(err)...)
+	}
+	if len(diags) == 0 {
+		return nil
 	}
-	return m, meta.Compile(m)
+	sortDiagnostics(diags)
+	return diags
 }
 
-func (p *parser) parseModule(data string, parent *meta.Module, featureSet meta.FeatureSet, loader meta.Loader) (*meta.Module, error) {
+// sortDiagnostics in order they appear in files. Ones with no location go
+// last.
+func sortDiagnostics(diags meta.Diagnostics) {
+	sort.SliceStable(diags, func(i, j int) bool {
+		a, b := diags[i].Location, diags[j].Location
+		if a == nil || b == nil {
+			return a != nil
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Col < b.Col
+	})
+}
+
+func (p *parser) compileModule(m *meta.Module, options Options) error {
+	compileOptions := meta.CompileOptions{
+		Extensions: options.Extensions,
+	}
+	// deviation modules are only parsed, their deviations are applied when
+	// module is compiled
+	for _, name := range options.Deviations {
+		d, err := p.loadAndParseModule(nil, name, "", options.Features, p.loadAndParseModule)
+		if err != nil {
+			return fmt.Errorf("%s - %w", name, err)
+		}
+		compileOptions.Deviations = append(compileOptions.Deviations, d)
+	}
+	return meta.CompileWithOptions(m, compileOptions)
+}
+
+// parseModule parses YANG, or YIN, where file is only used for locations of
+// definitions. After a problem, statement with the problem is skipped and
+// parsing continues to find more problems.  Error is meta.Diagnostics with
+// all the problems and module is what could be parsed.
+func (p *parser) parseModule(data string, file string, parent *meta.Module, featureSet meta.FeatureSet, loader meta.Loader) (*meta.Module, error) {
+	if isYin(data) {
+		return parseYin(data, file, parent, featureSet, loader)
+	}
 	l := lex(string(data), loader)
 	l.parent = parent
+	l.file = file
 	l.featureSet = featureSet
 	l.builder = &meta.Builder{}
-	errcode := yyParse(l)
-	if errcode != 0 || l.lastError != nil {
-		if l.lastError == nil {
-			l.lastError = fmt.Errorf("Error parsing, code %d", errcode)
+	if errcode := yyParse(l); errcode != 0 {
+		if len(l.diags) == 0 {
+			l.errorAt(l.tokPos, fmt.Sprintf("Error parsing, code %d", errcode))
 		}
-		return nil, l.lastError
-	} else if l.builder.LastErr != nil {
-		return nil, l.builder.LastErr
+		return nil, l.diags
 	}
-
 	m := l.stack.peek().(*meta.Module)
+	if len(l.diags) > 0 {
+		return m, l.diags
+	}
 	return m, nil
 }
 
 func (p *parser) loadAndParseModule(parent *meta.Module, yangfile string, rev string, featureSet meta.FeatureSet, loader meta.Loader) (*meta.Module, error) {
-	// TODO: Use rev
-	res, err := p.source(yangfile, ".yang")
+	res, file, err := p.open(yangfile, rev)
 	if err != nil {
 		return nil, err
 	}
-	if res == nil {
-		return nil, fmt.Errorf("%w. %s resource not found", fc.NotFoundError, yangfile)
-	}
 	if closer, ok := res.(io.Closer); ok {
 		defer closer.Close()
 	}
@@ -107,7 +178,63 @@ func (p *parser) loadAndParseModule(parent *meta.Module, yangfile string, rev st
 	if err != nil {
 		return nil, err
 	}
-	return p.parseModule(string(data), parent, featureSet, loader)
+	// submodules add their revisions to end of parent module's revisions
+	var before int
+	if parent != nil {
+		before = len(parent.RevisionHistory())
+	}
+	m, err := p.parseModule(string(data), file, parent, featureSet, loader)
+	if err != nil || rev == "" {
+		return m, err
+	}
+	revs := m.RevisionHistory()
+	if parent != nil {
+		revs = parent.RevisionHistory()[before:]
+	}
+	if len(revs) == 0 || revs[0].Ident() != rev {
+		found := "no revision"
+		if len(revs) > 0 {
+			found = revs[0].Ident()
+		}
+		return nil, fmt.Errorf("%w. revision %s of %s requested but found %s", fc.NotFoundError, rev, yangfile, found)
+	}
+	return m, nil
+}
+
+// open finds YANG or YIN file.  When a revision is requested, name@revision
+// is tried before name. File name found is also returned.
+func (p *parser) open(yangfile string, rev string) (io.Reader, string, error) {
+	if rev != "" {
+		res, file, err := p.openAny(yangfile + "@" + rev)
+		if res != nil || err != nil {
+			return res, file, err
+		}
+	}
+	res, file, err := p.openAny(yangfile)
+	if err != nil {
+		return nil, "", err
+	}
+	if res == nil {
+		return nil, "", fmt.Errorf("%w. %s resource not found", fc.NotFoundError, yangfile)
+	}
+	return res, file, nil
+}
+
+func (p *parser) openAny(name string) (io.Reader, string, error) {
+	file := name + ".yang"
+	res, err := p.source(name, ".yang")
+	if res == nil && (err == nil || errors.Is(err, os.ErrNotExist)) {
+		file = name + ".yin"
+		res, err = p.source(name, ".yin")
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, "", err
+	}
+	// source may have picked a file with a revision in it's name
+	if named, ok := res.(interface{ Name() string }); ok && err == nil {
+		file = filepath.Base(named.Name())
+	}
+	return res, file, nil
 }
 
 // func (p *parser) submoduleLoader(source source.Opener) meta.Loader {
@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/source"
+)
+
+func TestLocation(t *testing.T) {
+	ypath := source.Dir("./testdata/location")
+	m, err := LoadModuleWithOptions(ypath, "loc", Options{Uncompiled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, "loc.yang:1:1", m.Location().String())
+	top := meta.Find(m, "top").(*meta.Container)
+	fc.AssertEqual(t, "loc.yang:13:2", top.Location().String())
+
+	_, err = LoadModule(ypath, "loc")
+	var located *meta.LocationError
+	if !errors.As(err, &located) {
+		t.Fatalf("expected location error but got %v", err)
+	}
+	fc.AssertEqual(t, "loc-sub.yang:9:3", located.Location.String())
+	fc.AssertEqual(t, "loc-sub.yang:9:3 - loc/top/c/b - typedef no-such-type not found, "+
+		"instantiated from loc.yang:9:4, instantiated from loc.yang:14:3", err.Error())
+}
+
+func TestLocationFile(t *testing.T) {
+	ypath := source.Dir("./testdata/revision")
+	m, err := LoadModuleWithOptions(ypath, "car", Options{Uncompiled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, "car@2022-01-01.yang:1:1", m.Location().String())
+	m, err = LoadModuleWithOptions(ypath, "car", Options{Uncompiled: true, Revision: "2021-03-01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, "car@2021-03-01.yang:1:1", m.Location().String())
+}
@@ -14,44 +14,86 @@ import (
 	"strings"
 )
 
+// tokenString is the value of a quoted or unquoted string without the
+// whitespace around it
 func tokenString(s string) string {
-	return strings.Trim(s, " \t\n\r\"'")
+	s = strings.Trim(s, " \t\n\r")
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return unescape(strings.Trim(s[1:len(s)-1], " \t\n\r"))
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return strings.Trim(s[1:len(s)-1], " \t\n\r")
+	}
+	return s
 }
 
 // Lex implements goyacc interface
 func (l *lexer) Lex(lval *yySymType) int {
 	t, _ := l.nextToken()
-	if t.typ == parseEof {
+	l.tokPos = t.pos
+	l.tokTyp = t.typ
+	l.tokDepth = l.depth
+	switch t.typ {
+	case parseEof:
 		return 0
+	case parseErr:
+		l.errorAt(t.pos, t.val)
+	case token_curly_open:
+		l.depth++
+	case token_curly_close:
+		l.depth--
 	}
 	lval.token = t.val
+	lval.pos = t.pos
+	if t.typ == token_extension || t.typ > token_semi {
+		l.stmtPos = t.pos
+		l.stmtLevel = l.tokDepth
+	}
 	return int(t.typ)
 }
 
 // Error implements goyacc interface
 func (l *lexer) Error(e string) {
-	line, col := l.Position()
-	l.lastError = fmt.Errorf("%s - line %d, col %d", e, line, col)
+	if l.tokTyp == parseErr {
+		// lexer already reported problem
+		return
+	}
+	if l.tokPos == l.resumePos && (l.tokTyp == token_curly_close || l.tokTyp == parseEof) {
+		// problem right after a skipped statement is only because it was
+		// skipped. e.g. leaf with no body left
+		return
+	}
+	l.errorAt(l.tokPos, e)
 }
 
-func chkErr(l yyLexer, e error) bool {
+// chkErr records problem building definitions and parsing continues
+func chkErr(yylex yyLexer, e error) {
 	if e == nil {
-		return false
+		return
 	}
-	l.Error(e.Error())
-	return true
+	l := yylex.(*lexer)
+	l.errorAt(l.tokPos, e.Error())
+	l.builder.LastErr = nil
 }
 
-func chkErr2(l *lexer, keyword string, extension *meta.Extension) bool {
+func chkErr2(l *lexer, keyword string, extension *meta.Extension) {
 	if extension != nil {
 		l.builder.AddExtension(l.stack.peek(), keyword, extension)
 	}
-	if l.builder.LastErr != nil {
-		l.Error(l.builder.LastErr.Error())
-		return true
-	}
+	chkErr(l, l.builder.LastErr)
+}
 
-	return false
+// openBodies is the nesting level of statements the parser is in by counting
+// the '{' on the parser's stack
+func openBodies(stack []yySymType) int {
+	curlyOpen := yyTok2[token_curly_open-yyPrivate]
+	n := 0
+	for _, s := range stack {
+		if yyChk[s.yys] == curlyOpen {
+			n++
+		}
+	}
+	return n
 }
 
 func trimQuotes(s string) string {
@@ -61,7 +103,7 @@ func trimQuotes(s string) string {
 	return s
 }
 
-//line parser.y:60
+//line parser.y:102
 type yySymType struct {
 	yys     int
 	token   string
@@ -70,6 +112,7 @@ type yySymType struct {
 	num32   int
 	args    []string
 	ext     *meta.Extension
+	pos     int
 }
 
 const token_ident = 57346
@@ -157,6 +200,7 @@ const kywd_error_app_tag = 57427
 const kywd_error_message = 57428
 const kywd_bit = 57429
 const kywd_position = 57430
+const kywd_revision_date = 57431
 
 var yyToknames = [...]string{
 	"$end",
@@ -247,6 +291,7 @@ var yyToknames = [...]string{
 	"kywd_error_message",
 	"kywd_bit",
 	"kywd_position",
+	"kywd_revision_date",
 }
 var yyStatenames = [...]string{}
 
@@ -254,354 +299,410 @@ const yyEofCode = 1
 const yyErrCode = 2
 const yyInitialStackSize = 16
 
-//line parser.y:1534
+//line parser.y:1483
 
 //line yacctab:1
 var yyExca = [...]int{
 	-1, 1,
 	1, -1,
 	-2, 0,
+	-1, 124,
+	9, 340,
+	-2, 0,
+	-1, 126,
+	9, 231,
+	-2, 0,
+	-1, 137,
+	9, 329,
+	-2, 0,
+	-1, 248,
+	9, 341,
+	-2, 0,
+	-1, 255,
+	9, 350,
+	-2, 0,
+	-1, 280,
+	9, 232,
+	-2, 0,
+	-1, 293,
+	9, 388,
+	-2, 0,
+	-1, 366,
+	9, 330,
+	-2, 0,
+	-1, 425,
+	9, 54,
+	-2, 0,
+	-1, 426,
+	9, 54,
+	-2, 0,
+	-1, 446,
+	9, 205,
+	-2, 0,
+	-1, 492,
+	9, 54,
+	-2, 0,
+	-1, 493,
+	9, 54,
+	-2, 0,
+	-1, 508,
+	9, 55,
+	-2, 0,
+	-1, 533,
+	9, 206,
+	-2, 0,
 }
 
 const yyPrivate = 57344
 
-const yyLast = 1521
+const yyLast = 1602
 
 var yyAct = [...]int{
 
-	279, 625, 617, 13, 262, 276, 13, 329, 330, 569,
-	552, 388, 275, 392, 343, 453, 303, 509, 516, 361,
-	46, 529, 351, 398, 205, 45, 339, 369, 297, 47,
-	458, 44, 289, 432, 42, 318, 230, 220, 158, 41,
-	301, 290, 40, 299, 199, 251, 281, 39, 189, 244,
-	204, 16, 181, 168, 38, 164, 37, 212, 72, 287,
-	464, 465, 563, 632, 89, 89, 89, 634, 638, 25,
-	25, 25, 166, 89, 165, 479, 89, 268, 25, 459,
-	460, 25, 613, 89, 436, 439, 437, 438, 25, 418,
-	269, 27, 27, 27, 101, 102, 103, 561, 171, 300,
-	27, 560, 559, 27, 89, 558, 186, 164, 193, 25,
-	27, 202, 557, 216, 222, 502, 207, 273, 82, 164,
-	501, 235, 247, 254, 196, 293, 293, 26, 309, 26,
-	321, 27, 332, 342, 354, 364, 372, 236, 391, 178,
-	401, 195, 277, 337, 336, 11, 26, 631, 11, 232,
-	382, 337, 336, 179, 337, 336, 384, 182, 357, 325,
-	43, 383, 231, 258, 631, 25, 263, 381, 171, 256,
-	380, 296, 296, 228, 312, 379, 323, 177, 378, 346,
-	176, 186, 374, 377, 356, 305, 164, 27, 283, 193,
-	376, 163, 375, 174, 25, 241, 405, 240, 164, 202,
-	173, 268, 610, 271, 89, 3, 430, 456, 455, 25,
-	592, 166, 216, 165, 269, 587, 27, 266, 267, 514,
-	222, 409, 257, 26, 600, 407, 599, 598, 485, 597,
-	235, 27, 182, 410, 4, 585, 584, 413, 164, 596,
-	169, 595, 82, 417, 247, 164, 236, 218, 184, 239,
-	191, 254, 26, 200, 454, 214, 221, 431, 232, 434,
-	435, 505, 503, 233, 245, 252, 441, 291, 291, 426,
-	307, 231, 319, 228, 331, 340, 352, 362, 370, 445,
-	389, 468, 399, 457, 278, 295, 295, 12, 311, 293,
-	12, 258, 175, 448, 263, 421, 450, 256, 500, 477,
-	461, 476, 452, 443, 451, 442, 428, 89, 427, 635,
-	169, 260, 25, 302, 302, 89, 314, 282, 321, 498,
-	25, 471, 241, 184, 240, 139, 137, 138, 136, 495,
-	332, 191, 493, 491, 27, 296, 487, 474, 480, 342,
-	473, 200, 27, 131, 433, 130, 623, 325, 404, 472,
-	257, 354, 470, 475, 214, 129, 127, 128, 126, 467,
-	449, 364, 221, 447, 323, 484, 112, 424, 111, 372,
-	26, 425, 233, 488, 416, 357, 239, 107, 26, 106,
-	492, 423, 170, 382, 162, 346, 245, 161, 391, 384,
-	185, 50, 192, 252, 383, 201, 494, 215, 401, 496,
-	381, 356, 97, 380, 96, 234, 246, 253, 379, 292,
-	292, 378, 308, 280, 320, 374, 377, 341, 353, 363,
-	371, 499, 390, 376, 400, 375, 135, 134, 89, 512,
-	411, 291, 89, 25, 183, 133, 30, 25, 519, 260,
-	259, 132, 298, 298, 125, 313, 489, 490, 124, 295,
-	520, 123, 170, 555, 122, 27, 121, 534, 120, 27,
-	319, 113, 517, 110, 105, 185, 456, 455, 95, 160,
-	6, 89, 331, 192, 524, 525, 93, 302, 572, 95,
-	444, 340, 395, 201, 207, 536, 429, 415, 412, 172,
-	406, 26, 114, 352, 574, 26, 215, 187, 583, 194,
-	109, 607, 206, 362, 606, 227, 497, 486, 446, 512,
-	155, 370, 237, 154, 234, 152, 304, 304, 579, 315,
-	519, 326, 151, 335, 203, 358, 588, 385, 246, 394,
-	389, 403, 520, 523, 591, 253, 255, 150, 294, 294,
-	399, 310, 534, 322, 517, 589, 345, 355, 365, 373,
-	593, 393, 555, 402, 148, 89, 524, 525, 601, 172,
-	25, 183, 609, 30, 147, 146, 145, 144, 259, 572,
-	536, 510, 187, 292, 602, 143, 142, 578, 611, 141,
-	194, 89, 27, 414, 140, 574, 25, 190, 118, 468,
-	206, 117, 116, 115, 518, 553, 91, 332, 90, 620,
-	89, 628, 320, 621, 615, 25, 298, 616, 27, 579,
-	540, 227, 203, 629, 506, 523, 332, 620, 26, 636,
-	570, 237, 522, 341, 480, 628, 639, 27, 526, 624,
-	541, 62, 288, 61, 306, 353, 549, 550, 577, 637,
-	63, 261, 250, 249, 26, 363, 59, 640, 243, 242,
-	58, 510, 207, 371, 562, 360, 564, 565, 359, 68,
-	350, 566, 159, 26, 255, 349, 576, 67, 578, 198,
-	94, 197, 390, 581, 582, 285, 518, 32, 21, 568,
-	304, 21, 400, 567, 327, 324, 98, 99, 100, 586,
-	104, 89, 317, 108, 553, 316, 25, 64, 368, 367,
-	69, 284, 294, 274, 522, 60, 539, 537, 535, 326,
-	533, 570, 532, 511, 530, 528, 527, 89, 27, 119,
-	238, 335, 25, 229, 57, 89, 347, 338, 66, 577,
-	623, 322, 241, 208, 209, 545, 547, 554, 387, 331,
-	386, 618, 358, 626, 27, 149, 70, 551, 265, 153,
-	334, 521, 345, 333, 26, 65, 397, 576, 331, 618,
-	385, 396, 571, 531, 355, 89, 71, 626, 515, 207,
-	25, 190, 226, 225, 365, 395, 224, 223, 219, 394,
-	26, 544, 373, 34, 513, 546, 614, 542, 508, 403,
-	507, 217, 27, 511, 633, 575, 213, 248, 264, 211,
-	210, 393, 543, 33, 49, 548, 48, 36, 344, 35,
-	366, 402, 419, 89, 188, 29, 180, 28, 25, 89,
-	241, 608, 240, 167, 25, 89, 268, 22, 26, 641,
-	25, 20, 642, 521, 240, 538, 554, 19, 268, 269,
-	27, 18, 266, 267, 556, 89, 27, 408, 17, 15,
-	25, 269, 27, 571, 266, 267, 14, 10, 9, 8,
-	5, 2, 1, 157, 156, 207, 273, 82, 0, 580,
-	422, 0, 27, 0, 239, 0, 26, 207, 0, 82,
-	420, 420, 26, 619, 0, 627, 575, 272, 26, 89,
-	89, 573, 440, 0, 25, 25, 0, 241, 0, 240,
-	0, 619, 0, 0, 0, 0, 0, 0, 26, 627,
-	89, 0, 0, 0, 0, 25, 27, 27, 0, 248,
-	538, 0, 0, 0, 81, 0, 264, 0, 0, 76,
-	75, 0, 85, 462, 463, 77, 466, 27, 78, 0,
-	0, 83, 348, 556, 0, 84, 79, 80, 0, 469,
-	89, 239, 26, 26, 0, 25, 0, 0, 0, 590,
-	580, 0, 207, 273, 241, 0, 240, 0, 0, 0,
-	268, 0, 271, 26, 0, 0, 0, 27, 0, 0,
-	0, 0, 573, 269, 0, 0, 266, 267, 335, 0,
-	622, 478, 630, 0, 328, 0, 86, 0, 0, 481,
-	482, 0, 207, 273, 0, 0, 0, 335, 622, 0,
-	0, 82, 0, 26, 344, 89, 630, 0, 239, 0,
-	25, 0, 0, 0, 0, 0, 0, 270, 268, 81,
-	271, 0, 420, 420, 76, 75, 366, 85, 73, 74,
-	77, 269, 27, 78, 266, 267, 83, 0, 0, 0,
-	84, 79, 80, 0, 0, 0, 0, 0, 0, 0,
-	0, 86, 0, 0, 87, 0, 88, 207, 273, 82,
-	0, 0, 0, 0, 0, 0, 0, 89, 26, 92,
-	0, 7, 25, 51, 0, 30, 0, 0, 0, 272,
-	0, 81, 0, 0, 0, 504, 76, 75, 54, 85,
-	73, 74, 77, 0, 27, 78, 0, 0, 83, 0,
-	52, 53, 84, 79, 80, 0, 0, 0, 0, 23,
-	24, 0, 0, 86, 0, 0, 87, 0, 88, 0,
-	0, 82, 31, 89, 55, 0, 0, 7, 25, 51,
-	26, 30, 0, 0, 0, 56, 0, 81, 0, 0,
-	0, 0, 76, 75, 54, 85, 73, 74, 77, 0,
-	27, 78, 0, 0, 83, 0, 52, 53, 84, 79,
-	80, 0, 0, 0, 0, 23, 24, 0, 0, 86,
-	0, 0, 87, 0, 88, 0, 0, 82, 31, 0,
-	55, 0, 0, 0, 594, 0, 26, 89, 0, 612,
-	0, 56, 25, 0, 0, 0, 0, 603, 604, 605,
-	268, 81, 0, 0, 0, 0, 76, 75, 0, 85,
-	73, 74, 77, 269, 27, 78, 0, 0, 83, 0,
-	0, 0, 84, 79, 80, 0, 0, 0, 0, 0,
-	0, 0, 0, 86, 0, 0, 87, 0, 88, 207,
-	273, 82, 89, 0, 0, 0, 0, 25, 0, 0,
-	26, 0, 0, 0, 286, 268, 81, 0, 0, 0,
-	0, 76, 75, 0, 85, 73, 74, 77, 269, 27,
-	78, 0, 0, 83, 0, 0, 0, 84, 79, 80,
-	0, 0, 0, 0, 0, 0, 0, 0, 86, 0,
-	0, 87, 0, 88, 207, 273, 82, 89, 0, 483,
-	0, 0, 25, 0, 0, 26, 0, 0, 0, 286,
-	0, 81, 0, 0, 0, 0, 76, 75, 0, 85,
-	73, 74, 77, 0, 27, 78, 0, 0, 83, 348,
-	0, 0, 84, 79, 80, 0, 0, 0, 0, 89,
-	0, 0, 0, 86, 25, 0, 87, 0, 88, 207,
-	273, 82, 0, 81, 0, 0, 0, 0, 76, 75,
-	26, 85, 73, 74, 77, 0, 27, 78, 0, 0,
-	83, 348, 0, 0, 84, 79, 80, 0, 0, 0,
-	0, 89, 0, 0, 0, 86, 25, 0, 87, 0,
-	88, 207, 273, 82, 0, 81, 0, 0, 0, 0,
-	76, 75, 26, 85, 73, 74, 77, 0, 27, 78,
-	0, 0, 83, 0, 0, 0, 84, 79, 80, 0,
-	0, 0, 0, 89, 0, 0, 0, 86, 25, 0,
-	87, 0, 88, 207, 0, 82, 0, 81, 0, 0,
-	0, 0, 76, 75, 26, 85, 73, 74, 77, 0,
-	27, 78, 0, 0, 83, 0, 89, 0, 84, 79,
-	80, 0, 0, 0, 0, 0, 0, 0, 0, 86,
-	81, 0, 87, 0, 88, 76, 75, 82, 85, 73,
-	74, 77, 0, 0, 78, 0, 26, 83, 0, 0,
-	0, 84, 79, 80, 0, 0, 0, 0, 0, 0,
-	0, 0, 86, 0, 0, 87, 0, 88, 0, 0,
-	82,
+	284, 638, 281, 456, 630, 335, 16, 336, 558, 16,
+	615, 404, 575, 534, 514, 267, 280, 398, 394, 367,
+	349, 521, 288, 507, 375, 209, 461, 49, 54, 345,
+	208, 357, 287, 48, 47, 45, 44, 324, 43, 294,
+	302, 256, 306, 249, 235, 42, 224, 295, 41, 40,
+	161, 216, 203, 304, 185, 192, 50, 182, 169, 435,
+	76, 292, 467, 468, 104, 105, 106, 308, 167, 282,
+	166, 94, 19, 651, 28, 14, 28, 165, 14, 439,
+	442, 440, 441, 3, 645, 569, 462, 463, 167, 609,
+	166, 608, 94, 626, 619, 625, 30, 28, 30, 567,
+	566, 172, 199, 46, 607, 605, 606, 604, 480, 188,
+	479, 197, 4, 565, 206, 165, 220, 226, 455, 30,
+	454, 446, 305, 445, 240, 252, 259, 222, 298, 298,
+	165, 315, 29, 327, 29, 338, 348, 360, 370, 378,
+	431, 397, 430, 407, 77, 232, 164, 165, 265, 94,
+	307, 307, 644, 320, 28, 29, 310, 310, 264, 388,
+	303, 303, 363, 319, 237, 194, 390, 362, 263, 172,
+	170, 236, 389, 387, 386, 385, 30, 384, 186, 268,
+	195, 183, 188, 204, 383, 218, 225, 382, 381, 331,
+	311, 241, 197, 238, 250, 257, 180, 296, 296, 142,
+	313, 141, 325, 206, 337, 346, 358, 368, 376, 564,
+	395, 411, 405, 175, 563, 506, 220, 94, 233, 417,
+	493, 505, 28, 184, 226, 33, 174, 415, 413, 504,
+	200, 300, 300, 179, 317, 240, 178, 94, 170, 416,
+	624, 165, 28, 184, 30, 33, 194, 420, 262, 252,
+	176, 186, 232, 177, 183, 424, 259, 94, 165, 421,
+	94, 195, 28, 193, 30, 28, 193, 429, 94, 621,
+	434, 165, 204, 28, 601, 237, 460, 596, 265, 444,
+	29, 94, 236, 471, 30, 218, 28, 30, 264, 437,
+	438, 595, 451, 225, 298, 30, 448, 453, 263, 190,
+	29, 464, 241, 593, 238, 94, 410, 647, 30, 268,
+	28, 459, 458, 140, 94, 139, 307, 503, 250, 190,
+	29, 501, 310, 29, 327, 257, 303, 498, 134, 283,
+	133, 29, 30, 474, 496, 15, 338, 494, 15, 190,
+	490, 477, 190, 483, 132, 348, 131, 476, 475, 644,
+	94, 473, 482, 470, 130, 28, 129, 360, 457, 343,
+	342, 478, 452, 296, 290, 450, 115, 370, 114, 110,
+	24, 109, 428, 24, 487, 378, 94, 30, 262, 28,
+	331, 28, 363, 343, 342, 423, 495, 362, 491, 100,
+	492, 99, 488, 325, 397, 388, 436, 300, 53, 497,
+	426, 30, 390, 30, 407, 337, 425, 9, 389, 387,
+	386, 385, 499, 384, 346, 502, 96, 138, 137, 136,
+	383, 135, 94, 382, 381, 128, 358, 28, 343, 342,
+	171, 127, 517, 519, 126, 125, 368, 29, 187, 29,
+	196, 124, 123, 205, 376, 219, 116, 113, 108, 30,
+	510, 5, 524, 239, 251, 258, 561, 297, 297, 527,
+	314, 636, 326, 395, 539, 347, 359, 369, 377, 526,
+	396, 98, 406, 405, 447, 544, 432, 522, 422, 529,
+	419, 578, 459, 458, 530, 29, 77, 98, 163, 253,
+	269, 94, 94, 433, 541, 418, 28, 28, 171, 173,
+	350, 515, 372, 582, 525, 589, 412, 189, 117, 198,
+	112, 187, 210, 581, 517, 231, 590, 591, 30, 30,
+	500, 196, 242, 585, 489, 559, 309, 309, 597, 321,
+	449, 332, 205, 341, 158, 364, 524, 391, 157, 400,
+	523, 409, 600, 527, 211, 219, 598, 602, 580, 155,
+	576, 539, 610, 526, 154, 29, 616, 98, 561, 528,
+	233, 522, 544, 529, 239, 618, 620, 173, 530, 153,
+	151, 150, 149, 148, 511, 578, 611, 285, 251, 147,
+	189, 541, 146, 515, 583, 258, 531, 622, 525, 145,
+	198, 94, 471, 443, 555, 556, 28, 582, 246, 94,
+	245, 210, 144, 584, 28, 143, 338, 581, 633, 121,
+	641, 628, 568, 253, 570, 571, 120, 585, 30, 572,
+	269, 119, 231, 297, 523, 118, 30, 559, 7, 338,
+	633, 587, 588, 242, 649, 6, 483, 634, 641, 652,
+	650, 629, 580, 528, 576, 546, 642, 592, 653, 401,
+	637, 211, 244, 326, 29, 547, 66, 293, 65, 312,
+	599, 67, 29, 94, 266, 246, 255, 245, 28, 254,
+	246, 273, 245, 276, 347, 337, 63, 631, 583, 639,
+	248, 247, 62, 366, 274, 365, 359, 271, 272, 286,
+	30, 207, 309, 72, 356, 355, 369, 584, 337, 631,
+	71, 202, 201, 260, 377, 299, 299, 639, 316, 350,
+	328, 35, 87, 351, 361, 371, 379, 162, 399, 244,
+	408, 574, 332, 396, 244, 573, 29, 333, 97, 330,
+	94, 372, 323, 406, 341, 28, 94, 322, 68, 245,
+	374, 28, 373, 273, 101, 102, 103, 73, 107, 289,
+	627, 111, 212, 213, 279, 364, 274, 30, 646, 271,
+	272, 516, 64, 30, 545, 542, 540, 94, 538, 648,
+	537, 77, 28, 391, 535, 533, 94, 532, 122, 243,
+	207, 234, 211, 246, 87, 560, 551, 553, 211, 61,
+	509, 509, 400, 29, 30, 353, 94, 654, 414, 29,
+	655, 28, 409, 344, 70, 152, 636, 393, 392, 156,
+	577, 74, 557, 270, 536, 261, 340, 301, 301, 339,
+	318, 69, 329, 30, 403, 352, 401, 402, 380, 75,
+	29, 520, 550, 260, 77, 230, 552, 229, 548, 94,
+	228, 227, 223, 516, 28, 543, 246, 37, 245, 518,
+	513, 512, 273, 549, 562, 221, 554, 509, 509, 29,
+	217, 215, 94, 214, 36, 274, 30, 28, 271, 272,
+	52, 299, 51, 594, 39, 273, 38, 508, 191, 586,
+	32, 181, 31, 168, 25, 23, 22, 560, 274, 30,
+	21, 211, 278, 87, 94, 20, 18, 17, 13, 28,
+	244, 328, 29, 12, 577, 11, 8, 2, 1, 160,
+	159, 0, 0, 277, 211, 278, 87, 0, 0, 0,
+	0, 30, 351, 0, 0, 29, 0, 0, 0, 427,
+	0, 0, 543, 0, 361, 0, 77, 632, 334, 640,
+	91, 94, 0, 0, 371, 261, 211, 278, 0, 0,
+	0, 0, 379, 0, 0, 86, 562, 29, 0, 632,
+	81, 80, 0, 90, 78, 79, 82, 640, 0, 83,
+	0, 399, 88, 586, 0, 0, 89, 84, 85, 0,
+	0, 408, 0, 301, 0, 0, 0, 91, 0, 0,
+	92, 0, 93, 465, 466, 87, 469, 246, 0, 245,
+	0, 0, 0, 273, 341, 276, 635, 0, 643, 472,
+	0, 0, 0, 329, 0, 0, 274, 0, 0, 271,
+	272, 0, 0, 0, 0, 0, 0, 341, 635, 0,
+	0, 0, 0, 0, 352, 0, 643, 0, 0, 0,
+	0, 0, 0, 0, 87, 0, 0, 0, 0, 77,
+	0, 244, 481, 0, 94, 0, 0, 0, 579, 28,
+	484, 485, 0, 0, 380, 0, 275, 273, 86, 276,
+	0, 0, 0, 81, 80, 0, 90, 78, 79, 82,
+	274, 30, 83, 271, 272, 88, 0, 0, 0, 89,
+	84, 85, 0, 0, 0, 0, 0, 0, 0, 0,
+	91, 0, 0, 92, 0, 93, 211, 278, 87, 0,
+	0, 0, 0, 0, 0, 0, 0, 29, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 277, 77,
+	0, 0, 0, 0, 94, 0, 95, 0, 10, 28,
+	55, 0, 33, 0, 0, 0, 0, 0, 86, 0,
+	0, 0, 579, 81, 80, 58, 90, 78, 79, 82,
+	0, 30, 83, 0, 0, 88, 0, 56, 57, 89,
+	84, 85, 0, 0, 0, 0, 26, 27, 0, 0,
+	91, 0, 0, 92, 0, 93, 0, 0, 87, 34,
+	77, 59, 0, 0, 0, 94, 0, 29, 0, 10,
+	28, 55, 60, 33, 0, 0, 0, 0, 0, 86,
+	0, 0, 0, 0, 81, 80, 58, 90, 78, 79,
+	82, 0, 30, 83, 0, 0, 88, 0, 56, 57,
+	89, 84, 85, 0, 0, 0, 0, 26, 27, 0,
+	0, 91, 0, 0, 92, 0, 93, 0, 0, 87,
+	34, 0, 59, 0, 603, 77, 0, 0, 29, 0,
+	94, 0, 623, 60, 0, 28, 0, 0, 612, 613,
+	614, 617, 617, 273, 86, 0, 0, 0, 0, 81,
+	80, 0, 90, 78, 79, 82, 274, 30, 83, 0,
+	0, 88, 0, 0, 0, 89, 84, 85, 0, 0,
+	0, 0, 0, 0, 0, 0, 91, 0, 0, 92,
+	77, 93, 211, 278, 87, 94, 0, 0, 0, 0,
+	28, 0, 0, 29, 0, 0, 0, 291, 273, 86,
+	0, 0, 0, 0, 81, 80, 0, 90, 78, 79,
+	82, 274, 30, 83, 0, 0, 88, 0, 0, 0,
+	89, 84, 85, 0, 0, 0, 0, 0, 0, 0,
+	0, 91, 0, 0, 92, 77, 93, 211, 278, 87,
+	94, 0, 486, 0, 0, 28, 0, 0, 29, 0,
+	0, 0, 291, 0, 86, 0, 0, 0, 0, 81,
+	80, 0, 90, 78, 79, 82, 0, 30, 83, 0,
+	0, 88, 354, 0, 0, 89, 84, 85, 0, 0,
+	77, 0, 0, 0, 0, 94, 91, 0, 0, 92,
+	28, 93, 211, 278, 87, 0, 0, 0, 0, 86,
+	0, 0, 0, 29, 81, 80, 0, 90, 78, 79,
+	82, 0, 30, 83, 0, 0, 88, 354, 0, 0,
+	89, 84, 85, 0, 0, 77, 0, 0, 0, 0,
+	94, 91, 0, 0, 92, 28, 93, 211, 278, 87,
+	0, 0, 0, 0, 86, 0, 0, 0, 29, 81,
+	80, 0, 90, 78, 79, 82, 0, 30, 83, 0,
+	0, 88, 0, 0, 0, 89, 84, 85, 0, 0,
+	77, 0, 0, 0, 0, 94, 91, 0, 0, 92,
+	28, 93, 211, 0, 87, 0, 0, 0, 0, 86,
+	0, 0, 0, 29, 81, 80, 0, 90, 78, 79,
+	82, 0, 30, 83, 0, 0, 88, 0, 94, 0,
+	89, 84, 85, 28, 0, 0, 0, 0, 0, 0,
+	0, 91, 86, 0, 92, 0, 93, 81, 80, 87,
+	90, 0, 0, 82, 0, 30, 83, 0, 29, 88,
+	354, 0, 0, 89, 84, 85, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	211, 278, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 29,
 }
 var yyPact = [...]int{
 
-	180, -1000, 1126, 594, 592, 1070, -1000, 474, -1000, -1000,
+	58, -1000, 443, 631, 624, 1188, -1000, -1000, 1127, -1000,
+	466, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, 381, 466, 466, 466, -7,
+	466, 440, 361, 466, 505, 439, 358, 438, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, 394, 474, 474, 474, 23, 474, 456, 369,
-	474, 495, 455, 358, 453, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, 503, 621, 617, 612, 605,
+	466, 434, 433, 427, 426, 423, 417, 346, 336, 320,
+	413, 411, 410, 409, 305, 191, -1000, -1000, 601, 598,
+	585, 578, 575, 569, 568, 567, 566, 466, 565, 550,
+	545, 466, 534, 530, 482, -1000, -1000, 60, -1000, -1000,
+	369, 216, 203, 60, 243, 226, 223, 186, 230, -1000,
+	253, 92, 220, 729, -1000, 62, 142, -1000, -1000, -1000,
+	-1000, -1000, 22, 656, 1498, 1047, 1308, 832, 832, -1000,
+	855, -1000, 887, -1000, 274, 1408, 729, 1453, 1531, -1000,
+	592, -1000, 485, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, 22, -1000, -1000, -1000, 22, -1000, -1000, 80,
+	482, -1000, 22, -1000, -1000, 501, -1000, 307, 789, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, 487, 589, 588, 587, 584, 474, 450, 448, 446,
-	443, 440, 436, 348, 347, 335, 433, 427, 419, 418,
-	318, 317, -1000, 580, 575, 572, 571, 563, 562, 561,
-	560, 550, 474, 533, 518, 511, 474, 509, 506, 463,
-	379, 376, -1000, -1000, 64, -1000, -1000, 882, 190, 183,
-	64, 170, 167, 129, 143, 548, -1000, 758, 131, 114,
-	710, -1000, 182, 97, -1000, -1000, -1000, -1000, -1000, 0,
-	308, 1426, 1008, 1245, 806, 806, -1000, 57, -1000, 943,
-	-1000, 69, 1342, 710, 1384, 903, -1000, 425, -1000, 593,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 0,
-	-1000, -1000, -1000, 0, -1000, -1000, 203, 463, -1000, 0,
-	-1000, -1000, -1000, -1000, 485, -1000, 464, 838, -1000, -1000,
+	-1000, 210, -1000, -1000, 490, -1000, -1000, -1000, -1000, -1000,
+	475, 250, -1000, 473, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, 376, 729, -1000, -1000, -1000, -1000, -1000, 398, 392,
+	-1000, 466, -1000, -1000, 363, 62, -1000, -1000, -1000, -1000,
+	-1000, 132, 471, 484, -1000, -1000, -1000, -1000, 388, 388,
+	388, -1000, -1000, 2, 584, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, 113, 469, 482, 526, 356, 1498, -1000,
+	-1000, -1000, -1000, -1000, 353, 1047, -1000, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	421, -1000, -1000, 483, -1000, -1000, -1000, -1000, 574, -1000,
-	482, -1000, -1000, -1000, -1000, -1000, -1000, 365, 710, -1000,
-	-1000, -1000, -1000, -1000, 1459, 1459, -1000, 474, 373, 359,
-	362, 182, -1000, -1000, -1000, -1000, -1000, 298, 481, 197,
-	-1000, -1000, -1000, -1000, 336, 336, 336, -1000, 7, 883,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 295, 475,
-	463, 504, 354, 1426, -1000, -1000, -1000, -1000, -1000, 351,
-	1008, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, 294, 202, 461, 32, 32,
-	474, 474, -22, 474, 350, 1245, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, 474, 343, 806, -1000,
+	110, 306, 477, 39, 39, 466, 466, -20, 466, 344,
+	1308, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, 466, 342, 832, -1000, -1000, -1000, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, 340, 331, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, 328, 943, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 291, 474, 66,
-	-1000, -1000, -1000, -1000, -1000, -1000, 474, 474, 1300, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 220, 503, 327,
-	710, -1000, -1000, -1000, -1000, -1000, 1459, 1459, -1000, 324,
-	1384, -1000, -1000, -1000, -1000, -1000, -1000, 323, 903, -1000,
+	-1000, 339, 338, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, 332, 887, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, 100, 466, 343, -1000, -1000, -1000, -1000,
+	-1000, -1000, 466, 466, 1363, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, 384, 520, 331, 729, -1000, -1000, -1000,
+	-1000, -1000, 382, 212, -1000, 328, 1453, -1000, -1000, -1000,
+	-1000, -1000, -1000, 325, 1531, -1000, -1000, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, 320, 425, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, 502, 310, 593, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 289, -1000, -1000,
-	-1000, -1000, 110, -1000, -1000, 105, -1000, -1000, 253, 1459,
-	-1000, 252, 64, -1000, -1000, -1000, -1000, -1000, 153, -1000,
-	-1000, -1000, -1000, 181, -1000, -1000, 203, -1000, -1000, -1000,
-	-1000, -1000, -1000, 718, 203, 203, -1000, -1000, -1000, -1000,
-	-1000, -1000, 882, 102, 95, -1000, -1000, 92, 91, -1000,
-	-1000, 87, 64, 52, 203, 203, 0, -1000, -1000, 64,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 818, 0, -1000,
-	-1000, 64, 64, -1000, -1000, 1245, -1000, -1000, -1000, 227,
-	226, -1000, -1000, -1000, -1000, -1000, -1000, 203, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 206, 153, -1000,
-	-1000, -1000, -1000, -1000, 32, 950, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 201, 718, -1000,
-	-1000, 474, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 231,
-	219, 216, 461, 32, 474, 474, 474, 500, 497, -1000,
-	-1000, 812, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 193, 818, -1000,
+	-1000, -1000, 318, 592, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, 516, 312, 485, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, 308, -1000, -1000, -1000, -1000, 219, 211,
+	-1000, -1000, 205, -1000, -1000, 934, 934, 60, -1000, -1000,
+	-1000, 367, -1000, -1000, -1000, -1000, 983, -1000, -1000, 80,
+	-1000, -1000, -1000, -1000, -1000, -1000, 769, 80, 80, -1000,
+	-1000, -1000, -1000, -1000, -1000, 369, 204, 199, -1000, -1000,
+	103, 90, -1000, -1000, 89, 60, 75, 80, 80, 22,
+	-1000, -1000, 60, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	723, 22, -1000, -1000, 60, 60, -1000, -1000, 1308, -1000,
+	-1000, -1000, 934, 934, -1000, -1000, -1000, -1000, -1000, -1000,
+	80, -1000, -1000, -1000, -1000, -1000, -1000, 294, 934, -1000,
+	282, -1000, 268, 367, -1000, -1000, -1000, -1000, -1000, 39,
+	651, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, 265, 769, -1000, -1000, 466, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, 97, 96, 81, 477, 39,
+	466, 466, 466, 552, 552, -1000, -1000, 85, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, 1190, -1000, -1000, -1000, -1000, -1000, 72,
-	-1000, -1000, -1000, -1000, 64, -1000, 69, -1000, 684, -1000,
-	76, 53, 203, 0, 0, 0, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, 58, 300, -1000, -1000, -1000,
-	-1000, -1000, -1000, 461, 59, -1000, -1000, -1000, -1000, -1000,
-	-1000, 461, -1000, -1000, -1000, -1000, -1000, 203, -1000, -1000,
-	203, -1000, -1000,
+	-1000, -1000, -1000, 260, 723, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 1253,
+	231, 86, -1000, -1000, -1000, -1000, -1000, -1000, 83, -1000,
+	-1000, -1000, -1000, 60, -1000, 274, -1000, 415, -1000, 261,
+	74, 80, 22, 22, 22, -1000, -1000, 22, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 298, 760,
+	-1000, -1000, -1000, -1000, -1000, -1000, 477, 64, -1000, -1000,
+	-1000, -1000, -1000, -1000, 477, -1000, -1000, -1000, -1000, -1000,
+	80, -1000, -1000, 80, -1000, -1000,
 }
 var yyPgo = [...]int{
 
-	0, 30, 15, 38, 662, 864, 863, 58, 191, 862,
-	861, 860, 470, 859, 858, 857, 142, 284, 0, 856,
-	849, 51, 848, 841, 837, 831, 675, 827, 823, 53,
-	391, 817, 816, 52, 815, 814, 48, 89, 812, 809,
-	807, 56, 54, 47, 42, 39, 34, 160, 31, 25,
-	20, 29, 806, 804, 803, 800, 799, 57, 796, 791,
-	790, 788, 17, 784, 783, 778, 37, 777, 776, 33,
-	773, 772, 768, 18, 28, 4, 16, 317, 188, 99,
-	40, 41, 766, 761, 756, 23, 413, 755, 7, 8,
-	753, 750, 748, 46, 747, 10, 746, 740, 738, 11,
-	13, 728, 727, 26, 14, 726, 12, 724, 723, 36,
-	720, 716, 715, 21, 714, 712, 710, 708, 707, 706,
-	705, 703, 5, 701, 700, 699, 698, 27, 697, 695,
-	692, 35, 685, 684, 9, 683, 679, 677, 671, 669,
-	44, 50, 24, 667, 665, 660, 22, 659, 658, 655,
-	19, 650, 649, 648, 49, 646, 643, 642, 45, 641,
-	43, 640, 634, 633, 59, 632, 32, 631, 630, 629,
-	1, 613, 610, 607, 2, 603,
+	0, 26, 3, 50, 717, 10, 910, 909, 60, 146,
+	908, 907, 906, 407, 905, 903, 898, 69, 329, 0,
+	897, 896, 72, 895, 890, 886, 885, 364, 884, 883,
+	58, 398, 882, 881, 57, 54, 880, 878, 55, 23,
+	877, 876, 874, 49, 48, 45, 38, 36, 35, 103,
+	34, 33, 27, 56, 872, 870, 28, 864, 863, 861,
+	51, 860, 855, 851, 850, 14, 849, 847, 842, 46,
+	841, 840, 59, 837, 835, 831, 21, 40, 15, 67,
+	32, 22, 122, 42, 47, 829, 827, 824, 11, 577,
+	821, 5, 7, 819, 816, 813, 689, 812, 8, 811,
+	808, 807, 18, 17, 804, 803, 29, 20, 795, 16,
+	789, 781, 44, 779, 777, 775, 13, 774, 770, 768,
+	766, 765, 764, 762, 754, 2, 749, 747, 742, 740,
+	24, 738, 737, 732, 37, 729, 727, 12, 725, 721,
+	711, 702, 701, 52, 30, 25, 700, 695, 694, 31,
+	693, 685, 683, 19, 682, 681, 680, 43, 676, 669,
+	666, 41, 664, 53, 661, 659, 658, 61, 657, 39,
+	656, 655, 650, 1, 646, 645, 641, 4, 637,
 }
 var yyR1 = [...]int{
 
-	0, 9, 10, 10, 11, 11, 12, 12, 12, 12,
-	12, 12, 12, 12, 12, 12, 12, 12, 12, 12,
-	12, 27, 13, 13, 28, 28, 29, 29, 29, 29,
-	31, 32, 32, 21, 33, 33, 33, 33, 33, 33,
-	19, 34, 35, 35, 36, 36, 36, 36, 36, 20,
-	20, 37, 37, 26, 26, 26, 26, 26, 26, 26,
-	26, 26, 26, 26, 26, 26, 26, 26, 26, 38,
-	38, 24, 24, 54, 55, 55, 56, 56, 57, 57,
-	57, 57, 58, 58, 59, 60, 60, 61, 61, 62,
-	62, 62, 62, 63, 25, 64, 65, 65, 66, 66,
-	66, 66, 66, 66, 66, 67, 68, 70, 71, 69,
-	72, 72, 73, 73, 73, 73, 73, 73, 73, 73,
-	73, 53, 53, 82, 83, 83, 84, 84, 85, 85,
-	85, 85, 85, 47, 47, 87, 88, 88, 89, 89,
-	89, 89, 89, 90, 91, 86, 92, 93, 93, 94,
-	94, 95, 95, 95, 95, 52, 52, 96, 97, 97,
-	98, 98, 99, 99, 99, 99, 99, 99, 100, 48,
-	102, 102, 103, 103, 103, 103, 103, 103, 103, 101,
-	104, 105, 39, 107, 108, 108, 109, 109, 109, 109,
-	109, 109, 109, 3, 3, 76, 81, 81, 110, 111,
-	111, 112, 112, 113, 113, 113, 113, 113, 113, 113,
-	113, 113, 114, 114, 119, 119, 119, 118, 17, 17,
-	17, 117, 42, 120, 121, 121, 106, 106, 122, 122,
-	122, 122, 122, 122, 122, 122, 122, 123, 124, 51,
-	125, 125, 126, 126, 127, 127, 127, 127, 127, 127,
-	127, 127, 127, 127, 127, 127, 127, 127, 127, 127,
-	128, 46, 46, 129, 129, 130, 130, 131, 131, 131,
-	131, 131, 131, 131, 131, 133, 134, 134, 134, 134,
-	134, 134, 134, 134, 134, 134, 134, 132, 132, 135,
-	136, 136, 23, 137, 138, 138, 139, 139, 140, 140,
-	140, 140, 140, 140, 140, 141, 142, 49, 143, 144,
-	144, 145, 145, 146, 146, 146, 146, 146, 146, 146,
-	50, 147, 148, 148, 149, 149, 150, 150, 150, 150,
-	150, 40, 151, 152, 152, 153, 153, 154, 154, 154,
-	154, 41, 155, 156, 157, 157, 79, 79, 80, 158,
-	158, 158, 158, 158, 158, 158, 158, 158, 158, 158,
-	158, 158, 160, 160, 159, 75, 45, 45, 162, 162,
-	162, 162, 162, 162, 162, 162, 162, 161, 161, 43,
-	163, 164, 165, 165, 166, 166, 166, 166, 166, 166,
-	166, 166, 166, 166, 166, 166, 166, 166, 166, 78,
-	4, 4, 2, 2, 1, 1, 77, 44, 167, 116,
-	116, 168, 169, 169, 170, 170, 170, 170, 170, 171,
-	115, 115, 172, 173, 173, 174, 174, 174, 174, 174,
-	175, 16, 18, 14, 15, 22, 74, 8, 8, 30,
-	7, 5, 5, 6, 6,
+	0, 10, 11, 11, 12, 12, 13, 13, 13, 13,
+	13, 13, 13, 13, 13, 13, 13, 13, 13, 13,
+	13, 28, 14, 14, 29, 29, 30, 30, 30, 30,
+	32, 33, 33, 22, 35, 34, 34, 34, 34, 34,
+	34, 34, 20, 36, 37, 37, 38, 38, 38, 38,
+	38, 38, 21, 21, 39, 39, 27, 27, 27, 27,
+	27, 27, 27, 27, 27, 27, 27, 27, 27, 27,
+	27, 27, 27, 40, 40, 56, 25, 25, 57, 58,
+	58, 59, 59, 60, 60, 60, 60, 61, 61, 62,
+	63, 63, 64, 64, 65, 65, 65, 65, 66, 26,
+	67, 68, 68, 69, 69, 69, 69, 69, 69, 69,
+	69, 70, 71, 73, 74, 72, 75, 75, 76, 76,
+	76, 76, 76, 76, 76, 76, 76, 55, 55, 85,
+	86, 86, 87, 87, 88, 88, 88, 88, 88, 49,
+	49, 90, 91, 91, 92, 92, 92, 92, 92, 93,
+	94, 89, 95, 96, 96, 97, 97, 98, 98, 98,
+	98, 54, 54, 99, 100, 100, 101, 101, 102, 102,
+	102, 102, 102, 102, 103, 50, 105, 105, 106, 106,
+	106, 106, 106, 106, 106, 104, 107, 108, 41, 110,
+	111, 111, 112, 112, 112, 112, 112, 112, 112, 3,
+	3, 79, 84, 84, 113, 114, 114, 115, 115, 116,
+	116, 116, 116, 116, 116, 116, 116, 116, 116, 117,
+	117, 122, 122, 122, 121, 18, 18, 18, 120, 44,
+	123, 124, 124, 109, 109, 125, 125, 125, 125, 125,
+	125, 125, 125, 125, 126, 127, 53, 128, 128, 129,
+	129, 130, 130, 130, 130, 130, 130, 130, 130, 130,
+	130, 130, 130, 130, 130, 130, 130, 131, 48, 48,
+	132, 132, 133, 133, 134, 134, 134, 134, 134, 134,
+	134, 134, 136, 137, 137, 137, 137, 137, 137, 137,
+	137, 137, 137, 137, 135, 135, 138, 139, 139, 24,
+	140, 141, 141, 142, 142, 143, 143, 143, 143, 143,
+	143, 143, 144, 145, 51, 146, 147, 147, 148, 148,
+	149, 149, 149, 149, 149, 149, 149, 52, 150, 151,
+	151, 152, 152, 153, 153, 153, 153, 153, 42, 154,
+	155, 155, 156, 156, 157, 157, 157, 157, 43, 158,
+	159, 160, 160, 82, 82, 83, 161, 161, 161, 161,
+	161, 161, 161, 161, 161, 161, 161, 161, 161, 163,
+	163, 162, 78, 47, 47, 165, 165, 165, 165, 165,
+	165, 165, 165, 165, 164, 164, 45, 166, 167, 168,
+	168, 169, 169, 169, 169, 169, 169, 169, 169, 169,
+	169, 169, 169, 169, 169, 169, 169, 81, 5, 5,
+	4, 4, 2, 2, 1, 1, 80, 46, 170, 119,
+	119, 171, 172, 172, 173, 173, 173, 173, 173, 174,
+	118, 118, 175, 176, 176, 177, 177, 177, 177, 177,
+	178, 17, 19, 15, 16, 23, 77, 9, 9, 31,
+	8, 6, 6, 7, 7,
 }
 var yyR2 = [...]int{
 
-	0, 3, 3, 3, 1, 2, 3, 1, 1, 1,
+	0, 4, 2, 2, 1, 2, 3, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 2, 2, 4, 1, 2, 1, 1, 1, 1,
-	2, 1, 2, 3, 1, 3, 1, 1, 1, 1,
-	4, 2, 1, 2, 3, 1, 1, 1, 1, 2,
-	4, 0, 1, 1, 1, 1, 1, 1, 1, 1,
+	2, 1, 2, 3, 3, 1, 3, 1, 1, 1,
+	1, 1, 4, 2, 1, 2, 3, 1, 1, 1,
+	1, 1, 2, 4, 0, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	2, 2, 4, 2, 0, 1, 1, 2, 1, 1,
-	1, 1, 2, 4, 2, 0, 1, 1, 2, 1,
-	1, 1, 1, 3, 4, 2, 1, 2, 1, 1,
-	1, 2, 2, 2, 1, 3, 2, 2, 2, 3,
-	1, 2, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 2, 4, 2, 0, 1, 1, 2, 1, 1,
-	1, 1, 1, 2, 4, 2, 1, 2, 1, 1,
-	1, 1, 1, 3, 3, 3, 2, 2, 4, 1,
-	2, 1, 1, 1, 1, 2, 4, 2, 0, 1,
-	1, 2, 1, 1, 1, 1, 1, 1, 3, 4,
-	1, 2, 1, 1, 1, 1, 1, 1, 1, 2,
-	4, 2, 4, 2, 1, 2, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 3, 2, 4, 2, 0,
-	1, 1, 2, 1, 3, 1, 1, 1, 1, 1,
-	1, 1, 2, 4, 2, 2, 2, 3, 3, 3,
-	3, 3, 4, 2, 0, 1, 1, 2, 1, 1,
-	1, 1, 1, 1, 1, 1, 1, 3, 2, 4,
-	0, 1, 1, 2, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	2, 2, 4, 0, 1, 1, 2, 1, 1, 1,
-	1, 1, 1, 1, 1, 2, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 1, 1, 2, 4, 1,
+	1, 1, 1, 1, 2, 1, 2, 4, 2, 0,
+	1, 1, 2, 1, 1, 1, 1, 2, 4, 2,
+	0, 1, 1, 2, 1, 1, 1, 1, 3, 4,
+	2, 1, 2, 1, 1, 1, 2, 2, 2, 1,
+	1, 3, 2, 2, 2, 3, 1, 2, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 2, 4, 2,
+	0, 1, 1, 2, 1, 1, 1, 1, 1, 2,
+	4, 2, 1, 2, 1, 1, 1, 1, 1, 3,
+	3, 3, 2, 2, 4, 1, 2, 1, 1, 1,
 	1, 2, 4, 2, 0, 1, 1, 2, 1, 1,
-	1, 1, 3, 3, 1, 2, 2, 4, 2, 0,
-	1, 1, 2, 1, 1, 1, 1, 3, 3, 1,
-	4, 2, 0, 1, 1, 2, 1, 1, 1, 1,
-	1, 4, 2, 0, 1, 1, 2, 1, 1, 1,
-	1, 4, 2, 1, 1, 2, 3, 3, 3, 1,
-	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 3, 3, 3, 3, 2, 4, 1, 1,
+	1, 1, 1, 1, 3, 4, 1, 2, 1, 1,
+	1, 1, 1, 1, 1, 2, 4, 2, 4, 2,
+	1, 2, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 3, 2, 4, 2, 0, 1, 1, 2, 1,
+	3, 1, 1, 1, 1, 1, 1, 1, 1, 2,
+	4, 2, 2, 2, 3, 3, 3, 3, 3, 4,
+	2, 0, 1, 1, 2, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 3, 2, 4, 0, 1, 1,
+	2, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 2, 2, 4,
-	2, 1, 1, 2, 1, 1, 1, 1, 1, 1,
+	0, 1, 1, 2, 1, 1, 1, 1, 1, 1,
+	1, 1, 2, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 2, 4, 1, 1, 2, 4,
+	2, 0, 1, 1, 2, 1, 1, 1, 1, 4,
+	4, 1, 1, 1, 4, 2, 0, 1, 1, 2,
+	1, 1, 1, 1, 4, 4, 1, 4, 2, 0,
+	1, 1, 2, 1, 1, 1, 1, 1, 4, 2,
+	0, 1, 1, 2, 1, 1, 1, 1, 4, 2,
+	1, 1, 2, 3, 3, 3, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 3,
+	3, 3, 3, 2, 4, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 2, 2, 4, 2, 1, 1,
+	2, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 3, 1, 1,
 	1, 3, 1, 1, 1, 1, 3, 4, 2, 2,
 	4, 2, 1, 2, 1, 1, 1, 1, 1, 3,
 	2, 4, 2, 1, 2, 1, 1, 1, 1, 1,
@@ -610,139 +711,141 @@ var yyR2 = [...]int{
 }
 var yyChk = [...]int{
 
-	-1000, -9, -10, 25, 54, -11, -12, 11, -13, -14,
-	-15, -16, -17, -18, -19, -20, -21, -22, -23, -24,
-	-25, -26, -27, 49, 50, 12, 70, 34, -31, -34,
-	15, 62, -137, -54, -64, -39, -40, -41, -42, -43,
-	-44, -45, -46, -47, -48, -49, -50, -51, -52, -53,
-	-30, 13, 40, 41, 28, 64, 75, -107, -151, -155,
-	-120, -163, -167, -161, -128, -87, -101, -143, -147, -124,
-	-96, -82, -7, 30, 31, 27, 26, 32, 35, 43,
-	44, 21, 61, 38, 42, 29, 53, 56, 58, 7,
-	4, 4, 9, -12, -4, 5, 10, 8, -4, -4,
-	-4, 71, 72, 73, -4, 8, 10, 8, -4, 5,
-	8, 10, 8, 8, 5, 4, 4, 4, 4, -4,
-	8, 8, 8, 8, 8, 8, 10, 8, 10, 8,
-	10, 8, 8, 8, 8, 8, 10, 8, 10, 8,
-	4, 4, 4, 4, 4, 4, 4, 4, 4, -4,
-	4, 4, 4, -4, 4, 4, -5, -6, -3, -4,
-	6, 8, 8, -8, 55, 10, 8, -28, -29, -16,
-	-17, -18, -30, 10, 10, -8, 10, 10, 10, 10,
-	-32, -33, -21, 13, -16, -17, -18, -30, -35, -36,
-	13, -16, -17, -18, -30, 10, 10, -138, -139, -140,
-	-16, -17, -18, -86, -141, -142, -30, 59, 23, 24,
-	-55, -56, -57, -58, -16, -17, -18, -59, 65, -65,
-	-66, -16, -18, -67, -68, -70, -71, -30, 76, -108,
-	-109, -81, -74, -16, -17, -18, -76, -30, -110, 68,
-	16, 14, -152, -153, -154, -16, -17, -18, -26, -156,
-	-157, -158, -16, -17, -18, -86, -93, -79, -80, -77,
-	-78, -159, -75, -160, -26, -92, 36, 37, 20, 33,
-	19, 22, 81, 60, -121, -106, -122, -16, -17, -18,
-	-86, -93, -77, -78, -123, -26, 74, -164, -165, -166,
-	-81, -16, -17, -18, -86, -47, -93, -74, -77, -160,
-	-79, -80, -78, -76, -30, -164, -162, -16, -17, -18,
-	-86, -47, -93, -77, -78, -30, -129, -130, -131, -16,
-	-17, -18, -86, -93, -132, -51, -30, -133, 51, -88,
-	-89, -16, -18, -90, -91, -30, 86, 85, -102, -103,
-	-16, -17, -18, -104, -26, -86, -93, -105, 39, -144,
-	-145, -146, -16, -17, -18, -86, -141, -142, -30, -148,
-	-149, -150, -16, -17, -18, -86, -26, -125, -126, -127,
-	-16, -17, -18, -86, -93, -41, -42, -43, -44, -45,
-	-46, -48, -104, -49, -50, -30, -97, -98, -99, -16,
-	-17, -18, -100, -86, -30, 57, -83, -84, -85, -16,
-	-17, -18, -86, -30, -8, -3, 5, -7, 9, -29,
-	-33, 9, 5, -36, 9, 5, 9, -140, -37, -38,
-	-26, -37, -4, 8, 8, 9, -57, 10, 8, 5,
-	9, -66, -69, 8, -69, -69, 77, 79, 80, 78,
-	9, -109, 10, 8, 5, -3, 4, 9, -154, 9,
-	-158, 10, 8, -2, 52, 6, 5, -2, -1, 47,
-	48, -1, -4, -4, 82, 83, -4, 9, -122, -4,
-	9, -166, 9, 9, 9, -131, 10, 8, -4, 9,
-	-89, -4, -4, 9, -103, 8, 4, 9, -146, -37,
-	-37, 9, -150, 9, -127, 9, -99, 4, 9, -85,
-	9, 10, 10, 9, -26, 9, -8, -60, -61, -62,
-	-16, -17, -18, -63, 66, -72, -73, -74, -47, -75,
-	-76, -77, -78, -79, -80, -81, -8, -111, -112, -113,
-	-114, 45, -115, -116, -100, -117, -81, -118, -30, -119,
-	-172, -168, 69, 84, 63, 17, 67, 18, 87, -8,
-	-8, -94, -95, -16, -17, -18, -30, 10, 10, 10,
-	10, 10, -8, 10, -8, -8, -8, -135, -136, -134,
-	-16, -17, -18, -86, -76, -77, -78, -47, -79, -80,
-	-30, -8, -8, -106, 9, 9, -8, 9, -62, -1,
-	9, -73, 9, -113, -4, 10, 8, 10, 8, 10,
-	8, -2, -1, -4, -4, -4, 4, 4, 9, -95,
-	9, -134, 9, 10, -8, -88, -173, -174, -16, -17,
-	-18, -175, -30, 46, -169, -170, -16, -17, -18, -171,
-	-30, 88, 10, -8, 9, 9, -174, -2, 9, -170,
-	-2, -8, -8,
+	-1000, -10, -11, 25, 54, 8, 4, 4, -12, -13,
+	11, -14, -15, -16, -17, -18, -19, -20, -21, -22,
+	-23, -24, -25, -26, -27, -28, 49, 50, 12, 70,
+	34, -32, -36, 15, 62, -140, -57, -67, -41, -42,
+	-43, -44, -45, -46, -47, -48, -49, -50, -51, -52,
+	-53, -54, -55, -31, -56, 13, 40, 41, 28, 64,
+	75, -110, -154, -158, -123, -166, -170, -164, -131, -90,
+	-104, -146, -150, -127, -99, -85, -8, 2, 30, 31,
+	27, 26, 32, 35, 43, 44, 21, 61, 38, 42,
+	29, 53, 56, 58, 7, 9, -13, -4, 5, 10,
+	8, -4, -4, -4, 71, 72, 73, -4, 8, 10,
+	8, -4, 5, 8, 10, 8, 8, 5, 4, 4,
+	4, 4, -4, 8, 8, 8, 8, 8, 8, 10,
+	8, 10, 8, 10, 8, 8, 8, 8, 8, 10,
+	8, 10, 8, 4, 4, 4, 4, 4, 4, 4,
+	4, 4, -4, 4, 4, 4, -4, 4, 4, -6,
+	-7, -3, -4, 6, -9, 55, 10, 8, -29, -30,
+	-17, -18, -19, -31, 10, 10, -9, 10, 10, 10,
+	10, -33, -34, -22, 13, -35, -17, -18, -19, -31,
+	89, -37, -38, 13, -35, -17, -18, -19, -31, 10,
+	10, -141, -142, -143, -17, -18, -19, -89, -144, -145,
+	-31, 59, 23, 24, -58, -59, -60, -61, -17, -18,
+	-19, -62, 65, -68, -69, -17, -19, -70, -71, -73,
+	-74, -31, -56, 76, -111, -112, -84, -77, -17, -18,
+	-19, -79, -31, -113, 68, 16, 14, -155, -156, -157,
+	-17, -18, -19, -27, -159, -160, -161, -17, -18, -19,
+	-89, -96, -82, -83, -80, -81, -162, -78, -163, -27,
+	-95, 36, 37, 20, 33, 19, 22, 81, 60, -124,
+	-109, -125, -17, -18, -19, -89, -96, -80, -81, -126,
+	-27, 74, -167, -168, -169, -84, -17, -18, -19, -89,
+	-49, -96, -77, -80, -163, -82, -83, -81, -79, -31,
+	-56, -167, -165, -17, -18, -19, -89, -49, -96, -80,
+	-81, -31, -132, -133, -134, -17, -18, -19, -89, -96,
+	-135, -53, -31, -136, 51, -91, -92, -17, -19, -93,
+	-94, -31, 86, 85, -105, -106, -17, -18, -19, -107,
+	-27, -89, -96, -108, 39, -147, -148, -149, -17, -18,
+	-19, -89, -144, -145, -31, -151, -152, -153, -17, -18,
+	-19, -89, -27, -128, -129, -130, -17, -18, -19, -89,
+	-96, -43, -44, -45, -46, -47, -48, -50, -107, -51,
+	-52, -31, -100, -101, -102, -17, -18, -19, -103, -89,
+	-31, 57, -86, -87, -88, -17, -18, -19, -89, -31,
+	-9, -3, 5, -8, 9, -30, -34, 9, 5, 5,
+	-38, 9, 5, 9, -143, 8, 8, -4, 9, -60,
+	10, 8, 5, 9, -69, -72, 8, -72, -72, 77,
+	79, 80, 78, 9, -112, 10, 8, 5, -3, 4,
+	9, -157, 9, -161, 10, 8, -2, 52, 6, 5,
+	-2, -1, 47, 48, -1, -4, -4, 82, 83, -4,
+	9, -125, -4, 9, -169, 9, 9, 9, -134, 10,
+	8, -4, 9, -92, -4, -4, 9, -106, 8, 4,
+	9, -149, 8, 8, 9, -153, 9, -130, 9, -102,
+	4, 9, -88, 9, 10, 10, 10, -39, -40, -27,
+	-39, -9, -63, -64, -65, -17, -18, -19, -66, 66,
+	-75, -76, -77, -49, -78, -79, -80, -81, -82, -83,
+	-84, -9, -114, -115, -116, -117, 45, -118, -119, -103,
+	-120, -84, -121, -31, -56, -122, -175, -171, 69, 84,
+	63, 17, 67, 18, 87, -9, -9, -97, -98, -17,
+	-18, -19, -31, 10, 10, 10, 10, 10, -9, 10,
+	-9, -9, -9, -138, -139, -137, -17, -18, -19, -89,
+	-79, -80, -81, -49, -82, -83, -31, -9, -9, -109,
+	-39, -39, -9, 9, -27, 9, 9, -65, -1, 9,
+	-76, 9, -116, -4, 10, 8, 10, 8, 10, 8,
+	-2, -1, -4, -4, -4, -5, 4, -4, -5, 9,
+	-98, 9, -137, 9, 9, 9, 10, -9, -91, -176,
+	-177, -17, -18, -19, -178, -31, 46, -172, -173, -17,
+	-18, -19, -174, -31, 88, 10, -9, 9, 9, -177,
+	-2, 9, -173, -2, -9, -9,
 }
 var yyDef = [...]int{
 
-	0, -2, 0, 0, 0, 0, 4, 0, 7, 8,
-	9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
-	19, 20, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 53, 54, 55, 56, 57,
+	0, -2, 0, 0, 0, 0, 2, 3, 0, 4,
+	0, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+	16, 17, 18, 19, 20, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 56, 57,
 	58, 59, 60, 61, 62, 63, 64, 65, 66, 67,
-	68, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	68, 69, 70, 71, 72, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 439, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 441,
-	0, 0, 1, 5, 0, 400, 22, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 49, 0, 0, 0,
-	294, 71, 74, 0, 21, 30, 41, 293, 73, 95,
-	0, 333, 0, 224, 0, 0, 366, 0, 261, 263,
-	133, 0, 0, 309, 322, 240, 155, 158, 121, 124,
-	183, 332, 342, 223, 380, 408, 377, 378, 260, 135,
-	179, 308, 321, 238, 157, 123, 0, 442, 443, 193,
-	194, 2, 3, 6, 0, 437, 0, 0, 24, 26,
-	27, 28, 29, 433, 434, 431, 218, 219, 220, 432,
-	0, 31, 34, 0, 36, 37, 38, 39, 0, 42,
-	0, 45, 46, 47, 48, 33, 435, 0, 295, 296,
-	298, 299, 300, 301, 51, 51, 304, 0, 0, 0,
-	0, 75, 76, 78, 79, 80, 81, 0, 0, 0,
-	96, 98, 99, 100, 0, 0, 0, 104, 0, 0,
-	184, 186, 187, 188, 189, 190, 191, 192, 0, 0,
-	0, 0, 0, 334, 335, 337, 338, 339, 340, 0,
-	343, 344, 349, 350, 351, 352, 353, 354, 355, 356,
-	357, 358, 359, 360, 361, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 225, 226, 228, 229, 230,
-	231, 232, 233, 234, 235, 236, 0, 0, 381, 382,
-	384, 385, 386, 387, 388, 389, 390, 391, 392, 393,
-	394, 395, 396, 397, 398, 0, 0, 368, 369, 370,
-	371, 372, 373, 374, 375, 376, 0, 264, 265, 267,
-	268, 269, 270, 271, 272, 273, 274, 0, 0, 0,
-	136, 138, 139, 140, 141, 142, 0, 0, 0, 170,
-	172, 173, 174, 175, 176, 177, 178, 0, 0, 0,
-	310, 311, 313, 314, 315, 316, 51, 51, 319, 0,
-	323, 324, 326, 327, 328, 329, 330, 0, 241, 242,
-	244, 245, 246, 247, 248, 249, 250, 251, 252, 253,
-	254, 255, 256, 257, 258, 259, 0, 159, 160, 162,
-	163, 164, 165, 166, 167, 0, 0, 125, 126, 128,
-	129, 130, 131, 132, 440, 444, 401, 0, 23, 25,
-	32, 40, 0, 43, 50, 0, 292, 297, 0, 52,
-	69, 0, 0, 305, 306, 72, 77, 82, 85, 84,
-	94, 97, 101, 0, 102, 103, 0, 106, 107, 108,
-	182, 185, 196, 199, 0, 0, 198, 331, 336, 341,
-	345, 147, 0, 0, 0, 402, 403, 0, 0, 404,
-	405, 0, 0, 0, 0, 0, 146, 222, 227, 0,
-	379, 383, 407, 367, 262, 266, 287, 0, 275, 134,
-	137, 0, 0, 169, 171, 0, 181, 307, 312, 0,
-	0, 320, 325, 239, 243, 156, 161, 0, 122, 127,
-	438, 35, 44, 302, 70, 303, 145, 0, 86, 87,
-	89, 90, 91, 92, 0, 0, 110, 112, 113, 114,
-	115, 116, 117, 118, 119, 120, 105, 0, 200, 201,
-	203, 0, 205, 206, 207, 208, 209, 210, 211, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 436,
-	195, 0, 149, 151, 152, 153, 154, 346, 347, 348,
-	406, 399, 364, 365, 362, 363, 237, 0, 289, 290,
-	276, 277, 278, 279, 280, 281, 282, 283, 284, 285,
-	286, 143, 144, 0, 317, 318, 168, 83, 88, 0,
-	109, 111, 197, 202, 0, 212, 0, 420, 0, 409,
-	0, 0, 0, 214, 215, 216, 422, 411, 148, 150,
-	288, 291, 180, 93, 204, 0, 0, 423, 425, 426,
-	427, 428, 429, 0, 0, 412, 414, 415, 416, 417,
-	418, 0, 221, 217, 213, 421, 424, 0, 410, 413,
-	0, 430, 419,
+	0, 0, 0, 0, 0, 0, 449, 75, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 451, 1, 5, 0, 410, 22,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 52,
+	0, 0, 0, 301, 76, 79, 0, 21, 30, 43,
+	300, 78, 100, 0, -2, 0, -2, 0, 0, 373,
+	0, 268, 270, 139, 0, 0, 316, -2, 247, 161,
+	164, 127, 130, 189, 339, 349, 230, 387, 418, 384,
+	385, 267, 141, 185, 315, 328, 245, 163, 129, 0,
+	452, 453, 199, 200, 6, 0, 447, 0, 0, 24,
+	26, 27, 28, 29, 443, 444, 441, 225, 226, 227,
+	442, 0, 31, 35, 0, 37, 38, 39, 40, 41,
+	0, 0, 44, 0, 47, 48, 49, 50, 51, 33,
+	445, 0, 302, 303, 305, 306, 307, 308, 0, 0,
+	311, 0, 312, 313, 0, 80, 81, 83, 84, 85,
+	86, 0, 0, 0, 101, 103, 104, 105, 0, 0,
+	0, 109, 110, 0, 0, 190, 192, 193, 194, 195,
+	196, 197, 198, 0, 0, 0, 0, 0, -2, 342,
+	344, 345, 346, 347, 0, -2, 351, 356, 357, 358,
+	359, 360, 361, 362, 363, 364, 365, 366, 367, 368,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	-2, 233, 235, 236, 237, 238, 239, 240, 241, 242,
+	243, 0, 0, -2, 389, 391, 392, 393, 394, 395,
+	396, 397, 398, 399, 400, 401, 402, 403, 404, 405,
+	406, 0, 0, 375, 376, 377, 378, 379, 380, 381,
+	382, 383, 0, 271, 272, 274, 275, 276, 277, 278,
+	279, 280, 281, 0, 0, 0, 142, 144, 145, 146,
+	147, 148, 0, 0, 0, 176, 178, 179, 180, 181,
+	182, 183, 184, 0, 0, 0, 317, 318, 320, 321,
+	322, 323, 0, 0, 326, 0, -2, 331, 333, 334,
+	335, 336, 337, 0, 248, 249, 251, 252, 253, 254,
+	255, 256, 257, 258, 259, 260, 261, 262, 263, 264,
+	265, 266, 0, 165, 166, 168, 169, 170, 171, 172,
+	173, 0, 0, 131, 132, 134, 135, 136, 137, 138,
+	450, 454, 411, 0, 23, 25, 32, 42, 0, 0,
+	45, 53, 0, 299, 304, -2, -2, 0, 77, 82,
+	87, 90, 89, 99, 102, 106, 0, 107, 108, 0,
+	112, 113, 114, 188, 191, 202, -2, 0, 0, 204,
+	338, 343, 348, 352, 153, 0, 0, 0, 412, 413,
+	0, 0, 414, 415, 0, 0, 0, 0, 0, 152,
+	229, 234, 0, 386, 390, 417, 374, 269, 273, 294,
+	0, 282, 140, 143, 0, 0, 175, 177, 0, 187,
+	314, 319, -2, -2, 327, 332, 246, 250, 162, 167,
+	0, 128, 133, 448, 36, 34, 46, 0, -2, 73,
+	0, 151, 0, 91, 92, 94, 95, 96, 97, 0,
+	0, 116, 118, 119, 120, 121, 122, 123, 124, 125,
+	126, 111, 0, -2, 207, 209, 0, 211, 212, 213,
+	214, 215, 216, 217, 218, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 446, 201, 0, 155, 157,
+	158, 159, 160, 353, 354, 355, 416, 407, 371, 372,
+	369, 370, 244, 0, 296, 297, 283, 284, 285, 286,
+	287, 288, 289, 290, 291, 292, 293, 149, 150, 0,
+	0, 0, 174, 309, 74, 310, 88, 93, 0, 115,
+	117, 203, 208, 0, 219, 0, 430, 0, 419, 0,
+	0, 0, 221, 222, 223, 432, 408, 409, 421, 154,
+	156, 295, 298, 186, 324, 325, 98, 210, 0, 0,
+	433, 435, 436, 437, 438, 439, 0, 0, 422, 424,
+	425, 426, 427, 428, 0, 228, 224, 220, 431, 434,
+	0, 420, 423, 0, 440, 429,
 }
 var yyTok1 = [...]int{
 
@@ -758,7 +861,7 @@ var yyTok2 = [...]int{
 	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
 	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
 	72, 73, 74, 75, 76, 77, 78, 79, 80, 81,
-	82, 83, 84, 85, 86, 87, 88,
+	82, 83, 84, 85, 86, 87, 88, 89,
 }
 var yyTok3 = [...]int{
 	0,
@@ -1102,19 +1205,19 @@ yydefault:
 	switch yynt {
 
 	case 2:
-		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:175
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:221
 		{
 			l := yylex.(*lexer)
 			if l.parent != nil {
 				l.Error("expected submodule for include")
 				goto ret1
 			}
-			yylex.(*lexer).stack.push(l.builder.Module(yyDollar[2].token, l.featureSet))
+			l.push(l.builder.Module(yyDollar[2].token, l.featureSet))
 		}
 	case 3:
-		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:183
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:229
 		{
 			l := yylex.(*lexer)
 			if l.parent == nil {
@@ -1124,1069 +1227,970 @@ yydefault:
 			}
 			// sub modules really just re-add parent module back onto stack and let all
 			// children be added to that.
-			l.stack.push(l.parent)
+			l.stack.push(l.parent, 0)
 		}
 	case 6:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:200
+//line parser.y:246
 		{
 			l := yylex.(*lexer)
 			l.builder.Namespace(l.stack.peek(), yyDollar[2].token)
-			if chkErr2(l, "namespace", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "namespace", yyDollar[3].ext)
 		}
 	case 21:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:223
+//line parser.y:267
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Revision(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Revision(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
 	case 22:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:232
+//line parser.y:274
 		{
 			yylex.(*lexer).stack.pop()
 		}
 	case 23:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:235
+//line parser.y:277
 		{
 			yylex.(*lexer).stack.pop()
 		}
 	case 30:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:250
+//line parser.y:292
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Import(l.stack.peek(), yyDollar[2].token, l.loader))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Import(l.stack.peek(), yyDollar[2].token, l.loader))
+			chkErr(yylex, l.builder.LastErr)
 		}
 	case 33:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:263
+//line parser.y:303
 		{
 			l := yylex.(*lexer)
 			l.builder.Prefix(l.stack.peek(), yyDollar[2].token)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			chkErr(yylex, l.builder.LastErr)
+		}
+	case 34:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:310
+		{
+			l := yylex.(*lexer)
+			l.builder.Revision(l.stack.peek(), tokenString(yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 40:
+	case 42:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:280
+//line parser.y:326
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 41:
+	case 43:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:285
+//line parser.y:331
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Include(l.stack.peek(), yyDollar[2].token, yylex.(*lexer).loader))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Include(l.stack.peek(), yyDollar[2].token, yylex.(*lexer).loader))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 49:
+	case 52:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:305
+//line parser.y:350
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 50:
+	case 53:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:308
+//line parser.y:353
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 71:
+	case 75:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:388
+		{
+			l := yylex.(*lexer)
+			if !l.recover(openBodies(yyS[:yyp+1])) {
+				goto ret1
+			}
+			// problem is handled and lookahead is from before lexer skipped ahead
+			Errflag = 0
+			yyrcvr.char = -1
+			yytoken = -1
+		}
+	case 76:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:338
+//line parser.y:400
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 72:
+	case 77:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:341
+//line parser.y:403
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 73:
+	case 78:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:346
+//line parser.y:408
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.ExtensionDef(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.ExtensionDef(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 82:
+	case 87:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:368
+//line parser.y:428
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 83:
+	case 88:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:371
+//line parser.y:431
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 84:
+	case 89:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:376
+//line parser.y:436
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.ExtensionDefArg(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.ExtensionDefArg(l.stack.peek(), tokenString(yyDollar[2].token)))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 93:
+	case 98:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:398
+//line parser.y:456
 		{
 			l := yylex.(*lexer)
 			l.builder.YinElement(l.stack.peek(), yyDollar[2].boolean)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 94:
+	case 99:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:407
+//line parser.y:463
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 95:
+	case 100:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:412
+//line parser.y:468
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Deviation(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Deviation(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 105:
+	case 111:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:440
+//line parser.y:495
 		{
 			l := yylex.(*lexer)
 			l.builder.NotSupported(l.stack.peek())
-			if chkErr2(l, "not-supported", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "not-supported", yyDollar[3].ext)
 		}
-	case 106:
+	case 112:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:449
+//line parser.y:502
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.ReplaceDeviate(l.stack.peek()))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.ReplaceDeviate(l.stack.peek()))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 107:
+	case 113:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:458
+//line parser.y:509
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.DeleteDeviate(l.stack.peek()))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.DeleteDeviate(l.stack.peek()))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 108:
+	case 114:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:467
+//line parser.y:516
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.AddDeviate(l.stack.peek()))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.AddDeviate(l.stack.peek()))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 109:
+	case 115:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:476
+//line parser.y:523
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 121:
+	case 127:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:499
+//line parser.y:546
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 122:
+	case 128:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:502
+//line parser.y:549
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 123:
+	case 129:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:508
+//line parser.y:555
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Feature(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Feature(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 133:
+	case 139:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:531
+//line parser.y:576
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 134:
+	case 140:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:534
+//line parser.y:579
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 135:
+	case 141:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:539
+//line parser.y:584
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Must(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Must(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 143:
+	case 149:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:558
+//line parser.y:601
 		{
 			l := yylex.(*lexer)
 			l.builder.ErrorMessage(l.stack.peek(), yyDollar[2].token)
-			if chkErr2(l, "error-message", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "error-message", yyDollar[3].ext)
 		}
-	case 144:
+	case 150:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:567
+//line parser.y:608
 		{
 			l := yylex.(*lexer)
 			l.builder.ErrorAppTag(l.stack.peek(), yyDollar[2].token)
-			if chkErr2(l, "error-app-tag", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "error-app-tag", yyDollar[3].ext)
 		}
-	case 145:
+	case 151:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:577
+//line parser.y:616
 		{
 			l := yylex.(*lexer)
-			l.builder.IfFeature(l.stack.peek(), yyDollar[2].token)
-			if chkErr2(l, "if-feature", yyDollar[3].ext) {
-				goto ret1
-			}
+			i := l.builder.IfFeature(l.stack.peek(), yyDollar[2].token)
+			l.builder.Location(i, l.location(yyDollar[1].pos))
+			chkErr2(l, "if-feature", yyDollar[3].ext)
 		}
-	case 146:
+	case 152:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:586
+//line parser.y:624
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.When(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.When(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 147:
+	case 153:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:595
+//line parser.y:631
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 148:
+	case 154:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:598
+//line parser.y:634
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 155:
+	case 161:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:612
+//line parser.y:648
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 156:
+	case 162:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:615
+//line parser.y:651
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 157:
+	case 163:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:620
+//line parser.y:656
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Identity(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Identity(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 168:
+	case 174:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:644
+//line parser.y:678
 		{
 			l := yylex.(*lexer)
 			l.builder.Base(l.stack.peek(), yyDollar[2].token)
-			if chkErr2(l, "base", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "base", yyDollar[3].ext)
 		}
-	case 169:
+	case 175:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:653
+//line parser.y:685
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 179:
+	case 185:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:671
+//line parser.y:703
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Choice(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Choice(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 180:
+	case 186:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:680
+//line parser.y:710
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 181:
+	case 187:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:685
+//line parser.y:715
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Case(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Case(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 182:
+	case 188:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:694
+//line parser.y:722
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 183:
+	case 189:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:699
+//line parser.y:727
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Typedef(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Typedef(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 193:
+	case 199:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line parser.y:720
+//line parser.y:746
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 194:
+	case 200:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line parser.y:721
+//line parser.y:747
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 195:
+	case 201:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:724
+//line parser.y:750
 		{
 			l := yylex.(*lexer)
 			l.builder.Default(l.stack.peek(), yyDollar[2].token)
-			if chkErr2(l, "default", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "default", yyDollar[3].ext)
 		}
-	case 196:
+	case 202:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:733
+//line parser.y:757
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 197:
+	case 203:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:736
+//line parser.y:760
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 198:
+	case 204:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:741
+//line parser.y:765
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Type(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Type(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 204:
+	case 210:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:758
+//line parser.y:780
 		{
 			l := yylex.(*lexer)
 			l.builder.Path(l.stack.peek(), yyDollar[2].token)
-			if chkErr2(l, "path", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "path", yyDollar[3].ext)
 		}
-	case 212:
+	case 219:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:774
+//line parser.y:795
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 213:
+	case 220:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:777
+//line parser.y:798
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 214:
+	case 221:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:782
+//line parser.y:803
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.ValueRange(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.ValueRange(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 215:
+	case 222:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:789
+//line parser.y:808
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.LengthRange(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.LengthRange(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 216:
+	case 223:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:796
+//line parser.y:813
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Pattern(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Pattern(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 217:
+	case 224:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:805
+//line parser.y:820
 		{
 			l := yylex.(*lexer)
 			l.builder.RequireInstance(l.stack.peek(), yyDollar[2].boolean)
-			if chkErr2(l, "require-instance", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "require-instance", yyDollar[3].ext)
 		}
-	case 221:
+	case 228:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:819
+//line parser.y:832
 		{
 			l := yylex.(*lexer)
 			l.builder.FractionDigits(l.stack.peek(), yyDollar[2].num32)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 222:
+	case 229:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:828
+//line parser.y:839
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 223:
+	case 230:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:833
+//line parser.y:844
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Container(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Container(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 237:
+	case 244:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:861
+//line parser.y:870
 		{
 			l := yylex.(*lexer)
 			l.builder.Presence(l.stack.peek(), yyDollar[2].token)
-			if chkErr2(l, "presence", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "presence", yyDollar[3].ext)
 		}
-	case 238:
+	case 245:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:870
+//line parser.y:877
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Augment(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Augment(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 239:
+	case 246:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:879
+//line parser.y:884
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 260:
+	case 267:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:909
+//line parser.y:914
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Uses(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Uses(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 261:
+	case 268:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:918
+//line parser.y:921
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 262:
+	case 269:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:921
+//line parser.y:924
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 275:
+	case 282:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:943
+//line parser.y:946
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Refine(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Refine(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 287:
+	case 294:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:966
+//line parser.y:967
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 288:
+	case 295:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:969
+//line parser.y:970
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 292:
+	case 299:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:981
+//line parser.y:982
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 293:
+	case 300:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:986
+//line parser.y:987
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Action(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Action(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 302:
-		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1006
+	case 309:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1005
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 303:
-		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1009
+	case 310:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1008
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 305:
-		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1015
+	case 312:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1014
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.ActionInput(l.stack.peek()))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.ActionInput(l.stack.peek()))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 306:
-		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1024
+	case 313:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1021
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.ActionOutput(l.stack.peek()))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.ActionOutput(l.stack.peek()))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 307:
+	case 314:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:1036
+//line parser.y:1031
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 308:
+	case 315:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1041
+//line parser.y:1036
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Action(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Action(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 317:
-		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1061
+	case 324:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1054
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 318:
-		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1064
+	case 325:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1057
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 320:
+	case 327:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:1073
+//line parser.y:1066
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 321:
+	case 328:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1078
+//line parser.y:1071
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Notification(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Notification(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 331:
+	case 338:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:1102
+//line parser.y:1093
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 332:
+	case 339:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1107
+//line parser.y:1098
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Grouping(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Grouping(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 341:
+	case 348:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:1129
+//line parser.y:1118
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 342:
+	case 349:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1134
+//line parser.y:1123
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.List(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.List(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 346:
+	case 353:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1151
+//line parser.y:1138
 		{
 			l := yylex.(*lexer)
 			l.builder.MaxElements(l.stack.peek(), yyDollar[2].num32)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 347:
+	case 354:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1158
+//line parser.y:1143
 		{
 			l := yylex.(*lexer)
 			l.builder.UnBounded(l.stack.peek(), true)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 348:
+	case 355:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1167
+//line parser.y:1150
 		{
 			l := yylex.(*lexer)
 			l.builder.MinElements(l.stack.peek(), yyDollar[2].num32)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 362:
+	case 369:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1192
+//line parser.y:1173
 		{
 			l := yylex.(*lexer)
 			l.builder.OrderedBy(l.stack.peek(), meta.OrderedBySystem)
-			if chkErr2(l, "ordered-by", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "ordered-by", yyDollar[3].ext)
 		}
-	case 363:
+	case 370:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1199
+//line parser.y:1178
 		{
 			l := yylex.(*lexer)
 			l.builder.OrderedBy(l.stack.peek(), meta.OrderedByUser)
-			if chkErr2(l, "ordered-by", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "ordered-by", yyDollar[3].ext)
 		}
-	case 364:
+	case 371:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1208
+//line parser.y:1185
 		{
 			l := yylex.(*lexer)
 			l.builder.Key(l.stack.peek(), yyDollar[2].token)
-			if chkErr2(l, "key", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "key", yyDollar[3].ext)
+		}
+	case 372:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:1192
+		{
+			l := yylex.(*lexer)
+			l.builder.Unique(l.stack.peek(), yyDollar[2].token)
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 366:
+	case 373:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1220
+//line parser.y:1199
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 367:
+	case 374:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:1223
+//line parser.y:1202
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 377:
+	case 384:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1240
+//line parser.y:1219
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Any(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Any(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 378:
+	case 385:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1247
+//line parser.y:1224
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Any(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Any(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 379:
+	case 386:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:1256
+//line parser.y:1231
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 380:
+	case 387:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1261
+//line parser.y:1236
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Leaf(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Leaf(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 399:
+	case 407:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1297
+//line parser.y:1271
 		{
 			l := yylex.(*lexer)
 			l.builder.Mandatory(l.stack.peek(), yyDollar[2].boolean)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			chkErr(yylex, l.builder.LastErr)
+		}
+	case 408:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1278
+		{
+			yyVAL.token = yyDollar[1].token
 		}
-	case 400:
+	case 410:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line parser.y:1306
+//line parser.y:1282
 		{
 			yyVAL.token = tokenString(yyDollar[1].token)
 		}
-	case 401:
+	case 411:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1309
+//line parser.y:1285
 		{
 			yyVAL.token = yyDollar[1].token + tokenString(yyDollar[3].token)
 		}
-	case 402:
+	case 412:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line parser.y:1314
+//line parser.y:1290
 		{
 			n, err := strconv.ParseInt(yyDollar[1].token, 10, 32)
 			if err != nil || n < 0 {
 				yylex.Error(fmt.Sprintf("not a valid number for min elements %s", yyDollar[1].token))
-				goto ret1
 			}
 			yyVAL.num32 = int(n)
 		}
-	case 403:
+	case 413:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line parser.y:1322
+//line parser.y:1297
 		{
 			s := trimQuotes(yyDollar[1].token)
 			n, err := strconv.ParseInt(s, 10, 32)
 			if err != nil || n < 0 {
 				yylex.Error(fmt.Sprintf("not a valid number for min elements %s", yyDollar[1].token))
-				goto ret1
 			}
 			yyVAL.num32 = int(n)
 		}
-	case 404:
+	case 414:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line parser.y:1333
+//line parser.y:1307
 		{
 			yyVAL.boolean = true
 		}
-	case 405:
+	case 415:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line parser.y:1334
+//line parser.y:1308
 		{
 			yyVAL.boolean = false
 		}
-	case 406:
+	case 416:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1337
+//line parser.y:1311
 		{
 			l := yylex.(*lexer)
 			l.builder.Config(l.stack.peek(), yyDollar[2].boolean)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 407:
+	case 417:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:1349
+//line parser.y:1321
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 408:
+	case 418:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1354
+//line parser.y:1326
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.LeafList(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.LeafList(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 409:
+	case 419:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1363
+//line parser.y:1333
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 410:
+	case 420:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:1366
+//line parser.y:1336
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 411:
+	case 421:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1371
+//line parser.y:1341
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Bit(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Bit(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 419:
+	case 429:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1390
+//line parser.y:1358
 		{
 			l := yylex.(*lexer)
 			l.builder.Position(l.stack.peek(), yyDollar[2].num32)
-			if chkErr2(l, "position", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "position", yyDollar[3].ext)
 		}
-	case 420:
+	case 430:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1399
+//line parser.y:1365
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 421:
+	case 431:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line parser.y:1402
+//line parser.y:1368
 		{
 			yylex.(*lexer).stack.pop()
 		}
-	case 422:
+	case 432:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1407
+//line parser.y:1373
 		{
 			l := yylex.(*lexer)
-			l.stack.push(l.builder.Enum(l.stack.peek(), yyDollar[2].token))
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.push(l.builder.Enum(l.stack.peek(), yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 430:
+	case 440:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1426
+//line parser.y:1390
 		{
 			l := yylex.(*lexer)
 			l.builder.EnumValue(l.stack.peek(), yyDollar[2].num32)
-			if chkErr2(l, "value", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "value", yyDollar[3].ext)
 		}
-	case 431:
+	case 441:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1435
+//line parser.y:1397
 		{
 			l := yylex.(*lexer)
 			l.builder.Description(l.stack.peek(), yyDollar[2].token)
-			if chkErr2(l, "description", yyDollar[3].ext) {
-				goto ret1
-			}
+			chkErr2(l, "description", yyDollar[3].ext)
 		}
-	case 432:
+	case 442:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1444
+//line parser.y:1404
 		{
 			l := yylex.(*lexer)
 			l.builder.Reference(l.stack.peek(), yyDollar[2].token)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 433:
+	case 443:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1453
+//line parser.y:1411
 		{
 			l := yylex.(*lexer)
 			l.builder.Contact(l.stack.peek(), yyDollar[2].token)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 434:
+	case 444:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1462
+//line parser.y:1418
 		{
 			l := yylex.(*lexer)
 			l.builder.Organization(l.stack.peek(), yyDollar[2].token)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 435:
+	case 445:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1471
+//line parser.y:1425
 		{
 			l := yylex.(*lexer)
-			l.builder.YangVersion(l.stack.peek(), yyDollar[2].token)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.builder.YangVersion(l.stack.peek(), tokenString(yyDollar[2].token))
+			chkErr(yylex, l.builder.LastErr)
 		}
-	case 436:
+	case 446:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1480
+//line parser.y:1432
 		{
 			l := yylex.(*lexer)
-			l.builder.Units(l.stack.peek(), yyDollar[2].token)
-			if chkErr2(l, "units", yyDollar[3].ext) {
-				goto ret1
-			}
+			l.builder.Units(l.stack.peek(), tokenString(yyDollar[2].token))
+			chkErr2(l, "units", yyDollar[3].ext)
 		}
-	case 437:
+	case 447:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line parser.y:1489
+//line parser.y:1439
 		{
 			yyVAL.ext = nil
 		}
-	case 438:
+	case 448:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1492
+//line parser.y:1442
 		{
 			yyVAL.ext = yyDollar[2].ext
 		}
-	case 439:
+	case 449:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line parser.y:1503
+//line parser.y:1453
 		{
 			l := yylex.(*lexer)
 			l.builder.AddExtension(l.stack.peek(), "", yyDollar[1].ext)
 		}
-	case 440:
+	case 450:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:1509
+//line parser.y:1459
 		{
 			l := yylex.(*lexer)
 			yyVAL.ext = l.builder.Extension(yyDollar[1].token, yyDollar[2].args)
-			if chkErr(yylex, l.builder.LastErr) {
-				goto ret1
-			}
+			l.builder.Location(yyVAL.ext, l.location(yyDollar[1].pos))
+			chkErr(yylex, l.builder.LastErr)
 			// ironcically keyword extensions have have primary extensions
 			if yyDollar[3].ext != nil {
 				l.builder.AddExtension(yyVAL.ext, "", yyDollar[3].ext)
 			}
 		}
-	case 441:
+	case 451:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line parser.y:1522
+//line parser.y:1471
 		{
 			yyVAL.args = []string{}
 		}
-	case 443:
+	case 453:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line parser.y:1528
+//line parser.y:1477
 		{
 			yyVAL.args = []string{yyDollar[1].token}
 		}
-	case 444:
+	case 454:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:1531
+//line parser.y:1480
 		{
 			yyVAL.args = append(yyDollar[1].args, yyDollar[2].token)
 		}
@@ -8,44 +8,86 @@ import (
     "github.com/freeconf/yang/meta"
 )
 
+// tokenString is the value of a quoted or unquoted string without the
+// whitespace around it
 func tokenString(s string) string {
-    return strings.Trim(s, " \t\n\r\"'")
+    s = strings.Trim(s, " \t\n\r")
+    if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+        return unescape(strings.Trim(s[1:len(s)-1], " \t\n\r"))
+    }
+    if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+        return strings.Trim(s[1:len(s)-1], " \t\n\r")
+    }
+    return s
 }
 
 // Lex implements goyacc interface
 func (l *lexer) Lex(lval *yySymType) int {
     t, _ := l.nextToken()
-    if t.typ == parseEof {
+    l.tokPos = t.pos
+    l.tokTyp = t.typ
+    l.tokDepth = l.depth
+    switch t.typ {
+    case parseEof:
         return 0
+    case parseErr:
+        l.errorAt(t.pos, t.val)
+    case token_curly_open:
+        l.depth++
+    case token_curly_close:
+        l.depth--
     }
     lval.token = t.val
+    lval.pos = t.pos
+    if t.typ == token_extension || t.typ > token_semi {
+        l.stmtPos = t.pos
+        l.stmtLevel = l.tokDepth
+    }
     return int(t.typ)
 }
 
 // Error implements goyacc interface
 func (l *lexer) Error(e string) {
-    line, col := l.Position()
-    l.lastError = fmt.Errorf("%s - line %d, col %d", e, line, col)
+    if l.tokTyp == parseErr {
+        // lexer already reported problem
+        return
+    }
+    if l.tokPos == l.resumePos && (l.tokTyp == token_curly_close || l.tokTyp == parseEof) {
+        // problem right after a skipped statement is only because it was
+        // skipped. e.g. leaf with no body left
+        return
+    }
+    l.errorAt(l.tokPos, e)
 }
 
-func chkErr(l yyLexer, e error) bool {
+// chkErr records problem building definitions and parsing continues
+func chkErr(yylex yyLexer, e error) {
     if e == nil {
-        return false
+        return
     }
-    l.Error(e.Error())
-    return true
+    l := yylex.(*lexer)
+    l.errorAt(l.tokPos, e.Error())
+    l.builder.LastErr = nil
 }
 
-func chkErr2(l *lexer, keyword string, extension *meta.Extension) bool {
+func chkErr2(l *lexer, keyword string, extension *meta.Extension) {
     if extension != nil {
         l.builder.AddExtension(l.stack.peek(), keyword, extension)
     }
-    if l.builder.LastErr != nil {
-        l.Error(l.builder.LastErr.Error())
-        return true
-    }
+    chkErr(l, l.builder.LastErr)
+}
 
-    return false
+// openBodies is the nesting level of statements the parser is in by counting
+// the '{' on the parser's stack
+func openBodies(stack []yySymType) int {
+    curlyOpen := yyTok2[token_curly_open-yyPrivate]
+    n := 0
+    for _, s := range stack {
+        if yyChk[s.yys] == curlyOpen {
+            n++
+        }
+    }
+    return n
 }
 
 func trimQuotes(s string) string {
@@ -64,6 +106,7 @@ func trimQuotes(s string) string {
     num32    int
     args     []string
     ext      *meta.Extension
+    pos      int
 }
 
 %token <token> token_ident
@@ -153,11 +196,13 @@ func trimQuotes(s string) string {
 %token kywd_error_message
 %token kywd_bit
 %token kywd_position
+%token kywd_revision_date
 
 %type <boolean> bool_value
 %type <num32> int_value
 %type <token> string_or_number
 %type <token> string_value
+%type <token> ident_or_string
 %type <args> optional_extension_args
 %type <args> extension_args
 %type <ext> keyword_extension_stmt
@@ -167,20 +212,21 @@ func trimQuotes(s string) string {
 
 module :
     module_def
+    token_curly_open
     module_stmts
     token_curly_close
     /* don't pop, leave on stack */
 
 module_def :
-    kywd_module token_ident token_curly_open {
+    kywd_module token_ident {
         l := yylex.(*lexer)
         if l.parent != nil {
             l.Error("expected submodule for include")
             goto ret1
         }        
-        yylex.(*lexer).stack.push(l.builder.Module($2, l.featureSet))
+        l.push(l.builder.Module($2, l.featureSet))
     }
-    | kywd_submodule token_ident token_curly_open {
+    | kywd_submodule token_ident {
         l := yylex.(*lexer)
         if l.parent == nil {
             // may want to allow this is parsing submodules on their own has value
@@ -189,7 +235,7 @@ module_def :
         } 
         // sub modules really just re-add parent module back onto stack and let all 
         // children be added to that.
-        l.stack.push(l.parent)
+        l.stack.push(l.parent, 0)
     }
 
 module_stmts :
@@ -200,9 +246,7 @@ module_stmt :
     kywd_namespace string_value statement_end {
         l := yylex.(*lexer)
         l.builder.Namespace(l.stack.peek(), $2)
-        if chkErr2(l, "namespace", $3) {
-            goto ret1
-        }
+        chkErr2(l, "namespace", $3)
     }
     | revision_stmt
     | contact_stmt
@@ -222,10 +266,8 @@ module_stmt :
 revision_def :
     kywd_revision token_string {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Revision(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Revision(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 revision_stmt :
@@ -249,10 +291,8 @@ revision_body_stmt :
 import_def : 
     kywd_import token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Import(l.stack.peek(), $2, l.loader))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Import(l.stack.peek(), $2, l.loader))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 import_body_stmts :
@@ -263,14 +303,20 @@ prefix_stmt:
     kywd_prefix string_value token_semi {
         l := yylex.(*lexer)
         l.builder.Prefix(l.stack.peek(), $2)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        chkErr(yylex, l.builder.LastErr)
      }
 
+revision_date_stmt :
+    kywd_revision_date token_string token_semi {
+        l := yylex.(*lexer)
+        l.builder.Revision(l.stack.peek(), tokenString($2))
+        chkErr(yylex, l.builder.LastErr)
+    }
+
 import_body_stmt :
      prefix_stmt
      | kywd_revision token_string token_semi
+     | revision_date_stmt
      | description
      | status_stmt
      | reference_stmt
@@ -284,10 +330,8 @@ import_stmt :
 include_def : 
     kywd_include token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Include(l.stack.peek(), $2, yylex.(*lexer).loader))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Include(l.stack.peek(), $2, yylex.(*lexer).loader))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 include_body_stmts :
@@ -296,6 +340,7 @@ include_body_stmts :
 
 include_body_stmt :
      kywd_revision token_string token_semi
+     | revision_date_stmt
      | description
      | status_stmt
      | reference_stmt
@@ -330,10 +375,27 @@ body_stmt :
     | identity_stmt
     | feature_stmt
     | extension_stmt
+    | recover_stmt
 
 body_stmts :
     body_stmt | body_stmts body_stmt
 
+/*
+ skips the rest of the statement with a problem so the rest of the file can
+ be parsed to find more problems
+*/
+recover_stmt :
+    error {
+        l := yylex.(*lexer)
+        if !l.recover(openBodies(yyS[:yyp+1])) {
+            goto ret1
+        }
+        // problem is handled and lookahead is from before lexer skipped ahead
+        Errflag = 0
+        yyrcvr.char = -1
+        yytoken = -1
+    }
+
 extension_def_stmt :
     extension_def token_semi {
         yylex.(*lexer).stack.pop()
@@ -345,10 +407,8 @@ extension_def_stmt :
 extension_def : 
     kywd_extension token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.ExtensionDef(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.ExtensionDef(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 optional_extension_def_body_stmts :
@@ -375,10 +435,8 @@ extension_def_argument_stmt :
 argument_def :
     kywd_argument token_string {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.ExtensionDefArg(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.ExtensionDefArg(l.stack.peek(), tokenString($2)))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 optional_argument_def_body_stmts :
@@ -398,9 +456,7 @@ yin_element_stmt :
     kywd_yin_element bool_value token_semi {
         l := yylex.(*lexer)
         l.builder.YinElement(l.stack.peek(), $2)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        chkErr(yylex, l.builder.LastErr)
     }
 
 deviation_stmt :
@@ -411,10 +467,8 @@ deviation_stmt :
 deviation_def :
     kywd_deviation string_value {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Deviation(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }        
+        l.push(l.builder.Deviation(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)        
     }
 
 deviation_body_stmts :
@@ -435,41 +489,34 @@ deviation_body_stmt :
     | deviate_delete_def deviate_stmt
     | deviate_add_def deviate_stmt
     | extension_stmt
+    | recover_stmt
 
 deviate_not_supported:
     kywd_deviate kywd_not_supported statement_end {
         l := yylex.(*lexer)
         l.builder.NotSupported(l.stack.peek())
-        if chkErr2(l, "not-supported", $3) {
-            goto ret1
-        }
+        chkErr2(l, "not-supported", $3)
     }
 
 deviate_replace_def :
     kywd_deviate kywd_replace {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.ReplaceDeviate(l.stack.peek()))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }        
+        l.push(l.builder.ReplaceDeviate(l.stack.peek()))
+        chkErr(yylex, l.builder.LastErr)        
     }
 
 deviate_delete_def :
     kywd_deviate kywd_delete {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.DeleteDeviate(l.stack.peek()))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }        
+        l.push(l.builder.DeleteDeviate(l.stack.peek()))
+        chkErr(yylex, l.builder.LastErr)        
     }
 
 deviate_add_def :
     kywd_deviate kywd_add {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.AddDeviate(l.stack.peek()))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.AddDeviate(l.stack.peek()))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 deviate_stmt :
@@ -507,10 +554,8 @@ feature_stmt :
 feature_def :
     kywd_feature token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Feature(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Feature(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 optional_feature_body_stmts :
@@ -538,10 +583,8 @@ must_stmt :
 must_def :
     kywd_must string_value {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Must(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }        
+        l.push(l.builder.Must(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)        
     }
 
 error_body_stmts :
@@ -558,37 +601,30 @@ error_message_stmt :
     kywd_error_message string_value statement_end {
         l := yylex.(*lexer)
         l.builder.ErrorMessage(l.stack.peek(), $2)
-        if chkErr2(l, "error-message", $3) {
-            goto ret1
-        }        
+        chkErr2(l, "error-message", $3)        
     }
 
 error_app_tag_stmt :
     kywd_error_app_tag string_value statement_end {
         l := yylex.(*lexer)
         l.builder.ErrorAppTag(l.stack.peek(), $2)
-        if chkErr2(l, "error-app-tag", $3) {
-            goto ret1
-        }        
+        chkErr2(l, "error-app-tag", $3)        
     }
 
 
 if_feature_stmt :
     kywd_if_feature string_value statement_end {
         l := yylex.(*lexer)
-        l.builder.IfFeature(l.stack.peek(), $2)
-        if chkErr2(l, "if-feature", $3) {
-            goto ret1
-        }
+        i := l.builder.IfFeature(l.stack.peek(), $2)
+        l.builder.Location(i, l.location($<pos>1))
+        chkErr2(l, "if-feature", $3)
     }
 
 when_def : 
     kywd_when string_value {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.When(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.When(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 when_stmt :
@@ -619,10 +655,8 @@ identity_stmt :
 identity_def :
     kywd_identity token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Identity(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Identity(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 optional_identity_body_stmts :
@@ -644,9 +678,7 @@ base_stmt :
     kywd_base token_ident statement_end {
         l := yylex.(*lexer)        
         l.builder.Base(l.stack.peek(), $2)
-        if chkErr2(l, "base", $3) {
-            goto ret1
-        }
+        chkErr2(l, "base", $3)
     }
 
 choice_stmt :
@@ -670,10 +702,8 @@ choice_body_stmt :
 choice_def :
     kywd_choice token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Choice(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Choice(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 case_stmt :
@@ -684,10 +714,8 @@ case_stmt :
 case_def :
     kywd_case token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Case(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Case(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 typedef_stmt :
@@ -698,10 +726,8 @@ typedef_stmt :
 typedef_def :
     kywd_typedef token_ident {
         l := yylex.(*lexer)        
-        l.stack.push(l.builder.Typedef(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Typedef(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 typedef_stmt_body :
@@ -724,9 +750,7 @@ default_stmt :
     kywd_default string_or_number statement_end {
         l := yylex.(*lexer)        
         l.builder.Default(l.stack.peek(), $2)
-        if chkErr2(l, "default", $3) {
-            goto ret1
-        }
+        chkErr2(l, "default", $3)
     }
 
 type_stmt :
@@ -740,10 +764,8 @@ type_stmt :
 type_stmt_def :
     kywd_type token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Type(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Type(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 optional_type_body_stmts :
@@ -758,9 +780,7 @@ type_body_stmt :
     | kywd_path string_value statement_end {    
         l := yylex.(*lexer)
         l.builder.Path(l.stack.peek(), $2)
-        if chkErr2(l, "path", $3) {
-            goto ret1
-        }
+        chkErr2(l, "path", $3)
     }    
     | enum_stmt
     | bit_stmt
@@ -769,6 +789,7 @@ type_body_stmt :
     | type_stmt
     | require_instance_stmt
     | extension_stmt
+    | recover_stmt
 
 type_detail_stmt :
     type_detail_def token_semi {
@@ -781,33 +802,25 @@ type_detail_stmt :
 type_detail_def :     
     kywd_range string_value {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.ValueRange(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }        
+        l.push(l.builder.ValueRange(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)        
     }
     | kywd_length string_value {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.LengthRange(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }        
+        l.push(l.builder.LengthRange(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)        
     }
     | kywd_pattern string_value {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Pattern(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }        
+        l.push(l.builder.Pattern(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)        
     }
 
 require_instance_stmt :
     kywd_require_instance bool_value statement_end {
         l := yylex.(*lexer)
         l.builder.RequireInstance(l.stack.peek(), $2)
-        if chkErr2(l, "require-instance", $3) {
-            goto ret1
-        }        
+        chkErr2(l, "require-instance", $3)        
     }
 
 status_stmt : 
@@ -819,9 +832,7 @@ fraction_digits_stmt :
     kywd_fraction_digits int_value token_semi {
         l := yylex.(*lexer)
         l.builder.FractionDigits(l.stack.peek(), $2)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        chkErr(yylex, l.builder.LastErr)
     }
 
 container_stmt :
@@ -832,10 +843,8 @@ container_stmt :
 container_def :
     kywd_container token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Container(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Container(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 optional_container_body_stmts :
@@ -861,18 +870,14 @@ presence_stmt :
     kywd_presence string_value statement_end {
         l := yylex.(*lexer)
         l.builder.Presence(l.stack.peek(), $2)     
-        if chkErr2(l, "presence", $3) {
-            goto ret1
-        }
+        chkErr2(l, "presence", $3)
     }
 
 augment_def :
     kywd_augment string_value {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Augment(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Augment(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 augment_stmt :
@@ -908,10 +913,8 @@ augment_body_stmt :
 uses_def :
     kywd_uses token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Uses(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Uses(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 uses_stmt :
@@ -942,10 +945,8 @@ uses_body_stmt :
 refine_def : 
     kywd_refine string_value {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Refine(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Refine(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 refine_body_stmt :
@@ -985,10 +986,8 @@ rpc_stmt :
 rpc_def :
     kywd_rpc token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Action(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Action(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 optional_rpc_body_stmts :
@@ -1003,30 +1002,26 @@ rpc_body_stmt:
     | status_stmt
     | reference_stmt
     | if_feature_stmt
-    | rpc_input optional_body_stmts token_curly_close {
+    | rpc_input token_curly_open optional_body_stmts token_curly_close {
         yylex.(*lexer).stack.pop()
     }
-    | rpc_output optional_body_stmts token_curly_close {
+    | rpc_output token_curly_open optional_body_stmts token_curly_close {
         yylex.(*lexer).stack.pop()
     }
     | extension_stmt
 
 rpc_input :
-    kywd_input token_curly_open {
+    kywd_input {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.ActionInput(l.stack.peek()))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.ActionInput(l.stack.peek()))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 rpc_output :
-    kywd_output token_curly_open {
+    kywd_output {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.ActionOutput(l.stack.peek()))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.ActionOutput(l.stack.peek()))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 action_stmt :
@@ -1040,10 +1035,8 @@ action_stmt :
 action_def :
     kywd_action token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Action(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Action(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 optional_action_body_stmts :
@@ -1058,10 +1051,10 @@ action_body_stmt:
     | status_stmt
     | reference_stmt
     | if_feature_stmt
-    | rpc_input optional_body_stmts token_curly_close {
+    | rpc_input token_curly_open optional_body_stmts token_curly_close {
         yylex.(*lexer).stack.pop()
     }
-    | rpc_output optional_body_stmts token_curly_close {
+    | rpc_output token_curly_open optional_body_stmts token_curly_close {
         yylex.(*lexer).stack.pop()
     }
     | extension_stmt
@@ -1077,10 +1070,8 @@ notification_stmt :
 notification_def :
     kywd_notification token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Notification(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Notification(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 optional_notification_body_stmts :
@@ -1106,10 +1097,8 @@ grouping_stmt :
 grouping_def :
     kywd_grouping token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Grouping(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Grouping(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 optional_grouping_body_stmts : 
@@ -1133,10 +1122,8 @@ list_stmt :
 list_def :
     kywd_list token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.List(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.List(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 optional_list_body_stmts :
@@ -1151,25 +1138,19 @@ max_elements :
     kywd_max_elements int_value token_semi {
         l := yylex.(*lexer)        
         l.builder.MaxElements(l.stack.peek(), $2)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        chkErr(yylex, l.builder.LastErr)
     }
     | kywd_max_elements kywd_unbounded token_semi {
         l := yylex.(*lexer)
         l.builder.UnBounded(l.stack.peek(), true)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        chkErr(yylex, l.builder.LastErr)
     }
 
 min_elements : 
     kywd_min_elements int_value token_semi {
         l := yylex.(*lexer)
         l.builder.MinElements(l.stack.peek(), $2)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        chkErr(yylex, l.builder.LastErr)
     }
 
 list_body_stmt :
@@ -1192,29 +1173,27 @@ ordered_by_stmt :
     kywd_ordered_by kywd_system statement_end {
         l := yylex.(*lexer)
         l.builder.OrderedBy(l.stack.peek(), meta.OrderedBySystem)
-        if chkErr2(l, "ordered-by", $3) {
-            goto ret1
-        }
+        chkErr2(l, "ordered-by", $3)
     }
     | kywd_ordered_by kywd_user statement_end {
         l := yylex.(*lexer)
         l.builder.OrderedBy(l.stack.peek(), meta.OrderedByUser)
-        if chkErr2(l, "ordered-by", $3) {
-            goto ret1
-        }
+        chkErr2(l, "ordered-by", $3)
     }
 
 key_stmt: 
     kywd_key string_value statement_end {
         l := yylex.(*lexer)
         l.builder.Key(l.stack.peek(), $2)
-        if chkErr2(l, "key", $3) {
-            goto ret1
-        }
+        chkErr2(l, "key", $3)
     }
 
 unique_stmt:    
-    kywd_unique string_value token_semi
+    kywd_unique string_value token_semi {
+        l := yylex.(*lexer)
+        l.builder.Unique(l.stack.peek(), $2)
+        chkErr(yylex, l.builder.LastErr)
+    }
 
 anyxml_stmt:
     anyxml_def token_semi {
@@ -1239,17 +1218,13 @@ anyxml_body :
 anyxml_def :
     kywd_anyxml token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Any(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Any(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
     | kywd_anydata token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Any(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Any(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 leaf_stmt:
@@ -1260,10 +1235,8 @@ leaf_stmt:
 leaf_def :
     kywd_leaf token_ident {
         l := yylex.(*lexer)        
-        l.stack.push(l.builder.Leaf(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Leaf(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 optional_leaf_body_stmts:
@@ -1292,16 +1265,19 @@ leaf_body_stmt :
     | mandatory_stmt
     | default_stmt
     | extension_stmt
+    | recover_stmt
 
 mandatory_stmt : 
     kywd_mandatory bool_value token_semi {
         l := yylex.(*lexer)        
         l.builder.Mandatory(l.stack.peek(), $2)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        chkErr(yylex, l.builder.LastErr)
     }
 
+ident_or_string :
+    token_ident { $$ = $1 }
+    | string_value
+
 string_value :
     token_string {
         $$ = tokenString($1)
@@ -1315,7 +1291,6 @@ int_value :
         n, err := strconv.ParseInt($1, 10, 32)
         if err != nil || n < 0 {
             yylex.Error(fmt.Sprintf("not a valid number for min elements %s", $1))
-            goto ret1
         }       
         $$ = int(n)
     }
@@ -1324,7 +1299,6 @@ int_value :
         n, err := strconv.ParseInt(s, 10, 32)
         if err != nil || n < 0 {
             yylex.Error(fmt.Sprintf("not a valid number for min elements %s", $1))
-            goto ret1
         }       
         $$ = int(n)        
     }
@@ -1337,9 +1311,7 @@ config_stmt :
     kywd_config bool_value token_semi {
         l := yylex.(*lexer)
         l.builder.Config(l.stack.peek(), $2)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        chkErr(yylex, l.builder.LastErr)
     }
 
 leaf_list_stmt :
@@ -1353,10 +1325,8 @@ leaf_list_stmt :
 leaf_list_def :
     kywd_leaf_list token_ident {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.LeafList(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.LeafList(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 bit_stmt :
@@ -1368,12 +1338,10 @@ bit_stmt :
     }
 
 bit_def :
-    kywd_bit token_ident {
+    kywd_bit ident_or_string {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Bit(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }        
+        l.push(l.builder.Bit(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)        
     }
 
 bit_body_stmts :
@@ -1390,9 +1358,7 @@ position :
     kywd_position int_value statement_end {
         l := yylex.(*lexer)
         l.builder.Position(l.stack.peek(), $2)
-        if chkErr2(l, "position", $3) {
-            goto ret1
-        }
+        chkErr2(l, "position", $3)
     }
 
 enum_stmt :
@@ -1404,12 +1370,10 @@ enum_stmt :
     }
 
 enum_def : 
-    kywd_enum token_ident {
+    kywd_enum ident_or_string {
         l := yylex.(*lexer)
-        l.stack.push(l.builder.Enum(l.stack.peek(), $2))
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.push(l.builder.Enum(l.stack.peek(), $2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 enum_body_stmts :
@@ -1426,63 +1390,49 @@ enum_value :
     kywd_value int_value statement_end {
         l := yylex.(*lexer)
         l.builder.EnumValue(l.stack.peek(), $2)
-        if chkErr2(l, "value", $3) {
-            goto ret1
-        }
+        chkErr2(l, "value", $3)
     }
 
 description : 
     kywd_description string_value statement_end {
         l := yylex.(*lexer)
         l.builder.Description(l.stack.peek(), $2)     
-        if chkErr2(l, "description", $3) {
-            goto ret1
-        }
+        chkErr2(l, "description", $3)
     }
 
 reference_stmt :
     kywd_reference string_value token_semi {
         l := yylex.(*lexer)
         l.builder.Reference(l.stack.peek(), $2)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        chkErr(yylex, l.builder.LastErr)
     }
 
 contact_stmt :
     kywd_contact string_value token_semi {
         l := yylex.(*lexer)        
         l.builder.Contact(l.stack.peek(), $2)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        chkErr(yylex, l.builder.LastErr)
     }
 
 organization_stmt :
     kywd_organization string_value token_semi {
         l := yylex.(*lexer)
         l.builder.Organization(l.stack.peek(), $2)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        chkErr(yylex, l.builder.LastErr)
     }
 
 yang_ver_stmt : 
     kywd_yang_version token_string token_semi {
         l := yylex.(*lexer)
-        l.builder.YangVersion(l.stack.peek(), $2)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.builder.YangVersion(l.stack.peek(), tokenString($2))
+        chkErr(yylex, l.builder.LastErr)
     }
 
 units_stmt :
     kywd_units token_string statement_end {        
         l := yylex.(*lexer)        
-        l.builder.Units(l.stack.peek(), $2)
-        if chkErr2(l, "units", $3) {
-            goto ret1
-        }
+        l.builder.Units(l.stack.peek(), tokenString($2))
+        chkErr2(l, "units", $3)
     }
 
 statement_end :
@@ -1509,9 +1459,8 @@ keyword_extension_stmt :
     token_extension optional_extension_args statement_end {              
         l := yylex.(*lexer)
         $$ = l.builder.Extension($1, $2)
-        if chkErr(yylex, l.builder.LastErr) {
-            goto ret1
-        }
+        l.builder.Location($$, l.location($<pos>1))
+        chkErr(yylex, l.builder.LastErr)
         // ironcically keyword extensions have have primary extensions
         if $3 != nil {
             l.builder.AddExtension($$, "", $3)
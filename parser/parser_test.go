@@ -50,15 +50,15 @@ func TestParseErr(t *testing.T) {
 	}{
 		{
 			y:   `uses g1;`,
-			err: "x/g1 - g1 group not found",
+			err: "1:24 - x/g1 - g1 group not found",
 		},
 		{
 			y:   `container x { uses g1; }`,
-			err: "x/x/g1 - g1 group not found",
+			err: "1:38 - x/x/g1 - g1 group not found",
 		},
 		{
 			y:   `container x { choice z { case q { uses g1; } } }`,
-			err: "x/x/z/q/g1 - g1 group not found",
+			err: "1:58 - x/x/z/q/g1 - g1 group not found",
 		},
 	}
 	for _, test := range tests {
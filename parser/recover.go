@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxParseErrors stops looking for more problems in hopelessly broken files
+const maxParseErrors = 100
+
+// statementEnd is where parsing resumes after a problem at pos. The parser
+// recovered in the body of statements at nesting level so the rest of the
+// statement at that level is skipped, including any bodies of the statement.
+// Depth is the nesting of pos. Parsing resumes after the statement or at the
+// end of the body the statement is in.
+func statementEnd(input string, pos int, depth int, level int) int {
+	s := &stmtScanner{input: input, i: pos}
+	for s.i < len(input) {
+		at := s.i
+		c, valid := s.next()
+		if !valid {
+			continue
+		}
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			if depth <= level {
+				// end of body parser recovered in
+				return at
+			}
+			depth--
+			if depth == level {
+				return s.i
+			}
+		case ';':
+			if depth == level {
+				return s.i
+			}
+		}
+	}
+	return len(input)
+}
+
+// stmtScanner walks thru YANG skipping strings and comments
+type stmtScanner struct {
+	input string
+	i     int
+}
+
+// next character and true if it's not in a string or comment
+func (s *stmtScanner) next() (byte, bool) {
+	c := s.input[s.i]
+	s.i++
+	switch c {
+	case '"', '\'':
+		for s.i < len(s.input) && s.input[s.i] != c {
+			if c == '"' && s.input[s.i] == '\\' {
+				s.i++
+			}
+			s.i++
+		}
+		s.i++
+		return c, false
+	case '/':
+		if s.i < len(s.input) && s.input[s.i] == '/' {
+			for s.i < len(s.input) && s.input[s.i] != '\n' {
+				s.i++
+			}
+			return c, false
+		}
+		if s.i < len(s.input) && s.input[s.i] == '*' {
+			s.i++
+			for s.i+1 < len(s.input) && !(s.input[s.i] == '*' && s.input[s.i+1] == '/') {
+				s.i++
+			}
+			s.i += 2
+			return c, false
+		}
+	}
+	return c, true
+}
+
+// Token is a keyword, an argument or one of ';', '{' or '}' in YANG text
+type Token struct {
+
+	// Text has quotes removed and double quoted strings unescaped
+	Text   string
+	Quoted bool
+
+	// Start and End are offsets in text including any quotes
+	Start, End int
+}
+
+// Tokens splits YANG text the way the parser does but without failing on any
+// errors so text that is being edited can still be read
+func Tokens(input string) []Token {
+	var tokens []Token
+	s := &stmtScanner{input: input}
+	for s.i < len(input) {
+		at := s.i
+		c, valid := s.next()
+		if s.i > len(input) {
+			// string or comment was not closed
+			s.i = len(input)
+		}
+		switch {
+		case !valid && (c == char_doublequote || c == char_singlequote):
+			end := s.i
+			if end > at+1 && input[end-1] == c {
+				end--
+			}
+			text := input[at+1 : end]
+			if c == char_doublequote {
+				text = unescape(text)
+			}
+			tokens = append(tokens, Token{Text: text, Quoted: true, Start: at, End: s.i})
+		case !valid || unicode.IsSpace(rune(c)):
+		case c == ';' || c == '{' || c == '}':
+			tokens = append(tokens, Token{Text: input[at:s.i], Start: at, End: s.i})
+		default:
+			for s.i < len(input) && !isTokenDelim(input[s.i:]) {
+				s.i++
+			}
+			tokens = append(tokens, Token{Text: input[at:s.i], Start: at, End: s.i})
+		}
+	}
+	return tokens
+}
+
+// isTokenDelim also ends unquoted strings at '}' and quotes so statements
+// missing a ';' while being edited are still split
+func isTokenDelim(s string) bool {
+	return isStringDelim(rune(s[0])) || strings.ContainsRune("}\"'", rune(s[0])) ||
+		strings.HasPrefix(s, str_comment_start) || strings.HasPrefix(s, str_comment_inline_start)
+}
@@ -0,0 +1,630 @@
+package parser
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+)
+
+// isYin detects YIN, the XML form of YANG, so YIN modules can be loaded with
+// the same functions as YANG.
+func isYin(data string) bool {
+	return strings.HasPrefix(strings.TrimSpace(data), "<")
+}
+
+// yinStmt is an XML element before it is known if it is a statement or the
+// argument of it's parent statement
+type yinStmt struct {
+	space  string
+	prefix string
+	local  string
+	attrs  []xml.Attr
+	text   strings.Builder
+	subs   []*yinStmt
+
+	// pos is offset of element's start tag for locations of definitions
+	pos int
+}
+
+// parseYin builds module from YIN with the same builder the YANG parser uses
+// so both compile exactly the same. Imports are loaded as they are read to
+// know the namespaces of their prefixes and how their extensions have their
+// argument. Error is meta.Diagnostics when module could be read but had
+// problems.
+func parseYin(data string, file string, parent *meta.Module, featureSet meta.FeatureSet, loader meta.Loader) (*meta.Module, error) {
+	root, err := decodeYin(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w. invalid yin. %s", fc.BadRequestError, err)
+	}
+	if root.space != meta.YinNamespace {
+		return nil, fmt.Errorf("%w. expected root element in namespace %s", fc.BadRequestError, meta.YinNamespace)
+	}
+	c := &yinBuilder{
+		builder:       &meta.Builder{},
+		positions:     &lexer{input: data, file: file},
+		featureSet:    featureSet,
+		loader:        loader,
+		prefixes:      make(map[string]string),
+		extensionDefs: make(map[string]yinExtensionDef),
+	}
+	m, err := c.module(root, parent)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.diags) > 0 {
+		return m, c.diags
+	}
+	return m, nil
+}
+
+func decodeYin(data string) (*yinStmt, error) {
+	d := xml.NewDecoder(strings.NewReader(data))
+	// extensions are written as prefix:ident so we need to track the prefix
+	// and not just the namespace the prefix maps to.
+	var scopes []map[string]string
+	var stack []*yinStmt
+	var root *yinStmt
+	for {
+		pos := int(d.InputOffset())
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			scope := make(map[string]string)
+			var attrs []xml.Attr
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" {
+					scope[a.Value] = a.Name.Local
+				} else if a.Name.Space != "" || a.Name.Local != "xmlns" {
+					attrs = append(attrs, a)
+				}
+			}
+			scopes = append(scopes, scope)
+			s := &yinStmt{
+				space:  t.Name.Space,
+				prefix: yinPrefix(scopes, t.Name.Space),
+				local:  t.Name.Local,
+				attrs:  attrs,
+				pos:    pos,
+			}
+			if len(stack) == 0 {
+				root = s
+			} else {
+				parent := stack[len(stack)-1]
+				parent.subs = append(parent.subs, s)
+			}
+			stack = append(stack, s)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+			scopes = scopes[:len(scopes)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no root element")
+	}
+	return root, nil
+}
+
+func yinPrefix(scopes []map[string]string, space string) string {
+	for i := len(scopes) - 1; i >= 0; i-- {
+		if prefix, found := scopes[i][space]; found {
+			return prefix
+		}
+	}
+	return ""
+}
+
+type yinExtensionDef struct {
+	arg     string
+	element bool
+}
+
+type yinBuilder struct {
+	builder    *meta.Builder
+	featureSet meta.FeatureSet
+	loader     meta.Loader
+	diags      meta.Diagnostics
+
+	// positions finds line and column of elements the same way as for YANG
+	positions *lexer
+
+	// YANG prefixes by namespace as XML prefixes do not have to be the same
+	prefixes map[string]string
+
+	// extension definitions by prefix:ident. Extensions from modules that
+	// could not be loaded are not here so their arguments are guessed.
+	extensionDefs map[string]yinExtensionDef
+}
+
+func (c *yinBuilder) module(root *yinStmt, parent *meta.Module) (*meta.Module, error) {
+	var m *meta.Module
+	switch root.local {
+	case "module":
+		if parent != nil {
+			return nil, fmt.Errorf("%w. expected submodule for include", fc.BadRequestError)
+		}
+		name, _, err := c.argument(root, root.local, "name", false)
+		if err != nil {
+			return nil, err
+		}
+		m = c.builder.Module(name, c.featureSet)
+	case "submodule":
+		if parent == nil {
+			return nil, fmt.Errorf("%w. submodule is for includes", fc.BadRequestError)
+		}
+		// definitions of submodules are added to parent module
+		m = parent
+	default:
+		return nil, fmt.Errorf("%w. expected module or submodule but got %s", fc.BadRequestError, root.local)
+	}
+	c.localDefs(root, parent)
+	for _, s := range root.subs {
+		c.statement(m, s)
+	}
+	return m, nil
+}
+
+// localDefs finds the prefix and namespace of the module and the extensions
+// it defines as extensions can be used before they are defined
+func (c *yinBuilder) localDefs(root *yinStmt, parent *meta.Module) {
+	var prefix, namespace string
+	for _, s := range root.subs {
+		switch s.local {
+		case "prefix":
+			prefix = c.attr(s, "value")
+		case "belongs-to":
+			for _, sub := range s.subs {
+				if sub.local == "prefix" {
+					prefix = c.attr(sub, "value")
+				}
+			}
+		case "namespace":
+			namespace = c.attr(s, "uri")
+		}
+	}
+	if parent != nil {
+		namespace = parent.Namespace()
+		for ident, x := range parent.ExtensionDefs() {
+			c.extensionDefs[prefix+":"+ident] = extensionDef(x)
+		}
+	}
+	if namespace != "" {
+		c.prefixes[namespace] = prefix
+	}
+	for _, s := range root.subs {
+		if s.space != meta.YinNamespace || s.local != "extension" {
+			continue
+		}
+		var def yinExtensionDef
+		for _, arg := range s.subs {
+			if arg.local != "argument" {
+				continue
+			}
+			def.arg = c.attr(arg, "name")
+			for _, yinElem := range arg.subs {
+				if yinElem.local == "yin-element" {
+					def.element = (c.attr(yinElem, "value") == "true")
+				}
+			}
+		}
+		c.extensionDefs[prefix+":"+c.attr(s, "name")] = def
+	}
+}
+
+func extensionDef(x *meta.ExtensionDef) yinExtensionDef {
+	var def yinExtensionDef
+	if args := x.Arguments(); len(args) > 0 {
+		def.arg, def.element = args[0].Ident(), args[0].YinElement()
+	}
+	return def
+}
+
+// importLoader loads imported module for the namespace of the prefix it is
+// imported with and its extension definitions. Loader that is returned gives
+// that module to the resolver so it is not loaded again. Problems loading
+// are reported when imports are resolved.
+func (c *yinBuilder) importLoader(i *yinStmt, moduleName string) meta.Loader {
+	if c.loader == nil {
+		return nil
+	}
+	var prefix, rev string
+	for _, sub := range i.subs {
+		switch sub.local {
+		case "prefix":
+			prefix = c.attr(sub, "value")
+		case "revision-date":
+			rev = c.attr(sub, "date")
+		}
+	}
+	imported, err := c.loader(nil, moduleName, rev, c.featureSet, c.loader)
+	if err != nil || imported == nil {
+		return c.loader
+	}
+	c.prefixes[imported.Namespace()] = prefix
+	for ident, x := range imported.ExtensionDefs() {
+		c.extensionDefs[prefix+":"+ident] = extensionDef(x)
+	}
+	loader := c.loader
+	return func(parent *meta.Module, name string, r string, fs meta.FeatureSet, l meta.Loader) (*meta.Module, error) {
+		if imported != nil && parent == nil && name == moduleName && r == rev {
+			m := imported
+			imported = nil
+			return m, nil
+		}
+		return loader(parent, name, r, fs, l)
+	}
+}
+
+func (c *yinBuilder) attr(s *yinStmt, name string) string {
+	for _, a := range s.attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// argument of statement from the attribute or, when element is true, the
+// child element with name. Substatements are the children that are not the
+// argument.
+func (c *yinBuilder) argument(s *yinStmt, keyword string, name string, element bool) (string, []*yinStmt, error) {
+	if name == "" {
+		return "", s.subs, nil
+	}
+	if !element {
+		for _, a := range s.attrs {
+			if a.Name.Local == name {
+				return strings.Trim(a.Value, " \t\n\r"), s.subs, nil
+			}
+		}
+		return "", nil, fmt.Errorf("%w. %s missing attribute %s", fc.BadRequestError, keyword, name)
+	}
+	for i, sub := range s.subs {
+		if sub.local == name {
+			subs := append(append([]*yinStmt{}, s.subs[:i]...), s.subs[i+1:]...)
+			return strings.Trim(sub.text.String(), " \t\n\r"), subs, nil
+		}
+	}
+	return "", nil, fmt.Errorf("%w. %s missing element %s", fc.BadRequestError, keyword, name)
+}
+
+// statement adds definition of YIN element to parent. Like the YANG parser,
+// statement with a problem is skipped and building continues to find more
+// problems.
+func (c *yinBuilder) statement(parent interface{}, s *yinStmt) {
+	if s.space != meta.YinNamespace {
+		if ext := c.extension(s); ext != nil {
+			c.builder.AddExtension(parent, "", ext)
+			c.chkErr(s)
+		}
+		return
+	}
+	if s.local == "belongs-to" {
+		// prefix was read with the other definitions of the module
+		return
+	}
+	argName, element := meta.YinArgument(s.local)
+	arg, subs, err := c.argument(s, s.local, argName, element)
+	if err == nil {
+		var def interface{}
+		def, err = c.define(parent, s, arg)
+		if err == nil {
+			err = c.builder.LastErr
+		}
+		c.builder.LastErr = nil
+		if err == nil {
+			if def != nil {
+				c.builder.Location(def, c.location(s))
+			}
+			c.substatements(parent, def, s.local, subs)
+			return
+		}
+	}
+	c.errorAt(s, err)
+}
+
+// substatements are added to definition statement started or, when
+// statement does not start a definition, only extensions are allowed and
+// are added to parent with keyword of statement
+func (c *yinBuilder) substatements(parent interface{}, def interface{}, keyword string, subs []*yinStmt) {
+	for _, sub := range subs {
+		if def != nil {
+			c.statement(def, sub)
+			continue
+		}
+		if sub.space == meta.YinNamespace {
+			c.errorAt(sub, fmt.Errorf("unexpected %s in %s", sub.local, keyword))
+			continue
+		}
+		if ext := c.extension(sub); ext != nil {
+			c.builder.AddExtension(parent, keyword, ext)
+			c.chkErr(sub)
+		}
+	}
+}
+
+// location of element in YIN file
+func (c *yinBuilder) location(s *yinStmt) *meta.Location {
+	return c.positions.location(s.pos)
+}
+
+// errorAt records a problem with element and building continues to find
+// more problems
+func (c *yinBuilder) errorAt(s *yinStmt, err error) {
+	c.diags = append(c.diags, meta.NewDiagnostic(&meta.LocationError{
+		Location: c.location(s),
+		Err:      err,
+	}))
+}
+
+func (c *yinBuilder) chkErr(s *yinStmt) {
+	if c.builder.LastErr != nil {
+		c.errorAt(s, c.builder.LastErr)
+		c.builder.LastErr = nil
+	}
+}
+
+// define calls builder for statement with argument. Definition is returned
+// only when substatements, other than extensions, are added to it.
+func (c *yinBuilder) define(parent interface{}, s *yinStmt, arg string) (interface{}, error) {
+	b := c.builder
+	switch s.local {
+	case "namespace":
+		b.Namespace(parent, arg)
+	case "prefix":
+		b.Prefix(parent, arg)
+	case "yang-version":
+		b.YangVersion(parent, arg)
+	case "organization":
+		b.Organization(parent, arg)
+	case "contact":
+		b.Contact(parent, arg)
+	case "description":
+		b.Description(parent, arg)
+	case "reference":
+		b.Reference(parent, arg)
+	case "status":
+		if arg != "current" && arg != "obsolete" && arg != "deprecated" {
+			return nil, fmt.Errorf("invalid status %s", arg)
+		}
+	case "revision":
+		switch parent.(type) {
+		case *meta.Import, *meta.Include:
+			// ignored like in YANG
+			return nil, nil
+		}
+		return b.Revision(parent, arg), nil
+	case "revision-date":
+		b.Revision(parent, arg)
+	case "import":
+		return b.Import(parent, arg, c.importLoader(s, arg)), nil
+	case "include":
+		return b.Include(parent, arg, c.loader), nil
+	case "extension":
+		return b.ExtensionDef(parent, arg), nil
+	case "argument":
+		return b.ExtensionDefArg(parent, arg), nil
+	case "yin-element":
+		x, err := yinBool(s.local, arg)
+		b.YinElement(parent, x)
+		return nil, err
+	case "deviation":
+		return b.Deviation(parent, arg), nil
+	case "deviate":
+		switch arg {
+		case "not-supported":
+			b.NotSupported(parent)
+			return nil, nil
+		case "add":
+			return b.AddDeviate(parent), nil
+		case "replace":
+			return b.ReplaceDeviate(parent), nil
+		case "delete":
+			return b.DeleteDeviate(parent), nil
+		}
+		return nil, fmt.Errorf("invalid deviate %s", arg)
+	case "feature":
+		return b.Feature(parent, arg), nil
+	case "if-feature":
+		b.Location(b.IfFeature(parent, arg), c.location(s))
+	case "must":
+		return b.Must(parent, arg), nil
+	case "error-message":
+		b.ErrorMessage(parent, arg)
+	case "error-app-tag":
+		b.ErrorAppTag(parent, arg)
+	case "when":
+		return b.When(parent, arg), nil
+	case "identity":
+		return b.Identity(parent, arg), nil
+	case "base":
+		b.Base(parent, arg)
+	case "choice":
+		return b.Choice(parent, arg), nil
+	case "case":
+		return b.Case(parent, arg), nil
+	case "typedef":
+		return b.Typedef(parent, arg), nil
+	case "default":
+		b.Default(parent, arg)
+	case "type":
+		return b.Type(parent, arg), nil
+	case "path":
+		b.Path(parent, arg)
+	case "range":
+		return b.ValueRange(parent, arg), nil
+	case "length":
+		return b.LengthRange(parent, arg), nil
+	case "pattern":
+		return b.Pattern(parent, arg), nil
+	case "require-instance":
+		x, err := yinBool(s.local, arg)
+		b.RequireInstance(parent, x)
+		return nil, err
+	case "fraction-digits":
+		x, err := yinInt(s.local, arg)
+		b.FractionDigits(parent, x)
+		return nil, err
+	case "container":
+		return b.Container(parent, arg), nil
+	case "presence":
+		b.Presence(parent, arg)
+	case "augment":
+		return b.Augment(parent, arg), nil
+	case "uses":
+		return b.Uses(parent, arg), nil
+	case "refine":
+		return b.Refine(parent, arg), nil
+	case "rpc", "action":
+		return b.Action(parent, arg), nil
+	case "input":
+		return b.ActionInput(parent), nil
+	case "output":
+		return b.ActionOutput(parent), nil
+	case "notification":
+		return b.Notification(parent, arg), nil
+	case "grouping":
+		return b.Grouping(parent, arg), nil
+	case "list":
+		return b.List(parent, arg), nil
+	case "max-elements":
+		if arg == "unbounded" {
+			b.UnBounded(parent, true)
+			return nil, nil
+		}
+		x, err := yinInt(s.local, arg)
+		b.MaxElements(parent, x)
+		return nil, err
+	case "min-elements":
+		x, err := yinInt(s.local, arg)
+		b.MinElements(parent, x)
+		return nil, err
+	case "ordered-by":
+		switch arg {
+		case "system":
+			b.OrderedBy(parent, meta.OrderedBySystem)
+		case "user":
+			b.OrderedBy(parent, meta.OrderedByUser)
+		default:
+			return nil, fmt.Errorf("invalid ordered-by %s", arg)
+		}
+	case "key":
+		b.Key(parent, arg)
+	case "unique":
+		b.Unique(parent, arg)
+	case "anyxml", "anydata":
+		return b.Any(parent, arg), nil
+	case "leaf":
+		return b.Leaf(parent, arg), nil
+	case "leaf-list":
+		return b.LeafList(parent, arg), nil
+	case "config":
+		x, err := yinBool(s.local, arg)
+		b.Config(parent, x)
+		return nil, err
+	case "mandatory":
+		x, err := yinBool(s.local, arg)
+		b.Mandatory(parent, x)
+		return nil, err
+	case "bit":
+		return b.Bit(parent, arg), nil
+	case "position":
+		x, err := yinInt(s.local, arg)
+		b.Position(parent, x)
+		return nil, err
+	case "enum":
+		return b.Enum(parent, arg), nil
+	case "value":
+		x, err := yinInt(s.local, arg)
+		b.EnumValue(parent, x)
+		return nil, err
+	case "units":
+		b.Units(parent, arg)
+	default:
+		return nil, fmt.Errorf("unexpected %s", s.local)
+	}
+	return nil, nil
+}
+
+func yinBool(keyword string, arg string) (bool, error) {
+	switch arg {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("expected true or false for %s but got %s", keyword, arg)
+}
+
+func yinInt(keyword string, arg string) (int, error) {
+	n, err := strconv.ParseInt(arg, 10, 32)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("not a valid number for %s %s", keyword, arg)
+	}
+	return int(n), nil
+}
+
+// extension from element in namespace of module that defines it
+func (c *yinBuilder) extension(s *yinStmt) *meta.Extension {
+	prefix, found := c.prefixes[s.space]
+	if !found {
+		// module of extension is not known so hope XML prefix is the same as
+		// YANG prefix
+		prefix = s.prefix
+	}
+	if prefix == "" {
+		c.errorAt(s, fmt.Errorf("%w. no prefix for extension %s in namespace %s", fc.BadRequestError, s.local, s.space))
+		return nil
+	}
+	keyword := prefix + ":" + s.local
+	args := []string{}
+	subs := s.subs
+	if def, found := c.extensionDefs[keyword]; found {
+		if def.arg != "" {
+			arg, rest, err := c.argument(s, keyword, def.arg, def.element)
+			if err != nil {
+				c.errorAt(s, err)
+				return nil
+			}
+			args, subs = []string{arg}, rest
+		}
+	} else if len(s.attrs) > 0 {
+		// without definition, any attribute or a child element with only
+		// text is taken as the argument
+		args = []string{s.attrs[0].Value}
+	} else {
+		for i, sub := range s.subs {
+			if sub.space == s.space && len(sub.subs) == 0 && len(sub.attrs) == 0 {
+				args = []string{strings.Trim(sub.text.String(), " \t\n\r")}
+				subs = append(append([]*yinStmt{}, s.subs[:i]...), s.subs[i+1:]...)
+				break
+			}
+		}
+	}
+	ext := c.builder.Extension(keyword, args)
+	c.builder.Location(ext, c.location(s))
+	for _, sub := range subs {
+		if sub.space == meta.YinNamespace {
+			c.errorAt(sub, fmt.Errorf("unexpected %s in %s", sub.local, keyword))
+			continue
+		}
+		// ironically extensions can have extensions
+		if x := c.extension(sub); x != nil {
+			c.builder.AddExtension(ext, "", x)
+		}
+	}
+	return ext
+}
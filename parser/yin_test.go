@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"io"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/source"
+	"github.com/freeconf/yang/val"
+)
+
+func TestYin(t *testing.T) {
+	// main is only available as YIN and imports module only available as YANG
+	m, err := LoadModule(source.Dir("./testdata/yin"), "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, "main & only", m.Description())
+	c := meta.Find(m, "c").(*meta.Container)
+	// locations are where elements are in XML
+	fc.AssertEqual(t, "main.yin:19:3", c.Location().String())
+	fc.AssertEqual(t, "main.yin:20:5", c.Extensions()[0].Location().String())
+	fc.AssertEqual(t, "m:note", c.Extensions()[0].Prefix()+":"+c.Extensions()[0].Ident())
+	fc.AssertEqual(t, "a < b", c.Extensions()[0].Arguments()[0])
+	x := meta.Find(c, "x").(*meta.Leaf)
+	fc.AssertEqual(t, "main.yin:23:5", x.Location().String())
+	fc.AssertEqual(t, val.FmtInt32, x.Type().Format())
+	fc.AssertEqual(t, "high", x.Extensions()[0].Arguments()[0])
+	y := meta.Find(c, "y").(*meta.Leaf)
+	fc.AssertEqual(t, "../x > 0", y.Musts()[0].Expression())
+	// XML prefix is not the YANG prefix
+	fc.AssertEqual(t, "s:label", y.Extensions()[0].Prefix()+":"+y.Extensions()[0].Ident())
+	fc.AssertEqual(t, `it's "y"`, y.Extensions()[0].Arguments()[0])
+	z := meta.Find(c, "z").(*meta.Leaf)
+	fc.AssertEqual(t, "foo bar", z.Type().Enum()[0].Label)
+}
+
+func TestYinImportLoadedOnce(t *testing.T) {
+	dir := source.Dir("./testdata/yin")
+	var opened int
+	ypath := func(name string, ext string) (io.Reader, error) {
+		r, err := dir(name, ext)
+		if name == "sub" && r != nil {
+			opened++
+		}
+		return r, err
+	}
+	m, err := LoadModule(ypath, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc.AssertEqual(t, 1, opened)
+	fc.AssertEqual(t, "urn:sub", m.Imports()["s"].Module().Namespace())
+}
+
+func TestYinDiagnostics(t *testing.T) {
+	yin := `<module xmlns="urn:ietf:params:xml:ns:yang:yin:1" name="x">
+  <namespace uri="urn:x"/>
+  <prefix value="x"/>
+  <container name="c">
+    <config value="maybe"/>
+  </container>
+  <bogus/>
+</module>`
+	_, err := LoadModuleFromString(nil, yin)
+	diags := meta.DiagnosticsOf(err)
+	fc.AssertEqual(t, 2, len(diags))
+	fc.AssertEqual(t, 5, diags[0].Location.Line)
+	fc.AssertEqual(t, 5, diags[0].Location.Col)
+	fc.AssertEqual(t, 7, diags[1].Location.Line)
+	fc.AssertEqual(t, 3, diags[1].Location.Col)
+}
+
+func TestYinErrors(t *testing.T) {
+	tests := []string{
+		`<module name="x"/>`,
+		`<module xmlns="urn:ietf:params:xml:ns:yang:yin:1"><namespace/></module>`,
+		`<module xmlns="urn:ietf:params:xml:ns:yang:yin:1" name="x">`,
+		`<module xmlns="urn:ietf:params:xml:ns:yang:yin:1" name="x"><bogus/></module>`,
+		`<module xmlns="urn:ietf:params:xml:ns:yang:yin:1" name="x"><container name="c"><config value="maybe"/></container></module>`,
+	}
+	for _, test := range tests {
+		_, err := LoadModuleFromString(nil, test)
+		if err == nil {
+			t.Error("expected error for ", test)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -44,14 +46,29 @@ func Any(s ...Opener) Opener {
 	}
 }
 
-// Dir will simply find yang files in a directory
+// Dir will simply find yang files in a directory. If there is no file without
+// a revision in it's name, name@revision, then the latest revision is used.
 func Dir(root string) Opener {
 	return func(resourceId string, ext string) (io.Reader, error) {
 		path := fmt.Sprint(root, "/", resourceId, ext)
 		stream, err := os.Open(path)
 		if os.IsNotExist(err) {
-			return nil, nil
+			if strings.Contains(resourceId, "@") {
+				return nil, nil
+			}
+			return latestRevision(root, resourceId, ext)
 		}
 		return stream, err
 	}
 }
+
+// latestRevision finds file with latest revision in name.  Revisions are
+// dates, YYYY-MM-DD, so they sort in order they were made.
+func latestRevision(root string, resourceId string, ext string) (io.Reader, error) {
+	candidates, err := filepath.Glob(fmt.Sprint(root, "/", resourceId, "@*", ext))
+	if err != nil || len(candidates) == 0 {
+		return nil, err
+	}
+	sort.Strings(candidates)
+	return os.Open(candidates[len(candidates)-1])
+}
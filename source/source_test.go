@@ -1,6 +1,9 @@
 package source
 
 import (
+	"io"
+	"io/ioutil"
+	"os"
 	"testing"
 )
 
@@ -14,3 +17,39 @@ func TestDirNotFound(t *testing.T) {
 		t.Error("expected no err")
 	}
 }
+
+func TestDirLatestRevision(t *testing.T) {
+	dir, err := ioutil.TempDir("", "source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, rev := range []string{"2021-01-01", "2022-01-01"} {
+		if err := ioutil.WriteFile(dir+"/x@"+rev+".yang", []byte(rev), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		resource string
+		expected string
+	}{
+		{resource: "x", expected: "2022-01-01"},
+		{resource: "x@2021-01-01", expected: "2021-01-01"},
+		{resource: "x@2020-01-01", expected: ""},
+	}
+	for _, test := range tests {
+		s, err := Dir(dir)(test.resource, ".yang")
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual := ""
+		if s != nil {
+			data, _ := ioutil.ReadAll(s)
+			s.(io.Closer).Close()
+			actual = string(data)
+		}
+		if actual != test.expected {
+			t.Errorf("%s: expected %q got %q", test.resource, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,441 @@
+package tree
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/freeconf/yang/meta"
+	"github.com/freeconf/yang/val"
+)
+
+// Wtr writes a module as the tree diagram of RFC 8340 that IETF drafts use
+// to give an overview of a module.
+//
+//	module: car
+//	  +--rw engine
+//	  |  +--rw speed?   int32
+//	  +--ro tire* [pos]
+//	     +--ro pos    string
+//
+// Each node is written as <status>--<flags> <name><opts> <type> <if-features>
+// where status is "+" for current, "x" for deprecated and "o" for obsolete,
+// flags are "rw" for config, "ro" for read-only, "-w" for input, "-x" for
+// rpcs and actions and "-n" for notifications. Opts are "?" for optional,
+// "!" for presence containers, "*" for lists and leaf-lists followed by keys
+// of lists in brackets.
+//
+// Definitions that other modules augmented into module have the prefix of
+// the augmenting module and augments this module makes to other modules are
+// written in their own sections.
+//
+// Module must be compiled.
+type Wtr struct {
+	Out io.Writer
+
+	// Depth limits how many levels of nodes are written. Where there is
+	// more, "..." is written instead. Zero means no limit.
+	Depth int
+
+	// Path only writes the nodes along schema path like "/car/engine" and
+	// all the nodes below it.  Prefixes on path are ignored.
+	Path string
+
+	// LineLength is where longer lines are folded. Zero means no limit.
+	LineLength int
+}
+
+// WriteTree writes tree diagram of module to a string
+func WriteTree(m *meta.Module) (string, error) {
+	var buf bytes.Buffer
+	w := &Wtr{Out: &buf}
+	err := w.Write(m)
+	return buf.String(), err
+}
+
+// Write tree diagram of module to stream
+func (w *Wtr) Write(m *meta.Module) error {
+	t := &writer{
+		Wtr:    w,
+		module: m,
+		onPath: make(map[meta.Definition]bool),
+	}
+	if w.Path != "" {
+		for _, seg := range strings.Split(strings.Trim(w.Path, "/"), "/") {
+			if colon := strings.IndexRune(seg, ':'); colon >= 0 {
+				seg = seg[colon+1:]
+			}
+			t.path = append(t.path, seg)
+		}
+	}
+	fmt.Fprintf(&t.out, "module: %s\n", m.Ident())
+	t.children("  ", m.DataDefinitions(), "", 0, 1, t.path)
+
+	printed := make(map[meta.Meta]bool)
+	for _, a := range m.Augments() {
+		target := meta.Find(m, a.Ident())
+		if target == nil || meta.RootModule(target) == m || printed[target] {
+			continue
+		}
+		printed[target] = true
+		var defs []meta.Definition
+		for _, def := range target.(meta.HasDataDefinitions).DataDefinitions() {
+			if meta.NamespaceModule(def) == m {
+				defs = append(defs, def)
+			}
+		}
+		if x, valid := target.(meta.HasActions); valid {
+			defs = append(defs, actions(x.Actions(), m)...)
+		}
+		if x, valid := target.(meta.HasNotifications); valid {
+			defs = append(defs, notifications(x.Notifications(), m)...)
+		}
+		if len(defs) == 0 || len(t.path) > 0 {
+			continue
+		}
+		t.out.WriteString("\n")
+		t.fold("  augment ", a.Ident()+":")
+		t.children("    ", defs, flags(target, ""), 0, 1, nil)
+	}
+
+	if rpcs := actions(m.Actions(), nil); len(rpcs) > 0 && included(rpcs, t.path) {
+		t.out.WriteString("\n  rpcs:\n")
+		t.children("    ", rpcs, "", 0, 1, t.path)
+	}
+	if notifs := notifications(m.Notifications(), nil); len(notifs) > 0 && included(notifs, t.path) {
+		t.out.WriteString("\n  notifications:\n")
+		t.children("    ", notifs, "", 0, 1, t.path)
+	}
+	_, err := w.Out.Write(t.out.Bytes())
+	return err
+}
+
+type writer struct {
+	*Wtr
+	module *meta.Module
+	path   []string
+	out    bytes.Buffer
+
+	// ancestors of node being written so recursive schemas end
+	onPath map[meta.Definition]bool
+}
+
+// included is true if any of the definitions are along the path
+func included(defs []meta.Definition, path []string) bool {
+	if len(path) == 0 {
+		return true
+	}
+	for _, def := range defs {
+		if matches(def, path[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// children writes definitions with same parent where prefix is what is
+// written before each line and parentFlags are flags of parent so children
+// can inherit them. Width is for lining up types, zero to fit definitions.
+// Path is what is left of Wtr.Path to follow.
+func (t *writer) children(prefix string, defs []meta.Definition, parentFlags string, width int, level int, path []string) {
+	if len(path) > 0 {
+		var match []meta.Definition
+		for _, def := range defs {
+			if matches(def, path[0]) {
+				match = append(match, def)
+			}
+		}
+		defs = match
+	}
+	if width == 0 {
+		width = t.width(defs)
+	}
+	for i, def := range defs {
+		last := i == len(defs)-1
+		t.node(prefix, def, parentFlags, width, last, level, path)
+	}
+}
+
+// matches is true if definition is next segment of path. Choices and cases
+// are not in paths so they match if anything in them does.
+func matches(def meta.Definition, seg string) bool {
+	switch x := def.(type) {
+	case *meta.Choice:
+		for _, kase := range x.Cases() {
+			if matches(kase, seg) {
+				return true
+			}
+		}
+		return false
+	case *meta.ChoiceCase:
+		for _, child := range x.DataDefinitions() {
+			if matches(child, seg) {
+				return true
+			}
+		}
+		return false
+	}
+	return def.Ident() == seg
+}
+
+// width is longest name in a group of siblings so types line up. Definitions
+// in choices are indented further
+func (t *writer) width(defs []meta.Definition) int {
+	w := 0
+	for _, def := range defs {
+		var n int
+		switch x := def.(type) {
+		case *meta.Choice:
+			var cases []meta.Definition
+			for _, ident := range x.CaseIdents() {
+				cases = append(cases, x.Cases()[ident])
+			}
+			n = 3 + t.width(cases)
+		case *meta.ChoiceCase:
+			n = 3 + t.width(x.DataDefinitions())
+		default:
+			n = len(t.name(def))
+		}
+		if n > w {
+			w = n
+		}
+	}
+	return w
+}
+
+func (t *writer) node(prefix string, def meta.Definition, parentFlags string, width int, last bool, level int, path []string) {
+	f := flags(def, parentFlags)
+	line := prefix + status(def) + "--" + f + " "
+	if _, isCase := def.(*meta.ChoiceCase); isCase {
+		line = prefix + status(def) + "--:"
+	}
+	name := t.name(def)
+	var typ string
+	switch x := def.(type) {
+	case *meta.Choice:
+		name = "(" + name + ")"
+		if !x.Mandatory() {
+			name += "?"
+		}
+	case *meta.ChoiceCase:
+		name = "(" + name + ")"
+	case *meta.Container:
+		if x.Presence() != "" {
+			name += "!"
+		}
+	case *meta.List:
+		name += "*"
+		var keys []string
+		for _, k := range x.KeyMeta() {
+			keys = append(keys, k.Ident())
+		}
+		if len(keys) > 0 {
+			name += " [" + strings.Join(keys, " ") + "]"
+		}
+	case *meta.LeafList:
+		name += "*"
+		typ = typeName(x.Type())
+	case *meta.Leaf:
+		if !x.Mandatory() && !isKey(x) {
+			name += "?"
+		}
+		typ = typeName(x.Type())
+	case *meta.Any:
+		if !x.Mandatory() {
+			name += "?"
+		}
+		typ = "anydata"
+	}
+	var tail []string
+	if typ != "" {
+		if pad := width + 1 - len(name); pad > 0 {
+			name += strings.Repeat(" ", pad)
+		}
+		tail = append(tail, "  "+typ)
+	}
+	if features := ifFeatures(def); features != "" {
+		tail = append(tail, " "+features)
+	}
+	childPrefix := prefix + "|  "
+	if last {
+		childPrefix = prefix + "   "
+	}
+	// when line is too long, type and if-features move to next line lined up
+	// a little past the name
+	indent := childPrefix + strings.Repeat(" ", len(line)+4-len(childPrefix))
+	line += name
+	for _, s := range tail {
+		if t.LineLength > 0 && len(line)+len(s) > t.LineLength {
+			t.out.WriteString(strings.TrimRight(line, " ") + "\n")
+			line = indent + strings.TrimLeft(s, " ")
+			continue
+		}
+		line += s
+	}
+	t.out.WriteString(line + "\n")
+
+	var kids []meta.Definition
+	switch x := def.(type) {
+	case *meta.Choice:
+		for _, ident := range x.CaseIdents() {
+			kids = append(kids, x.Cases()[ident])
+		}
+	case *meta.Rpc:
+		if x.Input() != nil {
+			kids = append(kids, x.Input())
+		}
+		if x.Output() != nil {
+			kids = append(kids, x.Output())
+		}
+	}
+	if x, valid := def.(meta.HasDataDefinitions); valid {
+		kids = append(kids, x.DataDefinitions()...)
+	}
+	if x, valid := def.(meta.HasActions); valid {
+		kids = append(kids, actions(x.Actions(), nil)...)
+	}
+	if x, valid := def.(meta.HasNotifications); valid {
+		kids = append(kids, notifications(x.Notifications(), nil)...)
+	}
+	if len(kids) == 0 {
+		return
+	}
+	_, isChoice := def.(*meta.Choice)
+	_, isCase := def.(*meta.ChoiceCase)
+	if (t.Depth > 0 && level >= t.Depth && !isChoice && !isCase) || t.onPath[def] {
+		t.out.WriteString(childPrefix + "   ...\n")
+		return
+	}
+	t.onPath[def] = true
+	if isChoice || isCase {
+		// choices and cases are not data nodes so they do not count as a
+		// level and what is in them lines up with their siblings
+		t.children(childPrefix, kids, f, width-3, level, path)
+	} else {
+		if len(path) > 0 {
+			path = path[1:]
+		}
+		t.children(childPrefix, kids, f, 0, level+1, path)
+	}
+	delete(t.onPath, def)
+}
+
+// fold writes long text like augment paths across lines breaking at "/"
+func (t *writer) fold(prefix string, s string) {
+	line := prefix
+	for t.LineLength > 0 && len(line)+len(s) > t.LineLength {
+		cut := strings.LastIndex(s[:t.LineLength-len(line)], "/")
+		if cut <= 0 {
+			break
+		}
+		t.out.WriteString(line + s[:cut] + "\n")
+		line = strings.Repeat(" ", len(prefix)+2)
+		s = s[cut:]
+	}
+	t.out.WriteString(line + s + "\n")
+}
+
+// name of definition with prefix of module that augmented it in when it is
+// not this module
+func (t *writer) name(def meta.Definition) string {
+	switch def.(type) {
+	case *meta.RpcInput, *meta.RpcOutput, *meta.ChoiceCase:
+		return def.Ident()
+	}
+	if m := meta.NamespaceModule(def); m != t.module {
+		return m.Prefix() + ":" + def.Ident()
+	}
+	return def.Ident()
+}
+
+func flags(def meta.Meta, parentFlags string) string {
+	switch def.(type) {
+	case *meta.Rpc:
+		return "-x"
+	case *meta.Notification:
+		return "-n"
+	case *meta.RpcInput:
+		return "-w"
+	case *meta.RpcOutput:
+		return "ro"
+	}
+	switch parentFlags {
+	case "-w":
+		return "-w"
+	case "ro", "-n":
+		return "ro"
+	}
+	if x, valid := def.(meta.HasConfig); valid && x.IsConfigSet() && !x.Config() {
+		return "ro"
+	}
+	return "rw"
+}
+
+func status(def meta.Definition) string {
+	if x, valid := def.(meta.HasStatus); valid {
+		switch x.Status() {
+		case meta.Deprecated:
+			return "x"
+		case meta.Obsolete:
+			return "o"
+		}
+	}
+	return "+"
+}
+
+func isKey(l *meta.Leaf) bool {
+	if list, valid := l.Parent().(*meta.List); valid {
+		for _, k := range list.KeyMeta() {
+			if k.Ident() == l.Ident() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// typeName is type as it was written or the path of leafrefs
+func typeName(t *meta.Type) string {
+	if t.Format().Single() == val.FmtLeafRef {
+		return "-> " + t.Path()
+	}
+	return t.Ident()
+}
+
+func ifFeatures(def meta.Definition) string {
+	x, valid := def.(meta.HasIfFeatures)
+	if !valid || len(x.IfFeatures()) == 0 {
+		return ""
+	}
+	var exprs []string
+	for _, f := range x.IfFeatures() {
+		exprs = append(exprs, f.Expression())
+	}
+	return "{" + strings.Join(exprs, ",") + "}?"
+}
+
+// actions sorted by name and when module is given, only the ones module
+// augmented in
+func actions(m map[string]*meta.Rpc, from *meta.Module) []meta.Definition {
+	var defs []meta.Definition
+	for _, a := range m {
+		if from == nil || meta.NamespaceModule(a) == from {
+			defs = append(defs, a)
+		}
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Ident() < defs[j].Ident() })
+	return defs
+}
+
+func notifications(m map[string]*meta.Notification, from *meta.Module) []meta.Definition {
+	var defs []meta.Definition
+	for _, n := range m {
+		if from == nil || meta.NamespaceModule(n) == from {
+			defs = append(defs, n)
+		}
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Ident() < defs[j].Ident() })
+	return defs
+}
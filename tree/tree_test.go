@@ -0,0 +1,42 @@
+package tree
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+	"github.com/freeconf/yang/parser"
+	"github.com/freeconf/yang/source"
+)
+
+var updateFlag = flag.Bool("update", false, "update golden files instead of verifying against them")
+
+func TestTree(t *testing.T) {
+	ypath := source.Dir("./testdata")
+	car := parser.RequireModule(ypath, "car")
+	ext := parser.RequireModule(ypath, "car-ext")
+	tests := []struct {
+		wtr  Wtr
+		gold string
+	}{
+		{gold: "testdata/gold/car.txt"},
+		{wtr: Wtr{Depth: 2}, gold: "testdata/gold/car-depth.txt"},
+		{wtr: Wtr{Path: "/car:car/engine"}, gold: "testdata/gold/car-path.txt"},
+		{wtr: Wtr{LineLength: 40}, gold: "testdata/gold/car-ext.txt"},
+	}
+	for _, test := range tests {
+		t.Log(test.gold)
+		m := car
+		if test.wtr.LineLength > 0 {
+			m = ext
+		}
+		var actual bytes.Buffer
+		w := test.wtr
+		w.Out = &actual
+		if err := w.Write(m); err != nil {
+			t.Fatal(err)
+		}
+		fc.Gold(t, *updateFlag, actual.Bytes(), test.gold)
+	}
+}
@@ -1,9 +1,12 @@
 package val
 
 import (
+	"encoding/base64"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -149,6 +152,30 @@ func Conv(f Format, val interface{}) (Value, error) {
 		} else {
 			return Decimal64List(x), err
 		}
+	case FmtBits:
+		if x, err := toBits(val); err != nil {
+			return nil, err
+		} else {
+			return x, err
+		}
+	case FmtBitsList:
+		if x, err := toBitsList(val); err != nil {
+			return nil, err
+		} else {
+			return x, err
+		}
+	case FmtBinary:
+		if x, err := toBinary(val); err != nil {
+			return nil, err
+		} else {
+			return Binary(x), err
+		}
+	case FmtBinaryList:
+		if x, err := toBinaryList(val); err != nil {
+			return nil, err
+		} else {
+			return BinaryList(x), err
+		}
 	case FmtAny:
 		return Any{Thing: val}, err
 	case FmtString:
@@ -742,44 +769,99 @@ func toUInt64List(val interface{}) ([]uint64, error) {
 	return nil, fmt.Errorf("cannot coerse '%T' to int64 array", val)
 }
 
-func toDecimal64(val interface{}) (float64, error) {
+// ParseDecimal64 reads lexical form of decimal64 from RFC7950 Section 9.3.1
+// exactly. It is an error if there are more fraction digits than given.
+func ParseDecimal64(s string, fractionDigits int) (Decimal64, error) {
+	d, err := parseDecimal64(s)
+	if err != nil {
+		return d, err
+	}
+	return d.Scale(fractionDigits)
+}
+
+// parseDecimal64 keeps fraction digits as they were written
+func parseDecimal64(s string) (Decimal64, error) {
+	var d Decimal64
+	digits := s
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		digits = digits[1:]
+	}
+	if point := strings.IndexRune(digits, '.'); point >= 0 {
+		d.FractionDigits = len(digits) - point - 1
+		if point == 0 || d.FractionDigits == 0 {
+			return d, fmt.Errorf("'%s' is not a decimal64", s)
+		}
+		digits = digits[:point] + digits[point+1:]
+	}
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return d, fmt.Errorf("'%s' is not a decimal64", s)
+	}
+	if d.FractionDigits > MaxFractionDigits {
+		return d, fmt.Errorf("'%s' has more than %d fraction digits", s, MaxFractionDigits)
+	}
+	if strings.HasPrefix(s, "-") {
+		digits = "-" + digits
+	}
+	var err error
+	if d.Mantissa, err = strconv.ParseInt(digits, 10, 64); err != nil {
+		return d, fmt.Errorf("'%s' does not fit in decimal64", s)
+	}
+	return d, nil
+}
+
+func toDecimal64(val interface{}) (Decimal64, error) {
 	switch x := val.(type) {
+	case Decimal64:
+		return x, nil
 	case int8:
-		return float64(x), nil
+		return Decimal64{Mantissa: int64(x)}, nil
 	case uint8:
-		return float64(x), nil
+		return Decimal64{Mantissa: int64(x)}, nil
 	case int16:
-		return float64(x), nil
+		return Decimal64{Mantissa: int64(x)}, nil
 	case uint16:
-		return float64(x), nil
+		return Decimal64{Mantissa: int64(x)}, nil
 	case int:
-		return float64(x), nil
+		return Decimal64{Mantissa: int64(x)}, nil
 	case uint:
-		return float64(x), nil
+		return toDecimal64(uint64(x))
 	case int32:
-		return float64(x), nil
+		return Decimal64{Mantissa: int64(x)}, nil
 	case uint32:
-		return float64(x), nil
+		return Decimal64{Mantissa: int64(x)}, nil
 	case uint64:
-		return float64(x), nil
+		if x > math.MaxInt64 {
+			return Decimal64{}, fmt.Errorf("%d does not fit in decimal64", x)
+		}
+		return Decimal64{Mantissa: int64(x)}, nil
 	case int64:
-		return float64(x), nil
+		return Decimal64{Mantissa: x}, nil
 	case float32:
-		return float64(x), nil
+		return parseDecimal64(strconv.FormatFloat(float64(x), 'f', -1, 32))
 	case float64:
-		return x, nil
+		// shortest decimal that is this float
+		return parseDecimal64(strconv.FormatFloat(x, 'f', -1, 64))
 	case string:
-		return strconv.ParseFloat(x, 64)
+		return parseDecimal64(x)
 	}
-	return 0, fmt.Errorf("cannot coerse '%T' to float64", val)
+	return Decimal64{}, fmt.Errorf("cannot coerse '%T' to decimal64", val)
 }
 
-func toDecimal64List(val interface{}) ([]float64, error) {
+func toDecimal64List(val interface{}) ([]Decimal64, error) {
 	switch x := val.(type) {
-	case []float64:
+	case []Decimal64:
 		return x, nil
+	case []float64:
+		l := make([]Decimal64, len(x))
+		var err error
+		for i := 0; i < len(x); i++ {
+			if l[i], err = toDecimal64(x[i]); err != nil {
+				return nil, err
+			}
+		}
+		return l, nil
 	case []interface{}:
-		l := make([]float64, len(x))
+		l := make([]Decimal64, len(x))
 		var err error
 		for i := 0; i < len(x); i++ {
 			if l[i], err = toDecimal64(x[i]); err != nil {
@@ -788,7 +870,7 @@ func toDecimal64List(val interface{}) ([]float64, error) {
 		}
 		return l, nil
 	case []string:
-		l := make([]float64, len(x))
+		l := make([]Decimal64, len(x))
 		var err error
 		for i := 0; i < len(x); i++ {
 			if l[i], err = toDecimal64(x[i]); err != nil {
@@ -799,10 +881,111 @@ func toDecimal64List(val interface{}) ([]float64, error) {
 	default:
 		// TODO: Use reflection on general array type
 		if i, notSingle := toDecimal64(val); notSingle == nil {
-			return []float64{i}, nil
+			return []Decimal64{i}, nil
 		}
 	}
-	return nil, fmt.Errorf("cannot coerse '%T' to []int", val)
+	return nil, fmt.Errorf("cannot coerse '%T' to []decimal64", val)
+}
+
+func toBits(val interface{}) (Bits, error) {
+	var labels []string
+	switch x := val.(type) {
+	case Bits:
+		return x, nil
+	case string:
+		labels = strings.Fields(x)
+	case []string:
+		labels = x
+	case []interface{}:
+		for _, item := range x {
+			s, valid := item.(string)
+			if !valid {
+				return nil, fmt.Errorf("cannot coerse '%T' to bit", item)
+			}
+			labels = append(labels, s)
+		}
+	default:
+		return nil, fmt.Errorf("cannot coerse '%T' to bits", val)
+	}
+	bits := make(Bits, len(labels))
+	for i, label := range labels {
+		bits[i] = Bit{Position: -1, Label: label}
+	}
+	return bits, nil
+}
+
+func toBitsList(val interface{}) (BitsList, error) {
+	switch x := val.(type) {
+	case BitsList:
+		return x, nil
+	case []Bits:
+		return x, nil
+	case []string:
+		l := make(BitsList, len(x))
+		var err error
+		for i := 0; i < len(x); i++ {
+			if l[i], err = toBits(x[i]); err != nil {
+				return nil, err
+			}
+		}
+		return l, nil
+	case []interface{}:
+		l := make(BitsList, len(x))
+		var err error
+		for i := 0; i < len(x); i++ {
+			if l[i], err = toBits(x[i]); err != nil {
+				return nil, err
+			}
+		}
+		return l, nil
+	default:
+		if b, notSingle := toBits(val); notSingle == nil {
+			return BitsList{b}, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot coerse '%T' to bits list", val)
+}
+
+func toBinary(val interface{}) ([]byte, error) {
+	switch x := val.(type) {
+	case []byte:
+		return x, nil
+	case Binary:
+		return x, nil
+	case string:
+		return base64.StdEncoding.DecodeString(x)
+	}
+	return nil, fmt.Errorf("cannot coerse '%T' to binary", val)
+}
+
+func toBinaryList(val interface{}) ([][]byte, error) {
+	switch x := val.(type) {
+	case [][]byte:
+		return x, nil
+	case []string:
+		l := make([][]byte, len(x))
+		var err error
+		for i := 0; i < len(x); i++ {
+			if l[i], err = toBinary(x[i]); err != nil {
+				return nil, err
+			}
+		}
+		return l, nil
+	case []interface{}:
+		l := make([][]byte, len(x))
+		var err error
+		for i := 0; i < len(x); i++ {
+			if l[i], err = toBinary(x[i]); err != nil {
+				return nil, err
+			}
+		}
+		return l, nil
+	default:
+		if b, notSingle := toBinary(val); notSingle == nil {
+			return [][]byte{b}, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot coerse '%T' to binary list", val)
 }
 
 func toBoolList(val interface{}) ([]bool, error) {
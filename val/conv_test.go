@@ -118,38 +118,95 @@ func Test_Conv(t *testing.T) {
 		{
 			F:   FmtDecimal64,
 			In:  0,
-			Out: float64(0),
+			Out: Decimal64{},
 		},
 		{
 			F:   FmtDecimal64,
 			In:  float64(99),
-			Out: float64(99),
+			Out: Decimal64{Mantissa: 99},
 		},
 		{
 			F:   FmtDecimal64,
 			In:  "99",
-			Out: float64(99),
+			Out: Decimal64{Mantissa: 99},
+		},
+		{
+			F:   FmtDecimal64,
+			In:  float64(0.1),
+			Out: Decimal64{Mantissa: 1, FractionDigits: 1},
+		},
+		{
+			F:   FmtDecimal64,
+			In:  "-3.140",
+			Out: Decimal64{Mantissa: -3140, FractionDigits: 3},
+		},
+		{
+			F:       FmtDecimal64,
+			In:      ".5",
+			Invalid: true,
+		},
+		{
+			F:       FmtDecimal64,
+			In:      "1e3",
+			Invalid: true,
 		},
 		////////////
 		{
 			F:   FmtDecimal64List,
 			In:  0,
-			Out: []float64{0},
+			Out: []Decimal64{{}},
 		},
 		{
 			F:   FmtDecimal64List,
-			In:  []float64{99, 98},
-			Out: []float64{99, 98},
+			In:  []float64{99, 98.5},
+			Out: []Decimal64{{Mantissa: 99}, {Mantissa: 985, FractionDigits: 1}},
 		},
 		{
 			F:   FmtDecimal64List,
 			In:  []string{"99", "98"},
-			Out: []float64{99, 98},
+			Out: []Decimal64{{Mantissa: 99}, {Mantissa: 98}},
 		},
 		{
 			F:   FmtDecimal64List,
 			In:  []interface{}{"99", 98},
-			Out: []float64{99, 98},
+			Out: []Decimal64{{Mantissa: 99}, {Mantissa: 98}},
+		},
+		////////////
+		{
+			F:   FmtBits,
+			In:  "a  b",
+			Out: Bits{{Position: -1, Label: "a"}, {Position: -1, Label: "b"}},
+		},
+		{
+			F:   FmtBits,
+			In:  []interface{}{"a"},
+			Out: Bits{{Position: -1, Label: "a"}},
+		},
+		{
+			F:       FmtBits,
+			In:      99,
+			Invalid: true,
+		},
+		{
+			F:   FmtBitsList,
+			In:  []string{"a b", ""},
+			Out: BitsList{{{Position: -1, Label: "a"}, {Position: -1, Label: "b"}}, {}},
+		},
+		////////////
+		{
+			F:   FmtBinary,
+			In:  "aGk=",
+			Out: []byte("hi"),
+		},
+		{
+			F:       FmtBinary,
+			In:      "not base64!",
+			Invalid: true,
+		},
+		{
+			F:   FmtBinaryList,
+			In:  []interface{}{"aGk=", ""},
+			Out: [][]byte{[]byte("hi"), {}},
 		},
 	}
 	for _, test := range tests {
@@ -169,3 +226,72 @@ func Test_Conv(t *testing.T) {
 		}
 	}
 }
+
+func TestDecimal64(t *testing.T) {
+	tests := []struct {
+		in        string
+		digits    int
+		canonical string
+		invalid   bool
+	}{
+		{in: "0", digits: 1, canonical: "0.0"},
+		{in: "+1.50", digits: 2, canonical: "1.5"},
+		{in: "-0.05", digits: 3, canonical: "-0.05"},
+		{in: "0.1", digits: 18, canonical: "0.1"},
+		{in: "92233720368547758.07", digits: 2, canonical: "92233720368547758.07"},
+		{in: "-9.223372036854775808", digits: 18, canonical: "-9.223372036854775808"},
+		{in: "10", digits: 18, invalid: true},
+		{in: "1.234", digits: 2, invalid: true},
+		{in: "1.", digits: 2, invalid: true},
+		{in: "- 1", digits: 2, invalid: true},
+	}
+	for _, test := range tests {
+		d, err := ParseDecimal64(test.in, test.digits)
+		if test.invalid {
+			if err == nil {
+				t.Errorf("%s expected invalid, got %v", test.in, d)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		fc.AssertEqual(t, test.digits, d.FractionDigits)
+		fc.AssertEqual(t, test.canonical, d.String())
+	}
+
+	a, _ := ParseDecimal64("0.1", 18)
+	b, _ := ParseDecimal64("0.10", 2)
+	c, _ := ParseDecimal64("-0.2", 1)
+	fc.AssertEqual(t, 0, a.Compare(b))
+	fc.AssertEqual(t, 1, a.Compare(c))
+	fc.AssertEqual(t, -1, c.Compare(b))
+	fc.AssertEqual(t, 0.1, a.Float64())
+	fc.AssertEqual(t, "[0.1 -0.2]", Decimal64List{a, c}.String())
+}
+
+func TestBits(t *testing.T) {
+	b := Bits{{Position: 0, Label: "a"}, {Position: 3, Label: "c"}}
+	fc.AssertEqual(t, "a c", b.String())
+	fc.AssertEqual(t, true, b.IsSet("c"))
+	fc.AssertEqual(t, false, b.IsSet("b"))
+	fc.AssertEqual(t, 0, b.Compare(Bits{{Label: "a"}, {Label: "c"}}))
+}
+
+func TestBinary(t *testing.T) {
+	b := Binary("hi")
+	fc.AssertEqual(t, "aGk=", b.String())
+	fc.AssertEqual(t, -1, b.Compare(Binary("hj")))
+	fc.AssertEqual(t, "aGk=,", BinaryList{[]byte("hi"), {}}.String())
+}
+
+func TestIdentRefCompare(t *testing.T) {
+	x := IdentRef{Base: "b", Label: "x"}
+	m1 := IdentRef{Base: "b", Label: "x", Module: "m1"}
+	m2 := IdentRef{Base: "b", Label: "x", Module: "m2"}
+	fc.AssertEqual(t, -1, m1.Compare(m2))
+	fc.AssertEqual(t, -1, x.Compare(m1))
+	fc.AssertEqual(t, 1, m2.Compare(x))
+	fc.AssertEqual(t, 0, m1.Compare(m1))
+}
@@ -0,0 +1,127 @@
+package val
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse only accepts the lexical representation of a built-in type from
+// RFC7950 Section 9 unlike Conv that coerses anything close enough. String of
+// value is the canonical representation so parsed values compare correctly.
+// List formats are a list of one item. Enumerations, identityrefs and unions
+// need their type to be parsed, see node.ParseValue.
+func Parse(f Format, s string) (Value, error) {
+	item, err := parseItem(f.Single(), s)
+	if err != nil {
+		return nil, err
+	}
+	if f.IsList() {
+		return Conv(f, []interface{}{item.Value()})
+	}
+	return item, nil
+}
+
+func parseItem(f Format, s string) (Value, error) {
+	switch f {
+	case FmtString:
+		return String(s), nil
+	case FmtBool:
+		switch s {
+		case "true":
+			return Bool(true), nil
+		case "false":
+			return Bool(false), nil
+		}
+		return nil, fmt.Errorf("invalid boolean '%s'", s)
+	case FmtInt8, FmtInt16, FmtInt32, FmtInt64:
+		n, err := parseInt(s, f)
+		if err != nil {
+			return nil, err
+		}
+		switch f {
+		case FmtInt8:
+			return Int8(n), nil
+		case FmtInt16:
+			return Int16(n), nil
+		case FmtInt32:
+			return Int32(n), nil
+		}
+		return Int64(n), nil
+	case FmtUInt8, FmtUInt16, FmtUInt32, FmtUInt64:
+		n, err := parseUInt(s, f)
+		if err != nil {
+			return nil, err
+		}
+		switch f {
+		case FmtUInt8:
+			return UInt8(n), nil
+		case FmtUInt16:
+			return UInt16(n), nil
+		case FmtUInt32:
+			return UInt32(n), nil
+		}
+		return UInt64(n), nil
+	case FmtDecimal64:
+		return parseDecimal64(s)
+	case FmtBinary:
+		b, err := base64.StdEncoding.Strict().DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid binary '%s'", s)
+		}
+		return Binary(b), nil
+	case FmtBits:
+		return toBits(s)
+	}
+	return nil, fmt.Errorf("cannot parse %s without its type", f)
+}
+
+var intBits = map[Format]int{
+	FmtInt8:   8,
+	FmtInt16:  16,
+	FmtInt32:  32,
+	FmtInt64:  64,
+	FmtUInt8:  8,
+	FmtUInt16: 16,
+	FmtUInt32: 32,
+	FmtUInt64: 64,
+}
+
+// parseInt is from RFC7950 Section 9.2.1, an optional sign followed by
+// decimal digits. Hex and octal are only for defaults in modules.
+func parseInt(s string, f Format) (int64, error) {
+	if !isDigits(unsigned(s)) {
+		return 0, fmt.Errorf("invalid %s '%s'", f, s)
+	}
+	n, err := strconv.ParseInt(s, 10, intBits[f])
+	if err != nil {
+		return 0, fmt.Errorf("%s '%s' out of range", f, s)
+	}
+	return n, nil
+}
+
+func parseUInt(s string, f Format) (uint64, error) {
+	digits := unsigned(s)
+	if !isDigits(digits) {
+		return 0, fmt.Errorf("invalid %s '%s'", f, s)
+	}
+	// only zero can have a minus sign
+	isNegative := strings.HasPrefix(s, "-") && strings.Trim(digits, "0") != ""
+	n, err := strconv.ParseUint(digits, 10, intBits[f])
+	if err != nil || isNegative {
+		return 0, fmt.Errorf("%s '%s' out of range", f, s)
+	}
+	return n, nil
+}
+
+func unsigned(s string) string {
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		return s[1:]
+	}
+	return s
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.TrimLeft(s, "0123456789") == ""
+}
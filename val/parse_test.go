@@ -0,0 +1,54 @@
+package val
+
+import (
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		f         Format
+		in        string
+		canonical string
+	}{
+		{f: FmtInt32, in: "+007", canonical: "7"},
+		{f: FmtInt32, in: "-0", canonical: "0"},
+		{f: FmtInt8, in: "-128", canonical: "-128"},
+		{f: FmtInt8, in: "128"},
+		{f: FmtInt32, in: "0x10"},
+		{f: FmtInt32, in: " 1"},
+		{f: FmtInt32, in: "1.0"},
+		{f: FmtInt32, in: "+-1"},
+		{f: FmtUInt8, in: "255", canonical: "255"},
+		{f: FmtUInt8, in: "-00", canonical: "0"},
+		{f: FmtUInt8, in: "-1"},
+		{f: FmtUInt64, in: "18446744073709551615", canonical: "18446744073709551615"},
+		{f: FmtBool, in: "true", canonical: "true"},
+		{f: FmtBool, in: "True"},
+		{f: FmtBool, in: "1"},
+		{f: FmtDecimal64, in: "+1.50", canonical: "1.5"},
+		{f: FmtDecimal64, in: ".5"},
+		{f: FmtString, in: " x ", canonical: " x "},
+		{f: FmtBinary, in: "aGk=", canonical: "aGk="},
+		{f: FmtBinary, in: "aGk"},
+		{f: FmtBits, in: " a  b ", canonical: "a b"},
+		{f: FmtInt32List, in: "+1", canonical: "[1]"},
+		{f: FmtEnum, in: "a"},
+	}
+	for _, test := range tests {
+		v, err := Parse(test.f, test.in)
+		if test.canonical == "" {
+			if err == nil {
+				t.Errorf("%s '%s' expected error, got %v", test.f, test.in, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s '%s' %s", test.f, test.in, err)
+			continue
+		}
+		fc.AssertEqual(t, test.f, v.Format())
+		fc.AssertEqual(t, test.canonical, v.String())
+	}
+}
@@ -1,7 +1,10 @@
 package val
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -503,44 +506,135 @@ func (x UInt64List) Item(i int) Value {
 
 ///////////////////////
 
-type Decimal64 float64
+// Decimal64 is an exact decimal number. It is Mantissa divided by 10 to the
+// power of FractionDigits so 3.14 in a type with fraction-digits 2 is
+// Decimal64{Mantissa: 314, FractionDigits: 2}. FractionDigits is from the
+// type of the leaf, values converted without a type have as few as needed.
+type Decimal64 struct {
+	Mantissa       int64
+	FractionDigits int
+}
 
 func (Decimal64) Format() Format {
 	return FmtDecimal64
 }
 
+// String is canonical form from RFC7950 Section 9.3.2, there is always one
+// digit before and after the decimal point and no other leading or trailing
+// zeros.
 func (x Decimal64) String() string {
-	return fmt.Sprintf("%f", float64(x))
+	digits := strconv.FormatUint(abs(x.Mantissa), 10)
+	if len(digits) <= x.FractionDigits {
+		digits = strings.Repeat("0", x.FractionDigits-len(digits)+1) + digits
+	}
+	point := len(digits) - x.FractionDigits
+	frac := strings.TrimRight(digits[point:], "0")
+	if frac == "" {
+		frac = "0"
+	}
+	sign := ""
+	if x.Mantissa < 0 {
+		sign = "-"
+	}
+	return sign + digits[:point] + "." + frac
 }
 
 func (x Decimal64) Value() interface{} {
-	return float64(x)
+	return x
+}
+
+// MarshalJSON is canonical form, which is also a JSON number, so nothing is
+// lost by converting to float
+func (x Decimal64) MarshalJSON() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// Float64 is closest float to decimal
+func (x Decimal64) Float64() float64 {
+	f, _ := strconv.ParseFloat(x.String(), 64)
+	return f
+}
+
+// Scale is same value with different fraction digits. It is an error if
+// digits would be lost or value does not fit.
+func (x Decimal64) Scale(fractionDigits int) (Decimal64, error) {
+	if fractionDigits < 0 || fractionDigits > MaxFractionDigits {
+		return x, fmt.Errorf("fraction-digits %d not between 0 and %d", fractionDigits, MaxFractionDigits)
+	}
+	scaled := Decimal64{Mantissa: x.Mantissa, FractionDigits: fractionDigits}
+	if fractionDigits < x.FractionDigits {
+		p := pow10[x.FractionDigits-fractionDigits]
+		if x.Mantissa%p != 0 {
+			return x, fmt.Errorf("%s has more than %d fraction digits", x, fractionDigits)
+		}
+		scaled.Mantissa = x.Mantissa / p
+	} else if fractionDigits > x.FractionDigits {
+		p := pow10[fractionDigits-x.FractionDigits]
+		if x.Mantissa > math.MaxInt64/p || x.Mantissa < math.MinInt64/p {
+			return x, fmt.Errorf("%s does not fit in decimal64 with %d fraction digits", x, fractionDigits)
+		}
+		scaled.Mantissa = x.Mantissa * p
+	}
+	return scaled, nil
 }
 
 func (x Decimal64) Compare(b Comparable) int {
-	c := float64(x) - b.Value().(float64)
-	if c < 0 {
+	y := b.Value().(Decimal64)
+	xi, xf := x.parts()
+	yi, yf := y.parts()
+	switch {
+	case xi < yi:
 		return -1
-	} else if c > 0 {
+	case xi > yi:
+		return 1
+	case xf < yf:
+		return -1
+	case xf > yf:
 		return 1
 	}
 	return 0
 }
 
+// parts are whole number and fraction scaled to max fraction digits so
+// decimals with different fraction digits can be compared exactly
+func (x Decimal64) parts() (int64, int64) {
+	p := pow10[x.FractionDigits]
+	return x.Mantissa / p, (x.Mantissa % p) * pow10[MaxFractionDigits-x.FractionDigits]
+}
+
+// MaxFractionDigits is most fraction digits a decimal64 type may have
+const MaxFractionDigits = 18
+
+var pow10 = [...]int64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+func abs(x int64) uint64 {
+	if x < 0 {
+		return uint64(-(x + 1)) + 1
+	}
+	return uint64(x)
+}
+
 ///////////////////////
 
-type Decimal64List []float64
+type Decimal64List []Decimal64
 
 func (Decimal64List) Format() Format {
 	return FmtDecimal64List
 }
 
 func (x Decimal64List) String() string {
-	return fmt.Sprintf("%v", []float64(x))
+	s := make([]string, len(x))
+	for i, d := range x {
+		s[i] = d.String()
+	}
+	return "[" + strings.Join(s, " ") + "]"
 }
 
 func (x Decimal64List) Value() interface{} {
-	return []float64(x)
+	return []Decimal64(x)
 }
 
 func (x Decimal64List) Len() int {
@@ -548,7 +642,7 @@ func (x Decimal64List) Len() int {
 }
 
 func (x Decimal64List) Item(i int) Value {
-	return Decimal64(x[i])
+	return x[i]
 }
 
 ///////////////////////
@@ -665,9 +759,140 @@ func (x EnumList) Add(e string) EnumList {
 
 ///////////////////////
 
+// Bit is one bit of a bits type
+type Bit struct {
+	Position int
+	Label    string
+}
+
+// Bits is value of a bits type, the bits that are set. When converted with
+// the type, bits are in order of their position which is the canonical order
+// from RFC7950 Section 9.7.2. Otherwise they are in the order given and
+// position is -1.
+type Bits []Bit
+
+func (Bits) Format() Format {
+	return FmtBits
+}
+
+// String is labels separated by a space
+func (x Bits) String() string {
+	return strings.Join(x.Labels(), " ")
+}
+
+func (x Bits) Value() interface{} {
+	return x
+}
+
+func (x Bits) Compare(b Comparable) int {
+	return strings.Compare(x.String(), b.Value().(Bits).String())
+}
+
+func (x Bits) Labels() []string {
+	l := make([]string, len(x))
+	for i := range x {
+		l[i] = x[i].Label
+	}
+	return l
+}
+
+// IsSet is the test of the XPath bit-is-set function from RFC7950 Section
+// 10.6.1
+func (x Bits) IsSet(label string) bool {
+	for _, b := range x {
+		if b.Label == label {
+			return true
+		}
+	}
+	return false
+}
+
+///////////////////////
+
+type BitsList []Bits
+
+func (BitsList) Format() Format {
+	return FmtBitsList
+}
+
+func (x BitsList) String() string {
+	s := make([]string, len(x))
+	for i, b := range x {
+		s[i] = b.String()
+	}
+	return strings.Join(s, ",")
+}
+
+func (x BitsList) Value() interface{} {
+	return x
+}
+
+func (x BitsList) Len() int {
+	return len(x)
+}
+
+func (x BitsList) Item(i int) Value {
+	return x[i]
+}
+
+///////////////////////
+
+// Binary is value of binary type
+type Binary []byte
+
+func (Binary) Format() Format {
+	return FmtBinary
+}
+
+// String is base64 encoding from RFC7950 Section 9.8.2
+func (x Binary) String() string {
+	return base64.StdEncoding.EncodeToString(x)
+}
+
+func (x Binary) Value() interface{} {
+	return []byte(x)
+}
+
+func (x Binary) Compare(b Comparable) int {
+	return bytes.Compare(x, b.Value().([]byte))
+}
+
+///////////////////////
+
+type BinaryList [][]byte
+
+func (BinaryList) Format() Format {
+	return FmtBinaryList
+}
+
+func (x BinaryList) String() string {
+	s := make([]string, len(x))
+	for i, b := range x {
+		s[i] = Binary(b).String()
+	}
+	return strings.Join(s, ",")
+}
+
+func (x BinaryList) Value() interface{} {
+	return [][]byte(x)
+}
+
+func (x BinaryList) Len() int {
+	return len(x)
+}
+
+func (x BinaryList) Item(i int) Value {
+	return Binary(x[i])
+}
+
+///////////////////////
+
 type IdentRef struct {
 	Base  string
 	Label string
+
+	// Module identity is defined in, empty if it is not known
+	Module string
 }
 
 func (IdentRef) Format() Format {
@@ -678,13 +903,26 @@ func (x IdentRef) String() string {
 	return x.Label
 }
 
+// Qualified is label with module name in the form from RFC7951 Section 6.8 or
+// just label if module is not known
+func (x IdentRef) Qualified() string {
+	if x.Module == "" {
+		return x.Label
+	}
+	return x.Module + ":" + x.Label
+}
+
+// Compare always compares modules so ordering is consistent. Values from
+// node.NewValue have module filled in.
 func (x IdentRef) Compare(b Comparable) int {
 	y := b.(IdentRef)
-	baseCmp := strings.Compare(x.Base, y.Base)
-	if baseCmp == 0 {
-		return strings.Compare(x.Label, y.Label)
+	if c := strings.Compare(x.Base, y.Base); c != 0 {
+		return c
+	}
+	if c := strings.Compare(x.Label, y.Label); c != 0 {
+		return c
 	}
-	return baseCmp
+	return strings.Compare(x.Module, y.Module)
 }
 
 func (x IdentRef) Value() interface{} {
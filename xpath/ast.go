@@ -54,7 +54,7 @@ func num(s string) (interface{}, error) {
 }
 
 func literal(s string) interface{} {
-	cutset := "'"
+	cutset := "'\""
 	return strings.TrimRight(strings.TrimLeft(s, cutset), cutset)
 }
 
@@ -87,23 +87,19 @@ func (l *lexer) error(msg string) stateFunc {
 }
 
 func (l *lexer) acceptLiteral(ttype int) bool {
-	first := true
+	quote := l.next()
+	if quote != '\'' && quote != '"' {
+		l.backup()
+		return false
+	}
 	for {
 		switch l.next() {
 		case eof:
 			return false
-		case '\'':
-			if !first {
-				l.emit(ttype)
-				return true
-			}
-		default:
-			if first {
-				l.backup()
-				return false
-			}
+		case quote:
+			l.emit(ttype)
+			return true
 		}
-		first = false
 	}
 }
 
@@ -131,7 +127,7 @@ func (l *lexer) acceptAlphaNumeric(ttype int) bool {
 	for {
 		r := l.next()
 		// TODO: review spec on legal chars
-		if !unicode.IsDigit(r) && !unicode.IsLetter(r) && !(r == '-') && !(r == '_') && !(r == '.') {
+		if !unicode.IsDigit(r) && !unicode.IsLetter(r) && !(r == '-') && !(r == '_') && !(r == '.') && !(r == ':') {
 			l.backup()
 			if accepted {
 				l.emit(ttype)
@@ -180,6 +176,18 @@ func (l *lexer) acceptOperator() bool {
 	return true
 }
 
+// acceptPunct is for the rest of XPath 1.0 so any expression can be
+// tokenized even though only paths can be parsed
+func (l *lexer) acceptPunct() bool {
+	switch l.next() {
+	case '(', ')', '[', ']', ',', '|', '*', '+', '-', '@', '$':
+		l.emit(token_punct)
+		return true
+	}
+	l.backup()
+	return false
+}
+
 func lexBegin(l *lexer) stateFunc {
 	if l.isEnd() {
 		return nil
@@ -205,6 +213,14 @@ func lexBegin(l *lexer) stateFunc {
 	if l.acceptToken(token_number) {
 		return lexBegin
 	}
+
+	if l.acceptLiteral(token_literal) {
+		return lexBegin
+	}
+
+	if l.acceptPunct() {
+		return lexBegin
+	}
 	return l.error("unknown statement")
 }
 
@@ -37,6 +37,7 @@ const token_literal = 57347
 const token_number = 57348
 const token_operator = 57349
 const kywd_slash = 57350
+const token_punct = 57351
 
 var yyToknames = [...]string{
 	"$end",
@@ -47,6 +48,7 @@ var yyToknames = [...]string{
 	"token_number",
 	"token_operator",
 	"kywd_slash",
+	"token_punct",
 }
 var yyStatenames = [...]string{}
 
@@ -105,7 +107,7 @@ var yyTok1 = [...]int{
 }
 var yyTok2 = [...]int{
 
-	2, 3, 4, 5, 6, 7, 8,
+	2, 3, 4, 5, 6, 7, 8, 9,
 }
 var yyTok3 = [...]int{
 	0,
@@ -450,7 +452,7 @@ yydefault:
 
 	case 3:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:43
+//line parser.y:46
 		{
 			abs := &AbsolutePath{}
 			abs.Append(yyVAL.stack.pop())
@@ -458,20 +460,20 @@ yydefault:
 		}
 	case 5:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line parser.y:51
+//line parser.y:54
 		{
 			p := yyVAL.stack.pop()
 			yyVAL.stack.peek().Append(p)
 		}
 	case 8:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line parser.y:61
+//line parser.y:64
 		{
 			yyVAL.stack.push(&Segment{Ident: yyDollar[1].token})
 		}
 	case 9:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:64
+//line parser.y:67
 		{
 			n, err := num(yyDollar[3].token)
 			if err != nil {
@@ -482,7 +484,7 @@ yydefault:
 		}
 	case 10:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line parser.y:72
+//line parser.y:75
 		{
 			yyVAL.stack.push(&Segment{Ident: yyDollar[1].token, Expr: &Operator{Oper: yyDollar[2].token, Lhs: literal(yyDollar[3].token)}})
 		}
@@ -33,6 +33,9 @@ func (l *lexer) Error(e string) {
 
 %token kywd_slash
 
+/* only from Tokens, not part of path grammar */
+%token <token> token_punct
+
 %%
 
 path :
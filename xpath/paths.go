@@ -0,0 +1,183 @@
+package xpath
+
+import (
+	"strings"
+	"unicode"
+)
+
+// LocationPath is a path to nodes in an expression like "../a/b" with the
+// predicates removed
+type LocationPath struct {
+	Absolute bool
+	Steps    []string
+}
+
+func (p *LocationPath) String() string {
+	s := strings.Join(p.Steps, "/")
+	if p.Absolute {
+		return "/" + s
+	}
+	return s
+}
+
+// LocationPaths finds the paths in any XPath 1.0 expression like a must or
+// when so they can be checked against the schema. Unlike Parse, expression
+// does not have to be a path this package can evaluate. Paths using axes,
+// wildcards or that start from the result of a function like current() are
+// skipped.
+func LocationPaths(expr string) ([]*LocationPath, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	var paths []*LocationPath
+	// operand is true after a path, literal, number or ')' to tell operators
+	// like "and" or "*" apart from names and wildcards
+	operand := false
+	i := 0
+	for i < len(tokens) {
+		t := tokens[i]
+		switch {
+		case t.typ == token_literal || isNumber(t):
+			i++
+		case t.typ == token_operator:
+			i++
+			operand = false
+			continue
+		case t.typ == token_punct && t.val == ")":
+			i++
+			if i < len(tokens) && tokens[i].typ == kywd_slash {
+				// path relative to result of function
+				_, _, i = scanPath(tokens, i)
+			}
+		case t.typ == token_punct && t.val == "]":
+			i++
+		case t.typ == token_punct && t.val == "$":
+			i += 2
+		case t.typ == token_punct && t.val == "*" && operand:
+			i++
+			operand = false
+			continue
+		case t.typ == token_name && operand && isOperatorName(t.val):
+			i++
+			operand = false
+			continue
+		case t.typ == token_name && isPunct(tokens, i+1, "("):
+			// function call
+			i++
+			operand = false
+			continue
+		case t.typ == token_name || t.typ == kywd_slash || isPunct(tokens, i, "*") || isPunct(tokens, i, "@"):
+			var p *LocationPath
+			var ok bool
+			p, ok, i = scanPath(tokens, i)
+			if ok {
+				paths = append(paths, p)
+			}
+		default:
+			// '(', '[', ',', '|', '+' and '-'
+			i++
+			operand = false
+			continue
+		}
+		operand = true
+	}
+	return paths, nil
+}
+
+func tokenize(expr string) ([]Token, error) {
+	l := lex(expr)
+	var tokens []Token
+	for {
+		t, _ := l.nextToken()
+		if l.lastError != nil {
+			return nil, l.lastError
+		}
+		if t.typ == ParseEnd {
+			return tokens, nil
+		}
+		tokens = append(tokens, t)
+	}
+}
+
+// scanPath reads location path starting at i and returns the index after
+// the path.  Ok is false when path cannot be checked.
+func scanPath(tokens []Token, i int) (*LocationPath, bool, int) {
+	p := &LocationPath{}
+	ok := true
+	if tokens[i].typ == kywd_slash {
+		p.Absolute = true
+		i++
+	}
+	for i < len(tokens) {
+		t := tokens[i]
+		if t.typ == kywd_slash || isPunct(tokens, i, "*") || isPunct(tokens, i, "@") {
+			// descendants, wildcard or attribute
+			ok = false
+			i++
+			continue
+		}
+		if t.typ != token_name || isNumber(t) {
+			break
+		}
+		if strings.Contains(t.val, "::") {
+			// axis
+			ok = false
+		}
+		p.Steps = append(p.Steps, t.val)
+		i++
+		if isPunct(tokens, i, "(") {
+			// node test like text()
+			ok = false
+			i = skipBrackets(tokens, i, "(", ")")
+		}
+		for isPunct(tokens, i, "[") {
+			i = skipBrackets(tokens, i, "[", "]")
+		}
+		if i >= len(tokens) || tokens[i].typ != kywd_slash {
+			break
+		}
+		i++
+	}
+	return p, ok && len(p.Steps) > 0, i
+}
+
+func skipBrackets(tokens []Token, i int, open string, close string) int {
+	depth := 0
+	for ; i < len(tokens); i++ {
+		if tokens[i].typ != token_punct {
+			continue
+		}
+		switch tokens[i].val {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return i
+}
+
+func isPunct(tokens []Token, i int, punct string) bool {
+	return i < len(tokens) && tokens[i].typ == token_punct && tokens[i].val == punct
+}
+
+// isNumber because lexer reads numbers with a sign or after a name as names
+func isNumber(t Token) bool {
+	if t.typ == token_number {
+		return true
+	}
+	if t.typ != token_name {
+		return false
+	}
+	digits := strings.TrimPrefix(t.val, "-")
+	return len(digits) > 0 && unicode.IsDigit(rune(digits[0]))
+}
+
+// isOperatorName includes "-" because lexer reads it as a name
+func isOperatorName(name string) bool {
+	return name == "and" || name == "or" || name == "div" || name == "mod" || name == "-"
+}
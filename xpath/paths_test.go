@@ -0,0 +1,39 @@
+package xpath
+
+import (
+	"testing"
+
+	"github.com/freeconf/yang/fc"
+)
+
+func TestLocationPaths(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected []string
+	}{
+		{expr: "a", expected: []string{"a"}},
+		{expr: "../a/b = 'x/y'", expected: []string{"../a/b"}},
+		{expr: `../a != "x/y"`, expected: []string{"../a"}},
+		{expr: "/x:a[name = current()/../b]/c", expected: []string{"/x:a/c"}},
+		{expr: "count(../a) > 1 and b-c div 2", expected: []string{"../a", "b-c"}},
+		{expr: "current()/../a", expected: nil},
+		{expr: ". != 'true' or ../agent-id", expected: []string{".", "../agent-id"}},
+		{expr: "a * 2 >= ../*", expected: []string{"a"}},
+		{expr: "a - 1 > -2 | b", expected: []string{"a", "b"}},
+		{expr: "derived-from-or-self(type, 'x:y')", expected: []string{"type"}},
+		{expr: "child::a or //b or @c or $d", expected: nil},
+	}
+	for _, test := range tests {
+		paths, err := LocationPaths(test.expr)
+		fc.AssertEqual(t, nil, err)
+		var actual []string
+		for _, p := range paths {
+			actual = append(actual, p.String())
+		}
+		if !fc.AssertEqual(t, test.expected, actual) {
+			t.Log(test.expr)
+		}
+	}
+	_, err := LocationPaths("a = 'x")
+	fc.AssertEqual(t, true, err != nil)
+}